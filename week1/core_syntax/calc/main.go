@@ -0,0 +1,36 @@
+// calc 是基于 expr 包的命令行计算器。
+//
+// 不带参数时进入交互模式:
+//
+//	go run ./week1/core_syntax/calc
+//
+// 带参数时依次计算每个参数并输出结果，参数之间共享变量和函数:
+//
+//	go run ./week1/core_syntax/calc "r = 2" "pi * r^2"
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mag1cFall/go-get-started/week1/core_syntax/expr"
+)
+
+func main() {
+	env := expr.NewEnv()
+	if len(os.Args) > 1 {
+		for _, src := range os.Args[1:] {
+			v, err := env.Eval(src)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "错误:", err)
+				os.Exit(1)
+			}
+			fmt.Println(v)
+		}
+		return
+	}
+	if err := expr.RunREPL(os.Stdin, os.Stdout, env); err != nil {
+		fmt.Fprintln(os.Stderr, "读取输入失败:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,87 @@
+package expr
+
+import (
+	"strings"
+)
+
+// Node 是抽象语法树的节点。String 返回完全加上括号的形式，可以直观地看出运算的优先级和结合性。
+type Node interface {
+	Pos() int
+	String() string
+}
+
+// NumberLit 是数字字面量
+type NumberLit struct {
+	P     int
+	Value Value
+}
+
+// Var 是变量引用
+type Var struct {
+	P    int
+	Name string
+}
+
+// Unary 是一元运算 -x 或 +x
+type Unary struct {
+	P  int
+	Op TokenKind
+	X  Node
+}
+
+// Binary 是二元运算 x op y
+type Binary struct {
+	P    int // 运算符的位置，出错时指向运算符
+	Op   TokenKind
+	X, Y Node
+}
+
+// Call 是函数调用 name(args...)
+type Call struct {
+	P    int
+	Name string
+	Args []Node
+}
+
+// AssignStmt 是变量赋值 name = x
+type AssignStmt struct {
+	P    int
+	Name string
+	X    Node
+}
+
+// FuncDef 是函数定义 name(params...) = body
+type FuncDef struct {
+	P      int
+	Name   string
+	Params []string
+	Body   Node
+}
+
+func (n *NumberLit) Pos() int  { return n.P }
+func (n *Var) Pos() int        { return n.P }
+func (n *Unary) Pos() int      { return n.P }
+func (n *Binary) Pos() int     { return n.P }
+func (n *Call) Pos() int       { return n.P }
+func (n *AssignStmt) Pos() int { return n.P }
+func (n *FuncDef) Pos() int    { return n.P }
+
+func (n *NumberLit) String() string { return n.Value.String() }
+func (n *Var) String() string       { return n.Name }
+func (n *Unary) String() string     { return "(" + n.Op.String() + n.X.String() + ")" }
+func (n *Binary) String() string {
+	return "(" + n.X.String() + " " + n.Op.String() + " " + n.Y.String() + ")"
+}
+
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, a := range n.Args {
+		args[i] = a.String()
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (n *AssignStmt) String() string { return n.Name + " = " + n.X.String() }
+func (n *FuncDef) String() string {
+	return n.Name + "(" + strings.Join(n.Params, ", ") + ") = " + n.Body.String()
+}
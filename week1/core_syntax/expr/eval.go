@@ -0,0 +1,368 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrDivideByZero 表示除以零、对零取余或求零的负数次幂。core_syntax 中的 divide 也返回这个错误
+	ErrDivideByZero = errors.New("除数不能为零")
+	// ErrUndefined 表示使用了未定义的变量或函数
+	ErrUndefined = errors.New("未定义")
+	// ErrArgCount 表示调用函数时参数个数不对
+	ErrArgCount = errors.New("参数个数不对")
+	// ErrOverflow 表示整数运算的结果超出了 int64 的范围
+	ErrOverflow = errors.New("整数溢出")
+	// ErrRecursion 表示函数调用嵌套太深，通常是函数直接或间接地调用了自己
+	ErrRecursion = errors.New("函数调用层数过深")
+)
+
+// maxDepth 是自定义函数的最大嵌套调用层数。表达式中没有条件判断，递归永远不会结束，必须设上限。
+const maxDepth = 256
+
+// EvalError 是求值时的错误，Pos 指向出错的运算符或函数调用
+type EvalError struct {
+	Pos int
+	Err error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("计算错误 (第 %d 列): %v", e.Pos, e.Err)
+}
+
+func (e *EvalError) Unwrap() error { return e.Err }
+
+// builtin 是内置函数，arity 为 -1 表示接受一个或多个参数
+type builtin struct {
+	arity int
+	fn    func(args []Value) (Value, error)
+}
+
+// float1 把 float64 -> float64 的数学函数包装为内置函数
+func float1(f func(float64) float64) builtin {
+	return builtin{1, func(args []Value) (Value, error) { return Float(f(args[0].Float64())), nil }}
+}
+
+// extremum 返回 min 或 max：参数全是整数时结果是整数
+func extremum(better func(a, b float64) bool) builtin {
+	return builtin{-1, func(args []Value) (Value, error) {
+		best := args[0]
+		for _, a := range args[1:] {
+			if better(a.Float64(), best.Float64()) {
+				best = a
+			}
+		}
+		if slices.ContainsFunc(args, Value.IsFloat) {
+			return Float(best.Float64()), nil
+		}
+		return best, nil
+	}}
+}
+
+var builtins = map[string]builtin{
+	"abs": {1, func(args []Value) (Value, error) {
+		if i, ok := args[0].Int64(); ok {
+			return neg(args[0], i < 0)
+		}
+		return Float(math.Abs(args[0].f)), nil
+	}},
+	"sqrt": {1, func(args []Value) (Value, error) {
+		if x := args[0].Float64(); x < 0 {
+			return Value{}, fmt.Errorf("sqrt 的参数不能为负数, 得到 %v", args[0])
+		}
+		return Float(math.Sqrt(args[0].Float64())), nil
+	}},
+	"floor": float1(math.Floor),
+	"ceil":  float1(math.Ceil),
+	"round": float1(math.Round),
+	"int": {1, func(args []Value) (Value, error) {
+		f := math.Trunc(args[0].Float64())
+		if f < math.MinInt64 || f >= math.MaxInt64 || math.IsNaN(f) {
+			return Value{}, fmt.Errorf("%w: %v 无法转换为整数", ErrOverflow, args[0])
+		}
+		return Int(int64(f)), nil
+	}},
+	"float": {1, func(args []Value) (Value, error) { return Float(args[0].Float64()), nil }},
+	"min":   extremum(func(a, b float64) bool { return a < b }),
+	"max":   extremum(func(a, b float64) bool { return a > b }),
+}
+
+// Env 保存变量和自定义函数，同一个 Env 上的多次求值共享它们
+type Env struct {
+	vars  map[string]Value
+	funcs map[string]*FuncDef
+	depth int
+}
+
+// NewEnv 创建求值环境，预先定义了常量 pi 和 e
+func NewEnv() *Env {
+	return &Env{
+		vars:  map[string]Value{"pi": Float(math.Pi), "e": Float(math.E)},
+		funcs: map[string]*FuncDef{},
+	}
+}
+
+// Set 设置变量
+func (e *Env) Set(name string, v Value) { e.vars[name] = v }
+
+// Get 读取变量
+func (e *Env) Get(name string) (Value, bool) {
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Vars 返回所有变量名，按字母排序
+func (e *Env) Vars() []string { return slices.Sorted(maps.Keys(e.vars)) }
+
+// Funcs 返回所有自定义函数的定义，按函数名排序
+func (e *Env) Funcs() []*FuncDef {
+	defs := slices.Collect(maps.Values(e.funcs))
+	slices.SortFunc(defs, func(a, b *FuncDef) int { return strings.Compare(a.Name, b.Name) })
+	return defs
+}
+
+// Eval 解析并执行一条语句。赋值语句返回被赋的值，函数定义返回 0。
+func (e *Env) Eval(src string) (Value, error) {
+	n, err := Parse(src)
+	if err != nil {
+		return Value{}, err
+	}
+	return e.Run(n)
+}
+
+// Run 执行已经解析好的语句
+func (e *Env) Run(n Node) (Value, error) {
+	switch n := n.(type) {
+	case *AssignStmt:
+		v, err := e.eval(n.X, nil)
+		if err != nil {
+			return Value{}, err
+		}
+		e.vars[n.Name] = v
+		return v, nil
+	case *FuncDef:
+		if _, ok := builtins[n.Name]; ok {
+			return Value{}, &EvalError{Pos: n.P, Err: fmt.Errorf("不能重新定义内置函数 %s", n.Name)}
+		}
+		// 函数体中用到的其他函数在调用时才查找，所以可以先定义 f 再定义 f 调用的 g
+		e.funcs[n.Name] = n
+		return Value{}, nil
+	default:
+		return e.eval(n, nil)
+	}
+}
+
+// eval 计算表达式的值。locals 是当前函数调用的参数，函数体中先查找参数，再查找全局变量。
+func (e *Env) eval(n Node, locals map[string]Value) (Value, error) {
+	switch n := n.(type) {
+	case *NumberLit:
+		return n.Value, nil
+	case *Var:
+		if v, ok := locals[n.Name]; ok {
+			return v, nil
+		}
+		if v, ok := e.vars[n.Name]; ok {
+			return v, nil
+		}
+		return Value{}, &EvalError{Pos: n.P, Err: fmt.Errorf("%w的变量 %s", ErrUndefined, n.Name)}
+	case *Unary:
+		x, err := e.eval(n.X, locals)
+		if err != nil {
+			return Value{}, err
+		}
+		if n.Op == Plus {
+			return x, nil
+		}
+		v, err := neg(x, true)
+		if err != nil {
+			return Value{}, &EvalError{Pos: n.P, Err: err}
+		}
+		return v, nil
+	case *Binary:
+		x, err := e.eval(n.X, locals)
+		if err != nil {
+			return Value{}, err
+		}
+		y, err := e.eval(n.Y, locals)
+		if err != nil {
+			return Value{}, err
+		}
+		v, err := binary(n.Op, x, y)
+		if err != nil {
+			return Value{}, &EvalError{Pos: n.P, Err: err}
+		}
+		return v, nil
+	case *Call:
+		args := make([]Value, len(n.Args))
+		for i, a := range n.Args {
+			v, err := e.eval(a, locals)
+			if err != nil {
+				return Value{}, err
+			}
+			args[i] = v
+		}
+		v, err := e.call(n.Name, args)
+		var inner *EvalError
+		switch {
+		case err == nil:
+		case !errors.As(err, &inner):
+			return Value{}, &EvalError{Pos: n.P, Err: err}
+		case e.depth == 0:
+			// 函数体内错误的位置是相对于函数定义的，对正在输入的这一行没有意义，
+			// 所以在最外层改为指向调用处
+			return Value{}, &EvalError{Pos: n.P, Err: fmt.Errorf("在函数 %s 中: %w", n.Name, inner.Err)}
+		default:
+			return Value{}, err
+		}
+		return v, nil
+	default:
+		return Value{}, &EvalError{Pos: n.Pos(), Err: fmt.Errorf("%v 不能出现在表达式中", n)}
+	}
+}
+
+func (e *Env) call(name string, args []Value) (Value, error) {
+	if b, ok := builtins[name]; ok {
+		if b.arity >= 0 && len(args) != b.arity || b.arity < 0 && len(args) == 0 {
+			return Value{}, fmt.Errorf("%w: %s 需要 %s 个参数, 得到 %d 个", ErrArgCount, name, arityString(b.arity), len(args))
+		}
+		return b.fn(args)
+	}
+	def, ok := e.funcs[name]
+	if !ok {
+		return Value{}, fmt.Errorf("%w的函数 %s", ErrUndefined, name)
+	}
+	if len(args) != len(def.Params) {
+		return Value{}, fmt.Errorf("%w: %s 需要 %d 个参数, 得到 %d 个", ErrArgCount, name, len(def.Params), len(args))
+	}
+	if e.depth >= maxDepth {
+		return Value{}, fmt.Errorf("%w: 超过 %d 层, 请检查 %s 是否调用了自己", ErrRecursion, maxDepth, name)
+	}
+	e.depth++
+	defer func() { e.depth-- }()
+
+	locals := make(map[string]Value, len(args))
+	for i, p := range def.Params {
+		locals[p] = args[i]
+	}
+	return e.eval(def.Body, locals)
+}
+
+func arityString(n int) string {
+	if n < 0 {
+		return "至少 1"
+	}
+	return fmt.Sprint(n)
+}
+
+// neg 在 negate 为 true 时返回 -x，否则原样返回 x
+func neg(x Value, negate bool) (Value, error) {
+	if !negate {
+		return x, nil
+	}
+	if x.isFloat {
+		return Float(-x.f), nil
+	}
+	if x.i == math.MinInt64 {
+		return Value{}, ErrOverflow
+	}
+	return Int(-x.i), nil
+}
+
+// binary 计算二元运算。两个整数之间的运算检查溢出，而不是像 Go 一样悄悄回绕。
+func binary(op TokenKind, x, y Value) (Value, error) {
+	if !x.isFloat && !y.isFloat {
+		return intBinary(op, x.i, y.i)
+	}
+	a, b := x.Float64(), y.Float64()
+	switch op {
+	case Plus:
+		return Float(a + b), nil
+	case Minus:
+		return Float(a - b), nil
+	case Star:
+		return Float(a * b), nil
+	case Slash:
+		if b == 0 {
+			return Value{}, ErrDivideByZero // 浮点数除以零在 Go 中得到 Inf，这里与整数保持一致
+		}
+		return Float(a / b), nil
+	case Mod:
+		if b == 0 {
+			return Value{}, ErrDivideByZero
+		}
+		return Float(math.Mod(a, b)), nil
+	case Caret:
+		if a == 0 && b < 0 {
+			return Value{}, ErrDivideByZero // 0 ^ -n = 1 / 0^n，math.Pow 会返回 Inf
+		}
+		return Float(math.Pow(a, b)), nil
+	}
+	return Value{}, fmt.Errorf("未知的运算符 %v", op)
+}
+
+func intBinary(op TokenKind, a, b int64) (Value, error) {
+	switch op {
+	case Plus:
+		c := a + b
+		if (c > a) != (b > 0) {
+			return Value{}, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+		}
+		return Int(c), nil
+	case Minus:
+		c := a - b
+		if (c < a) != (b > 0) {
+			return Value{}, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+		}
+		return Int(c), nil
+	case Star:
+		c := a * b
+		if a != 0 && (c/a != b || a == -1 && b == math.MinInt64) {
+			return Value{}, fmt.Errorf("%w: %d * %d", ErrOverflow, a, b)
+		}
+		return Int(c), nil
+	case Slash, Mod:
+		if b == 0 {
+			return Value{}, ErrDivideByZero
+		}
+		if a == math.MinInt64 && b == -1 {
+			return Value{}, fmt.Errorf("%w: %d / %d", ErrOverflow, a, b)
+		}
+		if op == Slash {
+			return Int(a / b), nil // 与 divide 一样向零截断: 7 / 2 = 3, -7 / 2 = -3
+		}
+		return Int(a % b), nil
+	case Caret:
+		if b < 0 {
+			if a == 0 {
+				return Value{}, ErrDivideByZero
+			}
+			return Float(math.Pow(float64(a), float64(b))), nil
+		}
+		return intPow(a, b)
+	}
+	return Value{}, fmt.Errorf("未知的运算符 %v", op)
+}
+
+// intPow 用快速幂计算 a^b (b >= 0)，每一步都检查溢出
+func intPow(a, b int64) (Value, error) {
+	result, base := Int(1), Int(a)
+	for exp := b; exp > 0; exp >>= 1 {
+		var err error
+		if exp&1 == 1 {
+			if result, err = intBinary(Star, result.i, base.i); err != nil {
+				return Value{}, fmt.Errorf("%w: %d ^ %d", ErrOverflow, a, b)
+			}
+		}
+		if exp > 1 {
+			if base, err = intBinary(Star, base.i, base.i); err != nil {
+				return Value{}, fmt.Errorf("%w: %d ^ %d", ErrOverflow, a, b)
+			}
+		}
+	}
+	return result, nil
+}
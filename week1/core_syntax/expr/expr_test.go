@@ -0,0 +1,104 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePrecedence(t *testing.T) {
+	testCases := []struct {
+		src, want string
+	}{
+		{"1 + 2 * 3", "(1 + (2 * 3))"},
+		{"(1 + 2) * 3", "((1 + 2) * 3)"},
+		{"1 - 2 - 3", "((1 - 2) - 3)"},
+		{"2 ^ 3 ^ 2", "(2 ^ (3 ^ 2))"},
+		{"-2 ^ 2", "(-(2 ^ 2))"},
+		{"2 * -3", "(2 * (-3))"},
+		{"f(x, y) = x * x + y", "f(x, y) = ((x * x) + y)"},
+		{"r = max(1, 2.5) % 2", "r = (max(1, 2.5) % 2)"},
+	}
+	for _, tc := range testCases {
+		n, err := Parse(tc.src)
+		if err != nil {
+			t.Errorf("Parse(%q) 出错: %v", tc.src, err)
+			continue
+		}
+		if got := n.String(); got != tc.want {
+			t.Errorf("Parse(%q) = %s; want %s", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	env := NewEnv()
+	// 按顺序执行，后面的语句依赖前面定义的变量和函数
+	steps := []struct {
+		src, want string
+	}{
+		{"7 / 2", "3"},
+		{"-7 / 2", "-3"},
+		{"7.0 / 2", "3.5"},
+		{"7 % 3", "1"},
+		{"2 ^ 10", "1024"},
+		{"2 ^ -1", "0.5"},
+		{"8.0 / 2", "4.0"},
+		{"x = 3", "3"},
+		{"sq(n) = n * n", "0"},
+		{"hyp(a, b) = sqrt(sq(a) + sq(b))", "0"},
+		{"hyp(x, 4)", "5.0"},
+		{"min(3, 1, 2)", "1"},
+		{"abs(-x) + int(2.9)", "5"},
+	}
+	for _, s := range steps {
+		v, err := env.Eval(s.src)
+		if err != nil {
+			t.Fatalf("Eval(%q) 出错: %v", s.src, err)
+		}
+		if got := v.String(); got != s.want {
+			t.Errorf("Eval(%q) = %s; want %s", s.src, got, s.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	env := NewEnv()
+	if _, err := env.Eval("loop(n) = loop(n)"); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		src  string
+		want error
+		pos  int
+	}{
+		{"10 / 0", ErrDivideByZero, 4},
+		{"1 % (2 - 2)", ErrDivideByZero, 3},
+		{"1.5 / 0", ErrDivideByZero, 5},
+		{"0 ^ -1", ErrDivideByZero, 3},
+		{"0.0 ^ -0.5", ErrDivideByZero, 5},
+		{"y + 1", ErrUndefined, 1},
+		{"nope(1)", ErrUndefined, 1},
+		{"sqrt(1, 2)", ErrArgCount, 1},
+		{"9223372036854775807 + 1", ErrOverflow, 21},
+		{"2 ^ 63", ErrOverflow, 3},
+		{"1 + loop(1)", ErrRecursion, 5},
+	}
+	for _, tc := range testCases {
+		_, err := env.Eval(tc.src)
+		if !errors.Is(err, tc.want) {
+			t.Errorf("Eval(%q) 的错误 = %v; want %v", tc.src, err, tc.want)
+			continue
+		}
+		var ev *EvalError
+		if !errors.As(err, &ev) || ev.Pos != tc.pos {
+			t.Errorf("Eval(%q) 的错误位置 = %v; want 第 %d 列", tc.src, err, tc.pos)
+		}
+	}
+
+	for _, src := range []string{"", "1 +", "(1", "1 2", "3 = x", "f(1) = 2", "f(a, a) = a", "1 $ 2", "."} {
+		var syn *SyntaxError
+		if _, err := Parse(src); !errors.As(err, &syn) {
+			t.Errorf("Parse(%q) 应返回 *SyntaxError, 得到 %v", src, err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parse 解析一条语句，它可以是:
+//
+//	表达式      1 + 2 * x
+//	变量赋值    x = 1 + 2
+//	函数定义    f(x, y) = x * x + y
+//
+// 解析使用 Pratt 算法 (自顶向下的运算符优先级解析)：每个二元运算符有左右两个 "结合力"，
+// 结合力越大越先计算；左结合的运算符右边的结合力更大，右结合的运算符左边更大。
+// 加一个运算符只需要在 infixBP 中加一行，而不用像递归下降那样每一级优先级写一个函数。
+func Parse(src string) (Node, error) {
+	toks, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	if p.peek().Kind == EOF {
+		return nil, &SyntaxError{Pos: 1, Msg: "输入为空"}
+	}
+	n, err := p.expr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().Kind == Assign {
+		if n, err = p.definition(n); err != nil {
+			return nil, err
+		}
+	}
+	if t := p.peek(); t.Kind != EOF {
+		return nil, &SyntaxError{Pos: t.Pos, Msg: fmt.Sprintf("多余的 %v", t)}
+	}
+	return n, nil
+}
+
+type parser struct {
+	toks []Token
+	i    int
+}
+
+func (p *parser) peek() Token { return p.toks[p.i] }
+
+func (p *parser) next() Token {
+	t := p.toks[p.i]
+	if t.Kind != EOF { // 停在 EOF 上，之后的 peek 和 next 都返回 EOF
+		p.i++
+	}
+	return t
+}
+
+// infixBP 返回二元运算符的左右结合力
+func infixBP(k TokenKind) (left, right int, ok bool) {
+	switch k {
+	case Plus, Minus:
+		return 10, 11, true
+	case Star, Slash, Mod:
+		return 20, 21, true
+	case Caret:
+		return 31, 30, true // 右结合: 2^3^2 = 2^(3^2)
+	}
+	return 0, 0, false
+}
+
+// prefixBP 是一元正负号的结合力：比乘除高，所以 -2 * 3 = (-2) * 3；
+// 比乘方低，所以 -2^2 = -(2^2) = -4，与数学中的写法一致
+const prefixBP = 25
+
+// expr 解析一个表达式，遇到左结合力小于 minBP 的运算符时停下，把它留给上一层
+func (p *parser) expr(minBP int) (Node, error) {
+	left, err := p.prefix()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		lbp, rbp, ok := infixBP(op.Kind)
+		if !ok || lbp < minBP {
+			return left, nil
+		}
+		p.next()
+		right, err := p.expr(rbp)
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{P: op.Pos, Op: op.Kind, X: left, Y: right}
+	}
+}
+
+// prefix 解析可以出现在表达式开头的部分：数字、变量、函数调用、一元运算和括号
+func (p *parser) prefix() (Node, error) {
+	t := p.next()
+	switch t.Kind {
+	case Number:
+		v, err := parseNumber(t.Text)
+		if err != nil {
+			return nil, &SyntaxError{Pos: t.Pos, Msg: err.Error()}
+		}
+		return &NumberLit{P: t.Pos, Value: v}, nil
+	case Ident:
+		if p.peek().Kind == LParen {
+			return p.call(t)
+		}
+		return &Var{P: t.Pos, Name: t.Text}, nil
+	case Plus, Minus:
+		x, err := p.expr(prefixBP)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{P: t.Pos, Op: t.Kind, X: x}, nil
+	case LParen:
+		x, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.Kind != RParen {
+			return nil, &SyntaxError{Pos: r.Pos, Msg: fmt.Sprintf("缺少与第 %d 列匹配的右括号", t.Pos)}
+		}
+		return x, nil
+	case EOF:
+		return nil, &SyntaxError{Pos: t.Pos, Msg: "表达式不完整"}
+	default:
+		return nil, &SyntaxError{Pos: t.Pos, Msg: fmt.Sprintf("意外的 %v", t)}
+	}
+}
+
+// call 解析参数列表，name 是已经读取的函数名
+func (p *parser) call(name Token) (Node, error) {
+	p.next() // (
+	c := &Call{P: name.Pos, Name: name.Text}
+	if p.peek().Kind == RParen {
+		p.next()
+		return c, nil
+	}
+	for {
+		arg, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		c.Args = append(c.Args, arg)
+		switch t := p.next(); t.Kind {
+		case Comma:
+		case RParen:
+			return c, nil
+		default:
+			return nil, &SyntaxError{Pos: t.Pos, Msg: fmt.Sprintf("参数之间应为逗号, 得到 %v", t)}
+		}
+	}
+}
+
+// definition 在读到 = 之后，把已经解析的左边转换为赋值或函数定义。
+// 先按表达式解析、再检查左边的形状，这样 f(x) = ... 和 f(x) + 1 不需要提前区分。
+func (p *parser) definition(lhs Node) (Node, error) {
+	eq := p.next()
+	switch lhs := lhs.(type) {
+	case *Var:
+		x, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		return &AssignStmt{P: lhs.P, Name: lhs.Name, X: x}, nil
+	case *Call:
+		params := make([]string, len(lhs.Args))
+		for i, a := range lhs.Args {
+			v, ok := a.(*Var)
+			if !ok {
+				return nil, &SyntaxError{Pos: a.Pos(), Msg: "函数的参数必须是名称"}
+			}
+			if slices.Contains(params[:i], v.Name) {
+				return nil, &SyntaxError{Pos: a.Pos(), Msg: fmt.Sprintf("参数 %s 重复", v.Name)}
+			}
+			params[i] = v.Name
+		}
+		body, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		return &FuncDef{P: lhs.P, Name: lhs.Name, Params: params, Body: body}, nil
+	default:
+		return nil, &SyntaxError{Pos: eq.Pos, Msg: "= 的左边必须是变量名或函数声明, 如 x = 1 或 f(x) = x * 2"}
+	}
+}
+
+// parseNumber 把数字字面量转换为 Value：含小数点或指数的是浮点数，其余是整数
+func parseNumber(text string) (Value, error) {
+	if strings.ContainsAny(text, ".eE") {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return Value{}, fmt.Errorf("无效的数字 %s", text)
+		}
+		return Float(f), nil // 超出范围时 f 为 ±Inf 或 0，与 Go 的浮点数行为一致
+	}
+	i, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return Value{}, fmt.Errorf("整数 %s 超出 int64 的范围, 可以写成 %s.0 按浮点数计算", text, text)
+	}
+	return Int(i), nil
+}
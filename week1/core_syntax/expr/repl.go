@@ -0,0 +1,123 @@
+package expr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const replHelp = `语句:
+  1 + 2 * 3          计算表达式, 结果保存在变量 ans 中
+  x = 7 / 2          给变量赋值 (两个整数相除结果为整数, 写成 7.0 / 2 得到 3.5)
+  f(x, y) = x^2 + y  定义函数
+运算符: + - * / % ^ ( ), 乘方 ^ 是右结合的
+内置函数: abs sqrt floor ceil round int float min max, 常量: pi e
+命令:
+  :vars              列出变量
+  :funcs             列出自定义函数
+  :ast <表达式>      显示语法树 (加上括号的形式)
+  :tokens <表达式>   显示词法分析的结果
+  :help              显示帮助
+  :quit              退出`
+
+// RunREPL 运行交互式计算器 (Read-Eval-Print Loop)：从 in 逐行读取语句，把结果写到 out，
+// 读到输入结尾或 :quit 时返回。单条语句出错只会打印错误，不会结束循环。
+func RunREPL(in io.Reader, out io.Writer, env *Env) error {
+	const prompt = "> "
+	sc := bufio.NewScanner(in)
+	fmt.Fprintln(out, "表达式计算器, 输入 :help 查看帮助, :quit 退出")
+	for {
+		fmt.Fprint(out, prompt)
+		if !sc.Scan() {
+			fmt.Fprintln(out)
+			return sc.Err()
+		}
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			if quit := replCommand(out, env, line); quit {
+				return nil
+			}
+			continue
+		}
+
+		n, err := Parse(line)
+		if err == nil {
+			var v Value
+			if v, err = env.Run(n); err == nil {
+				switch n := n.(type) {
+				case *FuncDef:
+					fmt.Fprintln(out, "已定义", n)
+				case *AssignStmt:
+					fmt.Fprintln(out, n.Name, "=", v)
+				default:
+					env.Set("ans", v)
+					fmt.Fprintln(out, v)
+				}
+				continue
+			}
+		}
+		printError(out, len(prompt)+len(sc.Text())-len(strings.TrimLeft(sc.Text(), " \t"))-1, err)
+	}
+}
+
+// printError 打印错误，对带位置的错误用 ^ 指出出错的列。offset 是提示符与行首空白的宽度。
+func printError(out io.Writer, offset int, err error) {
+	var syn *SyntaxError
+	var ev *EvalError
+	switch {
+	case errors.As(err, &syn):
+		fmt.Fprintf(out, "%*s^\n", offset+syn.Pos, "")
+	case errors.As(err, &ev):
+		fmt.Fprintf(out, "%*s^\n", offset+ev.Pos, "")
+	}
+	fmt.Fprintln(out, "错误:", err)
+}
+
+// replCommand 执行以冒号开头的命令，返回是否退出
+func replCommand(out io.Writer, env *Env, line string) (quit bool) {
+	cmd, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch cmd {
+	case ":quit", ":q":
+		return true
+	case ":help", ":h":
+		fmt.Fprintln(out, replHelp)
+	case ":vars":
+		for _, name := range env.Vars() {
+			v, _ := env.Get(name)
+			fmt.Fprintf(out, "  %s = %v\n", name, v)
+		}
+	case ":funcs":
+		defs := env.Funcs()
+		if len(defs) == 0 {
+			fmt.Fprintln(out, "  (还没有自定义函数)")
+		}
+		for _, d := range defs {
+			fmt.Fprintln(out, " ", d)
+		}
+	case ":ast":
+		n, err := Parse(arg)
+		if err != nil {
+			fmt.Fprintln(out, "错误:", err)
+			return false
+		}
+		fmt.Fprintln(out, n)
+	case ":tokens":
+		toks, err := Tokenize(arg)
+		if err != nil {
+			fmt.Fprintln(out, "错误:", err)
+			return false
+		}
+		for _, t := range toks {
+			fmt.Fprintf(out, "  %3d  %v\n", t.Pos, t)
+		}
+	default:
+		fmt.Fprintf(out, "未知的命令 %s, 输入 :help 查看帮助\n", cmd)
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// Package expr 是一个小型的算术表达式求值器，把 core_syntax 中只能计算两个数的 divide 和 add
+// 扩展成能计算任意表达式的计算器。
+//
+// 求值分为三步:
+//
+//	源代码 --词法分析--> Token 序列 --语法分析--> 抽象语法树 (AST) --求值--> Value
+//
+// 支持整数和浮点数、+ - * / % ^、括号、变量赋值 (x = 1 + 2)、
+// 自定义函数 (f(x, y) = x * x + y) 以及 sqrt、abs 等内置函数。
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind 是 Token 的种类
+type TokenKind int
+
+const (
+	EOF TokenKind = iota
+	Number
+	Ident
+	Plus   // +
+	Minus  // -
+	Star   // *
+	Slash  // /
+	Mod    // %
+	Caret  // ^
+	LParen // (
+	RParen // )
+	Comma  // ,
+	Assign // =
+)
+
+var kindNames = map[TokenKind]string{
+	EOF: "输入结束", Number: "数字", Ident: "标识符",
+	Plus: "+", Minus: "-", Star: "*", Slash: "/", Mod: "%", Caret: "^",
+	LParen: "(", RParen: ")", Comma: ",", Assign: "=",
+}
+
+func (k TokenKind) String() string {
+	if s, ok := kindNames[k]; ok {
+		return s
+	}
+	return "TokenKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+var punct = map[rune]TokenKind{
+	'+': Plus, '-': Minus, '*': Star, '/': Slash, '%': Mod, '^': Caret,
+	'(': LParen, ')': RParen, ',': Comma, '=': Assign,
+}
+
+// Token 是词法分析的最小单位，Pos 是它在源代码中的位置 (从 1 开始的字符列号)
+type Token struct {
+	Kind TokenKind
+	Text string
+	Pos  int
+}
+
+func (t Token) String() string {
+	switch t.Kind {
+	case Number, Ident:
+		return fmt.Sprintf("%v %q", t.Kind, t.Text)
+	default:
+		return t.Kind.String()
+	}
+}
+
+// SyntaxError 是带位置的词法或语法错误
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("语法错误 (第 %d 列): %s", e.Pos, e.Msg)
+}
+
+// Tokenize 把源代码切分为 Token，最后一个 Token 总是 EOF
+func Tokenize(src string) ([]Token, error) {
+	var toks []Token
+	pos := 1 // 按字符而不是字节计数，中文输入时列号才准确
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			pos++
+		case r >= '0' && r <= '9' || r == '.':
+			n, err := scanNumber(src[i:])
+			if err != "" {
+				return nil, &SyntaxError{Pos: pos, Msg: err}
+			}
+			toks = append(toks, Token{Number, src[i : i+n], pos})
+			i += n
+			pos += n
+		case r == '_' || unicode.IsLetter(r):
+			start, startPos := i, pos
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+				pos++
+			}
+			toks = append(toks, Token{Ident, src[start:i], startPos})
+		default:
+			kind, ok := punct[r]
+			if !ok {
+				return nil, &SyntaxError{Pos: pos, Msg: fmt.Sprintf("无法识别的字符 %q", r)}
+			}
+			toks = append(toks, Token{kind, string(r), pos})
+			i += size
+			pos++
+		}
+	}
+	return append(toks, Token{EOF, "", pos}), nil
+}
+
+// scanNumber 返回 s 开头的数字的字节长度，格式为 123、1.5、.5、1e-3。
+// 数字只包含 ASCII 字符，所以字节长度就是字符数。
+func scanNumber(s string) (int, string) {
+	digits := func(i int) int {
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i
+	}
+	i := digits(0)
+	intPart := i > 0
+	if i < len(s) && s[i] == '.' {
+		j := digits(i + 1)
+		if !intPart && j == i+1 {
+			return 0, "单独的小数点不是数字"
+		}
+		i = j
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		k := digits(j)
+		if k == j {
+			return 0, "指数部分缺少数字"
+		}
+		i = k
+	}
+	return i, ""
+}
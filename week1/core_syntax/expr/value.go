@@ -0,0 +1,48 @@
+package expr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Value 是计算结果，可能是整数或浮点数。
+// 两个整数运算的结果仍是整数 (7 / 2 = 3，与 Go 和 divide 的整数除法一致)，
+// 只要有一个操作数是浮点数，结果就是浮点数 (7.0 / 2 = 3.5)。
+type Value struct {
+	i       int64
+	f       float64
+	isFloat bool
+}
+
+// Int 创建整数值
+func Int(i int64) Value { return Value{i: i} }
+
+// Float 创建浮点数值
+func Float(f float64) Value { return Value{f: f, isFloat: true} }
+
+// IsFloat 报告 v 是否为浮点数
+func (v Value) IsFloat() bool { return v.isFloat }
+
+// Int64 返回整数值，v 是浮点数时 ok 为 false
+func (v Value) Int64() (i int64, ok bool) { return v.i, !v.isFloat }
+
+// Float64 返回 v 的浮点数表示
+func (v Value) Float64() float64 {
+	if v.isFloat {
+		return v.f
+	}
+	return float64(v.i)
+}
+
+// String 格式化 v。值为整数的浮点数加上 ".0"，与整数区分开
+func (v Value) String() string {
+	if !v.isFloat {
+		return strconv.FormatInt(v.i, 10)
+	}
+	s := strconv.FormatFloat(v.f, 'g', -1, 64)
+	if !math.IsInf(v.f, 0) && !math.IsNaN(v.f) && !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
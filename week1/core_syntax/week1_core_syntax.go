@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strconv" // 用于字符串转换
+
+	"github.com/Mag1cFall/go-get-started/week1/core_syntax/expr"
 )
 
 // main 函数是程序的入口
@@ -126,6 +128,9 @@ func main() {
 	// 调用字符串转换示例函数
 	stringConversionExample()
 
+	// 调用表达式求值示例函数
+	exprExample()
+
 	fmt.Println("\n--- 核心语法学习告一段落 ---")
 }
 
@@ -134,8 +139,9 @@ func main() {
 // (int, error) 是返回值列表，第一个是结果，第二个是错误对象
 func divide(num1 int, num2 int) (int, error) {
 	if num2 == 0 {
-		// errors.New 创建一个新的错误对象
-		return 0, errors.New("除数不能为零")
+		// 返回预先用 errors.New 创建好的错误变量 (哨兵错误)，而不是每次都新建一个，
+		// 这样调用方可以用 errors.Is(err, expr.ErrDivideByZero) 判断是哪一种错误
+		return 0, expr.ErrDivideByZero
 	}
 	return num1 / num2, nil // nil 表示没有错误
 }
@@ -178,3 +184,50 @@ func stringConversionExample() {
 	// 注意：stringConversionExample 函数没有在 main 中被调用，
 	// 如果需要运行它，可以在 main 函数中添加 stringConversionExample()
 }
+
+// exprExample 演示 expr 包：divide 和 add 只能计算两个数，
+// expr 把它们推广为能计算任意表达式的计算器，除以零时报告与 divide 相同的错误。
+// 交互式的版本见 calc 命令: go run ./week1/core_syntax/calc
+func exprExample() {
+	fmt.Println("\n--- 6. 表达式求值 (expr 包) ---")
+	env := expr.NewEnv()
+	for _, src := range []string{
+		"5 + 3",              // 相当于 add(5, 3)
+		"10 / 2",             // 相当于 divide(10, 2)
+		"7 / 2",              // 整数除法，与 divide 一样舍去小数部分
+		"7.0 / 2",            // 有浮点数参与时结果是浮点数
+		"1 + 2 * (3 - 1)",    // 先乘除后加减，括号优先
+		"r = 2",              // 变量
+		"area(r) = pi * r^2", // 自定义函数
+		"area(r) + area(1)",
+	} {
+		// Eval = Parse + Run，这里分开调用，以便区分函数定义和普通表达式
+		n, err := expr.Parse(src)
+		if err != nil {
+			fmt.Println(src, "出错:", err)
+			continue
+		}
+		v, err := env.Run(n)
+		switch {
+		case err != nil:
+			fmt.Println(src, "出错:", err)
+		case isFuncDef(n):
+			fmt.Printf("%-20s => 已定义函数\n", src)
+		default:
+			fmt.Printf("%-20s => %v\n", src, v)
+		}
+	}
+
+	// 除以零: 表达式的错误带有出错的位置，但底层原因与 divide 相同，都是 expr.ErrDivideByZero
+	_, divErr := divide(10, 0)
+	_, err := env.Eval("10 / (r - 2)")
+	fmt.Println("divide(10, 0) 的错误:", divErr)
+	fmt.Println("10 / (r - 2) 的错误:", err)
+	fmt.Println("errors.Is(divErr, expr.ErrDivideByZero):", errors.Is(divErr, expr.ErrDivideByZero))
+	fmt.Println("errors.Is(err, expr.ErrDivideByZero):", errors.Is(err, expr.ErrDivideByZero))
+}
+
+func isFuncDef(n expr.Node) bool {
+	_, ok := n.(*expr.FuncDef)
+	return ok
+}
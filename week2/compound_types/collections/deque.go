@@ -0,0 +1,144 @@
+package collections
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Deque 是双端队列，两端的插入和删除都是均摊 O(1)，也可以按下标 O(1) 访问。
+//
+// 用切片实现队列时，s = s[1:] 出队会让底层数组的前部无法再利用；
+// Deque 使用环形缓冲区：head 指向第一个元素，写到数组末尾后绕回开头，
+// 只有数组真正装满时才扩容为两倍。零值是可以直接使用的空队列。
+type Deque[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+// NewDeque 创建初始容量至少为 capacity 的空队列
+func NewDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{buf: make([]T, max(capacity, 0))}
+}
+
+// Len 返回元素个数
+func (d *Deque[T]) Len() int { return d.n }
+
+// index 把逻辑下标 (0 是队首) 转换为 buf 中的下标
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// grow 在缓冲区已满时把容量翻倍，并把元素按顺序搬到新数组的开头
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), 8))
+	for i := range d.n {
+		buf[i] = d.buf[d.index(i)]
+	}
+	d.buf, d.head = buf, 0
+}
+
+// PushBack 在队尾添加元素
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.n)] = v
+	d.n++
+}
+
+// PushFront 在队首添加元素
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront 删除并返回队首元素，队列为空时 ok 为 false
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	var zero T
+	v, d.buf[d.head] = d.buf[d.head], zero // 清零，让垃圾回收器可以回收元素引用的内存
+	d.head = d.index(1)
+	d.n--
+	return v, true
+}
+
+// PopBack 删除并返回队尾元素，队列为空时 ok 为 false
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	var zero T
+	i := d.index(d.n - 1)
+	v, d.buf[i] = d.buf[i], zero
+	d.n--
+	return v, true
+}
+
+// Front 返回队首元素但不删除
+func (d *Deque[T]) Front() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back 返回队尾元素但不删除
+func (d *Deque[T]) Back() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// At 返回第 i 个元素 (0 是队首)，越界时 panic
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic(fmt.Sprintf("collections: 下标 %d 超出长度为 %d 的 Deque", i, d.n))
+	}
+	return d.buf[d.index(i)]
+}
+
+// Clear 删除所有元素，保留已经分配的容量
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head, d.n = 0, 0
+}
+
+// All 从队首到队尾遍历元素，键是下标
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range d.n {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 从队尾到队首遍历元素，键是下标
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.n - 1; i >= 0; i-- {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Values 从队首到队尾遍历元素
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	tests := []struct {
+		name string
+		cap  int
+		ops  func(d *Deque[int])
+		want []int
+	}{
+		{"零容量时 PushFront", 0, func(d *Deque[int]) {
+			for i := range 3 {
+				d.PushFront(i)
+			}
+		}, []int{2, 1, 0}},
+		// head 已经绕到数组末尾时扩容，元素要按逻辑顺序搬到新数组
+		{"PushFront 绕回后扩容", 4, func(d *Deque[int]) {
+			for i := range 10 {
+				d.PushFront(i)
+			}
+		}, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{"两端交替插入并扩容", 2, func(d *Deque[int]) {
+			for i := range 5 {
+				d.PushBack(i)
+				d.PushFront(-i - 1)
+			}
+		}, []int{-5, -4, -3, -2, -1, 0, 1, 2, 3, 4}},
+		{"出队后绕回再扩容", 4, func(d *Deque[int]) {
+			d.PushBack(1)
+			d.PushBack(2)
+			d.PopFront()
+			d.PopFront() // head 移到 2
+			for i := range 6 {
+				d.PushBack(10 + i)
+			}
+			d.PushFront(9)
+		}, []int{9, 10, 11, 12, 13, 14, 15}},
+		{"两端出队", 4, func(d *Deque[int]) {
+			for i := range 6 {
+				d.PushBack(i)
+			}
+			d.PopFront()
+			d.PopBack()
+		}, []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeque[int](tt.cap)
+			tt.ops(d)
+			if got := slices.Collect(d.Values()); !slices.Equal(got, tt.want) {
+				t.Fatalf("Values = %v, want %v", got, tt.want)
+			}
+			for i, v := range tt.want {
+				if d.At(i) != v {
+					t.Errorf("At(%d) = %d, want %d", i, d.At(i), v)
+				}
+			}
+			var back []int
+			for _, v := range d.Backward() {
+				back = append(back, v)
+			}
+			slices.Reverse(back)
+			if !slices.Equal(back, tt.want) {
+				t.Errorf("Backward 的逆序 = %v, want %v", back, tt.want)
+			}
+			if f, _ := d.Front(); f != tt.want[0] {
+				t.Errorf("Front = %d, want %d", f, tt.want[0])
+			}
+			if b, _ := d.Back(); b != tt.want[len(tt.want)-1] {
+				t.Errorf("Back = %d, want %d", b, tt.want[len(tt.want)-1])
+			}
+		})
+	}
+}
+
+func TestDequeEmpty(t *testing.T) {
+	var d Deque[string] // 零值可以直接使用
+	if _, ok := d.PopFront(); ok {
+		t.Error("空队列 PopFront 应该返回 ok=false")
+	}
+	if _, ok := d.Back(); ok {
+		t.Error("空队列 Back 应该返回 ok=false")
+	}
+	d.PushBack("a")
+	d.Clear()
+	if d.Len() != 0 {
+		t.Errorf("Clear 之后 Len = %d", d.Len())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("At 越界应该 panic")
+		}
+	}()
+	d.At(0)
+}
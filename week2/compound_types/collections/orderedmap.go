@@ -0,0 +1,138 @@
+package collections
+
+import "iter"
+
+// entry 是 OrderedMap 双向链表中的节点
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+}
+
+// OrderedMap 是按插入顺序遍历的 map。
+// 内置 map 的遍历顺序是随机的；OrderedMap 在 map 之外再用一条双向链表记录插入顺序，
+// 查找、插入、删除仍然都是 O(1)。零值是可以直接使用的空 map。
+type OrderedMap[K comparable, V any] struct {
+	m          map[K]*entry[K, V]
+	head, tail *entry[K, V]
+}
+
+// NewOrderedMap 创建空的 OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{m: map[K]*entry[K, V]{}}
+}
+
+// Set 设置 key 对应的值。新键追加到末尾，已有的键更新值并保持原来的位置。
+func (om *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := om.m[key]; ok {
+		e.value = value
+		return
+	}
+	if om.m == nil {
+		om.m = map[K]*entry[K, V]{}
+	}
+	e := &entry[K, V]{key: key, value: value, prev: om.tail}
+	if om.tail == nil {
+		om.head = e
+	} else {
+		om.tail.next = e
+	}
+	om.tail = e
+	om.m[key] = e
+}
+
+// Get 返回 key 对应的值，ok 表示键是否存在
+func (om *OrderedMap[K, V]) Get(key K) (value V, ok bool) {
+	if e, ok := om.m[key]; ok {
+		return e.value, true
+	}
+	return value, false
+}
+
+// Has 判断键是否存在
+func (om *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := om.m[key]
+	return ok
+}
+
+// Delete 删除键，返回键原来是否存在
+func (om *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := om.m[key]
+	if !ok {
+		return false
+	}
+	delete(om.m, key)
+	if e.prev == nil {
+		om.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		om.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	// e 自己的 prev 和 next 保持不变，这样正在遍历时删除当前元素，迭代器仍然能从 e 继续走下去
+	return true
+}
+
+// Len 返回键值对的数量
+func (om *OrderedMap[K, V]) Len() int { return len(om.m) }
+
+// Clear 删除所有键值对
+func (om *OrderedMap[K, V]) Clear() {
+	clear(om.m)
+	om.head, om.tail = nil, nil
+}
+
+// All 按插入顺序遍历所有键值对。
+// 与内置 map 相同，遍历过程中可以删除任意键 (已删除的键不会再出现)；
+// 遍历过程中新添加的键可能出现也可能不出现。
+func (om *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := om.head; e != nil; e = e.next {
+			if om.m[e.key] != e {
+				continue // 遍历时已被删除的节点，沿着它保留的 next 继续往后走
+			}
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 按插入顺序的逆序遍历所有键值对，遍历时修改 map 的规则与 All 相同
+func (om *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := om.tail; e != nil; e = e.prev {
+			if om.m[e.key] != e {
+				continue
+			}
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys 按插入顺序遍历所有键
+func (om *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range om.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values 按插入顺序遍历所有值
+func (om *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range om.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+// collectKeys 遍历 om.All()，每拿到一个键就调用一次 visit (可以在其中修改 om)
+func collectKeys(om *OrderedMap[string, int], visit func(k string)) []string {
+	var keys []string
+	for k := range om.All() {
+		keys = append(keys, k)
+		visit(k)
+	}
+	return keys
+}
+
+func TestOrderedMapOrder(t *testing.T) {
+	var om OrderedMap[string, int] // 零值可以直接使用
+	for i, k := range []string{"c", "a", "b"} {
+		om.Set(k, i)
+	}
+	om.Set("a", 10) // 更新已有的键不改变位置
+	if got := slices.Collect(om.Keys()); !slices.Equal(got, []string{"c", "a", "b"}) {
+		t.Errorf("Keys = %v, want [c a b]", got)
+	}
+	if got := slices.Collect(om.Values()); !slices.Equal(got, []int{0, 10, 2}) {
+		t.Errorf("Values = %v, want [0 10 2]", got)
+	}
+	var backward []string
+	for k := range om.Backward() {
+		backward = append(backward, k)
+	}
+	if !slices.Equal(backward, []string{"b", "a", "c"}) {
+		t.Errorf("Backward = %v, want [b a c]", backward)
+	}
+	// 删除后重新添加的键排到末尾
+	om.Delete("c")
+	om.Set("c", 3)
+	if got := slices.Collect(om.Keys()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("删除再添加之后 Keys = %v, want [a b c]", got)
+	}
+}
+
+func TestOrderedMapModifyDuringAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		visit    func(om *OrderedMap[string, int], k string)
+		want     []string // 遍历看到的键
+		wantKeys []string // 遍历结束后 map 中的键
+	}{
+		{
+			"删除当前键",
+			func(om *OrderedMap[string, int], k string) { om.Delete(k) },
+			[]string{"a", "b", "c", "d"},
+			nil,
+		},
+		{
+			"删除下一个键",
+			func(om *OrderedMap[string, int], k string) {
+				if k == "a" {
+					om.Delete("b")
+				}
+			},
+			[]string{"a", "c", "d"},
+			[]string{"a", "c", "d"},
+		},
+		{
+			"删除当前键和它后面的键",
+			func(om *OrderedMap[string, int], k string) {
+				if k == "b" {
+					om.Delete("b")
+					om.Delete("c")
+				}
+			},
+			[]string{"a", "b", "d"},
+			[]string{"a", "d"},
+		},
+		{
+			// 重新添加的键排到末尾，是新添加的键，所以会在遍历末尾再出现一次
+			"删除当前键后重新添加",
+			func(om *OrderedMap[string, int], k string) {
+				if k == "a" {
+					om.Delete("a")
+					om.Set("a", 100)
+				}
+			},
+			[]string{"a", "b", "c", "d", "a"},
+			[]string{"b", "c", "d", "a"},
+		},
+		{
+			"删除后面的键再重新添加",
+			func(om *OrderedMap[string, int], k string) {
+				if k == "a" {
+					om.Delete("b")
+					om.Set("b", 100)
+				}
+			},
+			[]string{"a", "c", "d", "b"},
+			[]string{"a", "c", "d", "b"},
+		},
+		{
+			"删除已经遍历过的键",
+			func(om *OrderedMap[string, int], k string) {
+				if k == "c" {
+					om.Delete("a")
+				}
+			},
+			[]string{"a", "b", "c", "d"},
+			[]string{"b", "c", "d"},
+		},
+		{
+			"遍历时清空",
+			func(om *OrderedMap[string, int], k string) { om.Clear() },
+			[]string{"a"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om := NewOrderedMap[string, int]()
+			for i, k := range []string{"a", "b", "c", "d"} {
+				om.Set(k, i)
+			}
+			got := collectKeys(om, func(k string) { tt.visit(om, k) })
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("遍历得到 %v, want %v", got, tt.want)
+			}
+			if keys := slices.Collect(om.Keys()); !slices.Equal(keys, tt.wantKeys) || om.Len() != len(tt.wantKeys) {
+				t.Errorf("遍历结束后 Keys = %v (Len %d), want %v", keys, om.Len(), tt.wantKeys)
+			}
+		})
+	}
+}
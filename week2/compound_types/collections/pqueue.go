@@ -0,0 +1,125 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+)
+
+// PriorityQueue 是基于二叉堆的优先队列，每次取出的都是 "最优先" 的元素。
+//
+// 堆是一棵存放在切片中的完全二叉树：下标 i 的子节点是 2i+1 和 2i+2，父节点是 (i-1)/2，
+// 并且每个节点都不比子节点 "后"。插入和取出都是 O(log n)，查看堆顶是 O(1)。
+// 标准库 container/heap 需要实现 heap.Interface 并通过 any 传值，这里直接用泛型实现。
+type PriorityQueue[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue 创建优先队列，less(a, b) 为 true 表示 a 比 b 优先
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// NewMinQueue 创建最小值优先的队列
+func NewMinQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Less[T])
+}
+
+// NewMaxQueue 创建最大值优先的队列
+func NewMaxQueue[T cmp.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// Len 返回元素个数
+func (pq *PriorityQueue[T]) Len() int { return len(pq.items) }
+
+// Push 添加元素：放到末尾，再向上交换到合适的位置
+func (pq *PriorityQueue[T]) Push(items ...T) {
+	for _, v := range items {
+		pq.items = append(pq.items, v)
+		pq.up(len(pq.items) - 1)
+	}
+}
+
+// Peek 返回最优先的元素但不删除，队列为空时 ok 为 false
+func (pq *PriorityQueue[T]) Peek() (v T, ok bool) {
+	if len(pq.items) == 0 {
+		return v, false
+	}
+	return pq.items[0], true
+}
+
+// Pop 删除并返回最优先的元素：把最后一个元素移到堆顶，再向下交换到合适的位置
+func (pq *PriorityQueue[T]) Pop() (v T, ok bool) {
+	n := len(pq.items)
+	if n == 0 {
+		return v, false
+	}
+	v = pq.items[0]
+	pq.items[0] = pq.items[n-1]
+	var zero T
+	pq.items[n-1] = zero
+	pq.items = pq.items[:n-1]
+	if n > 1 {
+		pq.down(0)
+	}
+	return v, true
+}
+
+// Clear 删除所有元素
+func (pq *PriorityQueue[T]) Clear() {
+	clear(pq.items)
+	pq.items = pq.items[:0]
+}
+
+// All 按堆在内存中的顺序遍历元素 (不是优先级顺序)，不会修改队列
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range pq.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain 按优先级顺序逐个取出元素，遍历结束时被取出的元素已经从队列中删除。
+// 中途 break 时，剩下的元素仍然留在队列中。
+func (pq *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := pq.Pop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i], pq.items[parent]) {
+			return
+		}
+		pq.items[i], pq.items[parent] = pq.items[parent], pq.items[i]
+		i = parent
+	}
+}
+
+func (pq *PriorityQueue[T]) down(i int) {
+	n := len(pq.items)
+	for {
+		best := i
+		for _, c := range [2]int{2*i + 1, 2*i + 2} {
+			if c < n && pq.less(pq.items[c], pq.items[best]) {
+				best = c
+			}
+		}
+		if best == i {
+			return
+		}
+		pq.items[i], pq.items[best] = pq.items[best], pq.items[i]
+		i = best
+	}
+}
@@ -0,0 +1,67 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueueDrain(t *testing.T) {
+	input := []int{5, 1, 4, 1, 3, 9, 2}
+	tests := []struct {
+		name      string
+		pq        func() *PriorityQueue[int]
+		breakAt   int   // 取出几个元素后 break，-1 表示遍历到底
+		want      []int // 遍历得到的元素
+		remaining []int // 按优先级顺序留在队列中的元素
+	}{
+		{"最小值优先", NewMinQueue[int], -1, []int{1, 1, 2, 3, 4, 5, 9}, nil},
+		{"最大值优先", NewMaxQueue[int], -1, []int{9, 5, 4, 3, 2, 1, 1}, nil},
+		{"中途 break", NewMinQueue[int], 3, []int{1, 1, 2}, []int{3, 4, 5, 9}},
+		{"第一个就 break", NewMaxQueue[int], 1, []int{9}, []int{5, 4, 3, 2, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := tt.pq()
+			pq.Push(input...)
+			var got []int
+			for v := range pq.Drain() {
+				got = append(got, v)
+				if len(got) == tt.breakAt {
+					break
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Drain = %v, want %v", got, tt.want)
+			}
+			// break 时已经交给循环体的元素被取走了，其余元素留在队列中
+			if pq.Len() != len(tt.remaining) {
+				t.Fatalf("Drain 之后 Len = %d, want %d", pq.Len(), len(tt.remaining))
+			}
+			if rest := slices.Collect(pq.Drain()); !slices.Equal(rest, tt.remaining) {
+				t.Errorf("剩下的元素 = %v, want %v", rest, tt.remaining)
+			}
+		})
+	}
+}
+
+func TestPriorityQueueCustom(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	pq := NewPriorityQueue(func(a, b task) bool { return a.priority < b.priority })
+	if _, ok := pq.Pop(); ok {
+		t.Error("空队列 Pop 应该返回 ok=false")
+	}
+	pq.Push(task{"写代码", 2}, task{"修 bug", 1}, task{"开会", 3})
+	if top, _ := pq.Peek(); top.name != "修 bug" || pq.Len() != 3 {
+		t.Errorf("Peek = %v, Len = %d; want 修 bug, 3", top, pq.Len())
+	}
+	if n := len(slices.Collect(pq.All())); n != 3 || pq.Len() != 3 {
+		t.Errorf("All 遍历了 %d 个元素, 之后 Len = %d; want 3 且不修改队列", n, pq.Len())
+	}
+	pq.Clear()
+	if _, ok := pq.Peek(); ok {
+		t.Error("Clear 之后队列应该为空")
+	}
+}
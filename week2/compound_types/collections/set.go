@@ -0,0 +1,146 @@
+// Package collections 提供几种标准库没有内置的泛型容器：
+//
+//   - Set[T]: 基于 map 的集合，支持并集、交集、差集
+//   - OrderedMap[K, V]: 按插入顺序遍历的 map
+//   - Deque[T]: 基于环形缓冲区的双端队列
+//   - PriorityQueue[T]: 基于二叉堆的优先队列
+//
+// 所有容器都提供返回 iter.Seq / iter.Seq2 的方法，可以直接用在 for range 中，
+// 也可以交给 slices.Collect、maps.Collect 等标准库函数。
+// 容器都不是并发安全的，多个 goroutine 同时使用时需要自己加锁。
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set 是元素类型为 T 的集合。零值是可以直接使用的空集合。
+// Go 没有内置集合类型，惯用写法是 map[T]struct{}：struct{} 不占内存，只用到 map 的键。
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// NewSet 创建包含 items 的集合，重复元素只保留一个
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// SetOf 从迭代器收集元素，例如 SetOf(maps.Keys(m))
+func SetOf[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := NewSet[T]()
+	for v := range seq {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+// Add 添加元素，已经存在的元素会被忽略
+func (s *Set[T]) Add(items ...T) {
+	if s.m == nil {
+		s.m = make(map[T]struct{}, len(items))
+	}
+	for _, v := range items {
+		s.m[v] = struct{}{}
+	}
+}
+
+// Remove 删除元素，返回元素原来是否存在
+func (s *Set[T]) Remove(v T) bool {
+	_, ok := s.m[v]
+	delete(s.m, v) // 对 nil map 执行 delete 是安全的
+	return ok
+}
+
+// Contains 判断元素是否在集合中
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Len 返回元素个数
+func (s *Set[T]) Len() int { return len(s.m) }
+
+// Clear 删除所有元素
+func (s *Set[T]) Clear() { clear(s.m) }
+
+// Clone 返回集合的副本
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{m: maps.Clone(s.m)}
+}
+
+// All 返回遍历所有元素的迭代器。与 map 一样，遍历顺序是不确定的。
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.m)
+}
+
+// Union 返回并集 s ∪ o
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	out := s.Clone()
+	out.Add(slices.Collect(o.All())...)
+	return out
+}
+
+// Intersection 返回交集 s ∩ o
+func (s *Set[T]) Intersection(o *Set[T]) *Set[T] {
+	small, large := s, o
+	if small.Len() > large.Len() {
+		small, large = large, small // 遍历较小的集合
+	}
+	out := NewSet[T]()
+	for v := range small.m {
+		if large.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference 返回差集 s - o，即在 s 中但不在 o 中的元素
+func (s *Set[T]) Difference(o *Set[T]) *Set[T] {
+	out := NewSet[T]()
+	for v := range s.m {
+		if !o.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SymmetricDifference 返回只属于其中一个集合的元素
+func (s *Set[T]) SymmetricDifference(o *Set[T]) *Set[T] {
+	out := s.Difference(o)
+	for v := range o.m {
+		if !s.Contains(v) {
+			out.m[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// IsSubset 判断 s 是否是 o 的子集
+func (s *Set[T]) IsSubset(o *Set[T]) bool {
+	if s.Len() > o.Len() {
+		return false
+	}
+	for v := range s.m {
+		if !o.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 判断两个集合是否包含相同的元素
+func (s *Set[T]) Equal(o *Set[T]) bool {
+	return s.Len() == o.Len() && s.IsSubset(o)
+}
+
+// Sorted 返回排好序的元素切片，方便得到确定的输出顺序
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	return slices.Sorted(s.All())
+}
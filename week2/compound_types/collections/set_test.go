@@ -0,0 +1,62 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet(1, 2, 3, 3)
+	b := NewSet(2, 3, 4)
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{"并集", a.Union(b), []int{1, 2, 3, 4}},
+		{"交集", a.Intersection(b), []int{2, 3}},
+		{"差集", a.Difference(b), []int{1}},
+		{"对称差", a.SymmetricDifference(b), []int{1, 4}},
+		{"与零值集合的并集", new(Set[int]).Union(a), []int{1, 2, 3}},
+		{"与零值集合的交集", a.Intersection(new(Set[int])), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sorted(tt.got); !slices.Equal(got, tt.want) {
+				t.Errorf("得到 %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if a.Len() != 3 || b.Len() != 3 {
+		t.Errorf("集合运算修改了参数: a=%v b=%v", Sorted(a), Sorted(b))
+	}
+}
+
+func TestSetMembership(t *testing.T) {
+	var s Set[string] // 零值可以直接使用
+	if s.Remove("x") || s.Contains("x") {
+		t.Error("空集合不应该包含任何元素")
+	}
+	s.Add("x", "y")
+	if !s.Remove("x") || s.Remove("x") || s.Len() != 1 {
+		t.Errorf("删除之后 = %v, want [y]", Sorted(&s))
+	}
+	tests := []struct {
+		a, b             *Set[int]
+		subset, equality bool
+	}{
+		{NewSet(1, 2), NewSet(1, 2, 3), true, false},
+		{NewSet(1, 2, 3), NewSet(1, 2), false, false},
+		{NewSet(1, 2), NewSet(2, 1), true, true},
+		{NewSet[int](), NewSet(1), true, false},
+		{NewSet(1, 4), NewSet(1, 2, 3), false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.IsSubset(tt.b); got != tt.subset {
+			t.Errorf("%v.IsSubset(%v) = %v, want %v", Sorted(tt.a), Sorted(tt.b), got, tt.subset)
+		}
+		if got := tt.a.Equal(tt.b); got != tt.equality {
+			t.Errorf("%v.Equal(%v) = %v, want %v", Sorted(tt.a), Sorted(tt.b), got, tt.equality)
+		}
+	}
+}
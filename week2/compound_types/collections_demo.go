@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Mag1cFall/go-get-started/week2/compound_types/collections"
+)
+
+// --- 5. 用切片和 map 搭建的泛型容器 ---
+// 内置的切片和 map 已经能覆盖大多数需求，但集合、有序 map、双端队列、优先队列
+// 这几种容器经常被重复实现。collections 包用泛型把它们各实现一次。
+func demoCollections() {
+	fmt.Println("\n--- 5. 泛型容器 (collections 包) ---")
+
+	// Set: 两组学生选修的课程
+	alice := collections.NewSet("Go", "数据库", "网络")
+	bob := collections.NewSet("Go", "算法", "网络")
+	fmt.Println("都选了:", collections.Sorted(alice.Intersection(bob)))
+	fmt.Println("一共开了:", collections.Sorted(alice.Union(bob)))
+	fmt.Println("只有 Alice 选了:", collections.Sorted(alice.Difference(bob)))
+
+	// OrderedMap: 遍历顺序就是插入顺序，而内置 map 每次运行都可能不同
+	capitals := collections.NewOrderedMap[string, string]()
+	capitals.Set("中国", "北京")
+	capitals.Set("日本", "东京")
+	capitals.Set("法国", "巴黎")
+	capitals.Set("中国", "北京市") // 更新已有的键，位置不变
+	for country, city := range capitals.All() {
+		fmt.Printf("  %s -> %s\n", country, city)
+	}
+
+	// Deque: 两端都能进出
+	dq := collections.NewDeque[int](4)
+	for i := 1; i <= 3; i++ {
+		dq.PushBack(i)
+		dq.PushFront(-i)
+	}
+	front, _ := dq.PopFront()
+	back, _ := dq.PopBack()
+	fmt.Println("Deque:", slices.Collect(dq.Values()), "| 弹出队首", front, "队尾", back)
+
+	// PriorityQueue: 按优先级处理任务，数字越小越紧急
+	type task struct {
+		name     string
+		priority int
+	}
+	pq := collections.NewPriorityQueue(func(a, b task) bool { return a.priority < b.priority })
+	pq.Push(task{"写文档", 3}, task{"修复线上故障", 0}, task{"代码评审", 2}, task{"回复邮件", 5})
+	var order []string
+	for t := range pq.Drain() {
+		order = append(order, t.name)
+	}
+	fmt.Println("处理顺序:", strings.Join(order, " -> "))
+}
@@ -0,0 +1,192 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU 是方阵的 LU 分解 PA = LU：P 是行置换矩阵，L 是对角线为 1 的下三角矩阵，U 是上三角矩阵。
+//
+// 分解一次的代价是 O(n³)，之后求行列式只需 O(n)，解一个方程组只需 O(n²)，
+// 所以同一个系数矩阵要解多个方程组时，应该先分解再反复调用 Solve。
+type LU struct {
+	n        int
+	lu       []float64 // L 的严格下三角部分和 U 存放在同一个 n×n 数组中
+	piv      []int     // 分解后的第 i 行来自原矩阵的第 piv[i] 行
+	sign     float64   // 行交换次数为偶数时为 1，奇数时为 -1
+	singular bool
+}
+
+// LU 使用部分主元高斯消元法分解方阵。每一列都选绝对值最大的元素作为主元，
+// 避免除以很小的数放大舍入误差。奇异矩阵也能完成分解，但之后不能用于求解和求逆。
+func (m *Matrix[T]) LU() (*LU, error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("%w: LU 分解需要方阵, 得到 %d×%d", ErrNotSquare, m.rows, m.cols)
+	}
+	n := m.rows
+	f := &LU{n: n, lu: m.Float().data, piv: make([]int, n), sign: 1}
+	a := f.lu
+
+	// 主元的绝对值小于这个阈值就认为是 0，阈值与矩阵元素的量级成正比
+	maxAbs := 0.0
+	for _, v := range a {
+		maxAbs = max(maxAbs, math.Abs(v))
+	}
+	tol := float64(n) * maxAbs * 0x1p-52
+
+	for i := range f.piv {
+		f.piv[i] = i
+	}
+	for k := range n {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := range n {
+				a[p*n+j], a[k*n+j] = a[k*n+j], a[p*n+j]
+			}
+			f.piv[p], f.piv[k] = f.piv[k], f.piv[p]
+			f.sign = -f.sign
+		}
+		pivot := a[k*n+k]
+		if math.Abs(pivot) <= tol {
+			f.singular = true
+			continue // 这一列已经全是 0，不需要消元
+		}
+		for i := k + 1; i < n; i++ {
+			l := a[i*n+k] / pivot
+			a[i*n+k] = l
+			if l == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= l * a[k*n+j]
+			}
+		}
+	}
+	return f, nil
+}
+
+// Singular 报告矩阵是否 (在数值精度范围内) 奇异
+func (f *LU) Singular() bool { return f.singular }
+
+// Pivot 返回行置换：PA 的第 i 行是 A 的第 Pivot()[i] 行
+func (f *LU) Pivot() []int { return append([]int(nil), f.piv...) }
+
+// L 返回单位下三角矩阵
+func (f *LU) L() *Matrix[float64] {
+	l := Identity[float64](f.n)
+	for i := range f.n {
+		for j := range i {
+			l.data[i*f.n+j] = f.lu[i*f.n+j]
+		}
+	}
+	return l
+}
+
+// U 返回上三角矩阵
+func (f *LU) U() *Matrix[float64] {
+	u, _ := New[float64](f.n, f.n)
+	for i := range f.n {
+		for j := i; j < f.n; j++ {
+			u.data[i*f.n+j] = f.lu[i*f.n+j]
+		}
+	}
+	return u
+}
+
+// Det 返回行列式：det(A) = det(P)⁻¹·det(L)·det(U) = sign·ΠUᵢᵢ
+func (f *LU) Det() float64 {
+	if f.singular {
+		return 0
+	}
+	d := f.sign
+	for i := range f.n {
+		d *= f.lu[i*f.n+i]
+	}
+	return d
+}
+
+// Solve 解线性方程组 Ax = b
+func (f *LU) Solve(b []float64) ([]float64, error) {
+	if len(b) != f.n {
+		return nil, fmt.Errorf("%w: %d×%d 的方程组需要长度为 %d 的右端向量, 得到 %d",
+			ErrDimensionMismatch, f.n, f.n, f.n, len(b))
+	}
+	if f.singular {
+		return nil, ErrSingular
+	}
+	n, a := f.n, f.lu
+	// 先解 Ly = Pb (前代)，再解 Ux = y (回代)
+	x := make([]float64, n)
+	for i := range n {
+		sum := b[f.piv[i]]
+		for j := range i {
+			sum -= a[i*n+j] * x[j]
+		}
+		x[i] = sum
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= a[i*n+j] * x[j]
+		}
+		x[i] = sum / a[i*n+i]
+	}
+	return x, nil
+}
+
+// Inverse 返回逆矩阵：依次以单位矩阵的每一列为右端解方程组，解就是逆矩阵的对应列
+func (f *LU) Inverse() (*Matrix[float64], error) {
+	if f.singular {
+		return nil, ErrSingular
+	}
+	inv, _ := New[float64](f.n, f.n)
+	e := make([]float64, f.n)
+	for j := range f.n {
+		clear(e)
+		e[j] = 1
+		col, err := f.Solve(e)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range col {
+			inv.data[i*f.n+j] = v
+		}
+	}
+	return inv, nil
+}
+
+// Det 返回方阵的行列式
+func (m *Matrix[T]) Det() (float64, error) {
+	f, err := m.LU()
+	if err != nil {
+		return 0, err
+	}
+	return f.Det(), nil
+}
+
+// Inverse 返回方阵的逆矩阵，奇异矩阵返回 ErrSingular
+func (m *Matrix[T]) Inverse() (*Matrix[float64], error) {
+	f, err := m.LU()
+	if err != nil {
+		return nil, err
+	}
+	return f.Inverse()
+}
+
+// Solve 解线性方程组 m·x = b
+func (m *Matrix[T]) Solve(b []T) ([]float64, error) {
+	f, err := m.LU()
+	if err != nil {
+		return nil, err
+	}
+	fb := make([]float64, len(b))
+	for i, v := range b {
+		fb[i] = float64(v)
+	}
+	return f.Solve(fb)
+}
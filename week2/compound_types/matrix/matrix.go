@@ -0,0 +1,217 @@
+// Package matrix 实现了一个泛型稠密矩阵 Matrix[T]。
+//
+// 数组 [2][3]int 的大小是类型的一部分，编译时就必须确定；真正的矩阵运算需要在运行时决定大小，
+// 所以 Matrix 用一个一维切片按行存放全部元素 (行主序)：第 i 行第 j 列的元素位于 data[i*cols+j]。
+// 与 [][]T 相比，一维切片只需要一次内存分配，元素在内存中连续，遍历时对 CPU 缓存更友好。
+//
+// 加法、乘法等运算在 T 上进行；行列式、LU 分解、求逆和解方程需要做除法，统一在 float64 上计算。
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Number 是矩阵元素类型的约束
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+var (
+	// ErrDimensionMismatch 表示参与运算的矩阵形状不匹配
+	ErrDimensionMismatch = errors.New("矩阵维度不匹配")
+	// ErrNotSquare 表示运算要求方阵
+	ErrNotSquare = errors.New("矩阵不是方阵")
+	// ErrSingular 表示矩阵奇异 (行列式为 0)，不可逆
+	ErrSingular = errors.New("矩阵是奇异的")
+)
+
+// Matrix 是 rows 行 cols 列的稠密矩阵，零值是 0×0 的空矩阵。
+type Matrix[T Number] struct {
+	rows, cols int
+	data       []T
+}
+
+// New 创建 rows×cols 的零矩阵
+func New[T Number](rows, cols int) (*Matrix[T], error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("%w: 行数和列数不能为负, 得到 %d×%d", ErrDimensionMismatch, rows, cols)
+	}
+	return &Matrix[T]{rows: rows, cols: cols, data: make([]T, rows*cols)}, nil
+}
+
+// FromSlice 用行主序的 data 创建 rows×cols 的矩阵，data 会被复制
+func FromSlice[T Number](rows, cols int, data []T) (*Matrix[T], error) {
+	m, err := New[T](rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != rows*cols {
+		return nil, fmt.Errorf("%w: %d×%d 的矩阵需要 %d 个元素, 得到 %d 个",
+			ErrDimensionMismatch, rows, cols, rows*cols, len(data))
+	}
+	copy(m.data, data)
+	return m, nil
+}
+
+// FromRows 用二维切片创建矩阵，每一行的长度必须相同
+func FromRows[T Number](rows [][]T) (*Matrix[T], error) {
+	if len(rows) == 0 {
+		return &Matrix[T]{}, nil
+	}
+	cols := len(rows[0])
+	m, _ := New[T](len(rows), cols)
+	for i, row := range rows {
+		if len(row) != cols {
+			return nil, fmt.Errorf("%w: 第 %d 行有 %d 个元素, 第 0 行有 %d 个", ErrDimensionMismatch, i, len(row), cols)
+		}
+		copy(m.data[i*cols:], row)
+	}
+	return m, nil
+}
+
+// Identity 返回 n×n 的单位矩阵
+func Identity[T Number](n int) *Matrix[T] {
+	m := &Matrix[T]{rows: n, cols: n, data: make([]T, n*n)}
+	for i := range n {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Rows 返回行数
+func (m *Matrix[T]) Rows() int { return m.rows }
+
+// Cols 返回列数
+func (m *Matrix[T]) Cols() int { return m.cols }
+
+// Dims 返回行数和列数
+func (m *Matrix[T]) Dims() (rows, cols int) { return m.rows, m.cols }
+
+// At 返回第 i 行第 j 列的元素 (从 0 开始)，越界时 panic，与切片下标越界的行为一致
+func (m *Matrix[T]) At(i, j int) T {
+	m.check(i, j)
+	return m.data[i*m.cols+j]
+}
+
+// Set 设置第 i 行第 j 列的元素
+func (m *Matrix[T]) Set(i, j int, v T) {
+	m.check(i, j)
+	m.data[i*m.cols+j] = v
+}
+
+func (m *Matrix[T]) check(i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("matrix: 下标 (%d, %d) 超出 %d×%d 矩阵的范围", i, j, m.rows, m.cols))
+	}
+}
+
+// Row 返回第 i 行的副本。只检查行下标，r×0 矩阵的每一行都是空切片
+func (m *Matrix[T]) Row(i int) []T {
+	if i < 0 || i >= m.rows {
+		panic(fmt.Sprintf("matrix: 行下标 %d 超出 %d×%d 矩阵的范围", i, m.rows, m.cols))
+	}
+	return append([]T(nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+// ToRows 把矩阵复制为二维切片
+func (m *Matrix[T]) ToRows() [][]T {
+	out := make([][]T, m.rows)
+	for i := range out {
+		out[i] = m.Row(i)
+	}
+	return out
+}
+
+// Clone 返回矩阵的深拷贝
+func (m *Matrix[T]) Clone() *Matrix[T] {
+	return &Matrix[T]{rows: m.rows, cols: m.cols, data: append([]T(nil), m.data...)}
+}
+
+// Equal 判断两个矩阵形状相同且所有元素相等
+func (m *Matrix[T]) Equal(b *Matrix[T]) bool {
+	if m.rows != b.rows || m.cols != b.cols {
+		return false
+	}
+	for i, v := range m.data {
+		if v != b.data[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Transpose 返回转置矩阵
+func (m *Matrix[T]) Transpose() *Matrix[T] {
+	t := &Matrix[T]{rows: m.cols, cols: m.rows, data: make([]T, len(m.data))}
+	for i := range m.rows {
+		for j := range m.cols {
+			t.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Add 返回 m + b，两个矩阵的形状必须相同
+func (m *Matrix[T]) Add(b *Matrix[T]) (*Matrix[T], error) {
+	return m.elementwise(b, "相加", func(x, y T) T { return x + y })
+}
+
+// Sub 返回 m - b，两个矩阵的形状必须相同
+func (m *Matrix[T]) Sub(b *Matrix[T]) (*Matrix[T], error) {
+	return m.elementwise(b, "相减", func(x, y T) T { return x - y })
+}
+
+func (m *Matrix[T]) elementwise(b *Matrix[T], op string, f func(x, y T) T) (*Matrix[T], error) {
+	if m.rows != b.rows || m.cols != b.cols {
+		return nil, fmt.Errorf("%w: %d×%d 与 %d×%d 不能%s", ErrDimensionMismatch, m.rows, m.cols, b.rows, b.cols, op)
+	}
+	out := &Matrix[T]{rows: m.rows, cols: m.cols, data: make([]T, len(m.data))}
+	for i := range m.data {
+		out.data[i] = f(m.data[i], b.data[i])
+	}
+	return out, nil
+}
+
+// Scale 返回 k 乘以矩阵的每个元素
+func (m *Matrix[T]) Scale(k T) *Matrix[T] {
+	out := m.Clone()
+	for i := range out.data {
+		out.data[i] *= k
+	}
+	return out
+}
+
+// Float 把矩阵转换为 float64 元素
+func (m *Matrix[T]) Float() *Matrix[float64] {
+	out := &Matrix[float64]{rows: m.rows, cols: m.cols, data: make([]float64, len(m.data))}
+	for i, v := range m.data {
+		out.data[i] = float64(v)
+	}
+	return out
+}
+
+// String 按行输出矩阵，每一列右对齐
+func (m *Matrix[T]) String() string {
+	cells := make([]string, len(m.data))
+	width := 0
+	for i, v := range m.data {
+		cells[i] = fmt.Sprint(v)
+		width = max(width, len(cells[i]))
+	}
+	var sb strings.Builder
+	for i := range m.rows {
+		sb.WriteByte('[')
+		for j := range m.cols {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprintf(&sb, "%*s", width, cells[i*m.cols+j])
+		}
+		sb.WriteString("]\n")
+	}
+	return sb.String()
+}
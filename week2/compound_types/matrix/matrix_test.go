@@ -0,0 +1,198 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// random 生成元素在 [-1, 1) 内的 rows×cols 随机矩阵，使用固定种子保证每次运行的数据相同
+func random(rows, cols int, seed uint64) *Matrix[float64] {
+	r := rand.New(rand.NewPCG(seed, 7))
+	m, _ := New[float64](rows, cols)
+	for i := range m.data {
+		m.data[i] = r.Float64()*2 - 1
+	}
+	return m
+}
+
+// mulNaive 是按 i-j-k 顺序串行计算的对照组
+func mulNaive[T Number](m, b *Matrix[T]) *Matrix[T] {
+	out, _ := New[T](m.rows, b.cols)
+	for i := range m.rows {
+		for j := range b.cols {
+			var sum T
+			for k := range m.cols {
+				sum += m.data[i*m.cols+k] * b.data[k*b.cols+j]
+			}
+			out.data[i*b.cols+j] = sum
+		}
+	}
+	return out
+}
+
+func TestMul(t *testing.T) {
+	a, _ := FromRows([][]int{{1, 2, 3}, {4, 5, 6}})
+	b, _ := FromRows([][]int{{7, 8}, {9, 10}, {11, 12}})
+	want, _ := FromRows([][]int{{58, 64}, {139, 154}})
+	got, err := a.Mul(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("a×b =\n%vwant\n%v", got, want)
+	}
+	if _, err := a.Mul(a); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("2×3 乘 2×3 的错误 = %v, want ErrDimensionMismatch", err)
+	}
+
+	// 足够大的矩阵会走并行分支，结果必须与串行的朴素算法一致
+	x, y := random(150, 120, 1), random(120, 90, 2)
+	par, _ := x.Mul(y)
+	naive := mulNaive(x, y)
+	for i := range par.data {
+		if math.Abs(par.data[i]-naive.data[i]) > 1e-9 {
+			t.Fatalf("并行乘法第 %d 个元素 = %g, want %g", i, par.data[i], naive.data[i])
+		}
+	}
+}
+
+func TestLU(t *testing.T) {
+	a, _ := FromRows([][]float64{{2, 1, 1}, {4, -6, 0}, {-2, 7, 2}})
+	det, err := a.Det()
+	if err != nil || math.Abs(det-(-16)) > 1e-9 {
+		t.Errorf("Det() = %g, %v; want -16", det, err)
+	}
+
+	// PA = LU
+	f, _ := a.LU()
+	lu, _ := f.L().Mul(f.U())
+	for i, p := range f.Pivot() {
+		for j := range 3 {
+			if math.Abs(lu.At(i, j)-a.At(p, j)) > 1e-12 {
+				t.Fatalf("LU 的第 %d 行与 PA 不一致: %v vs %v", i, lu.Row(i), a.Row(p))
+			}
+		}
+	}
+
+	// A·A⁻¹ = I，A·x = b
+	m := random(40, 40, 3)
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod, _ := m.Mul(inv)
+	for i := range 40 {
+		for j := range 40 {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(prod.At(i, j)-want) > 1e-9 {
+				t.Fatalf("A·A⁻¹[%d][%d] = %g, want %g", i, j, prod.At(i, j), want)
+			}
+		}
+	}
+	b := random(1, 40, 4).Row(0)
+	x, err := m.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax, _ := m.MulVec(x)
+	for i := range b {
+		if math.Abs(ax[i]-b[i]) > 1e-9 {
+			t.Fatalf("(A·x)[%d] = %g, want %g", i, ax[i], b[i])
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	singular, _ := FromRows([][]int{{1, 2}, {2, 4}})
+	if det, _ := singular.Det(); det != 0 {
+		t.Errorf("奇异矩阵的 Det() = %g, want 0", det)
+	}
+	if _, err := singular.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("Inverse() 错误 = %v, want ErrSingular", err)
+	}
+	rect, _ := New[int](2, 3)
+	if _, err := rect.Det(); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Det() 错误 = %v, want ErrNotSquare", err)
+	}
+	if _, err := FromRows([][]int{{1, 2}, {3}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("FromRows 错误 = %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := singular.Solve([]int{1, 2, 3}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Solve 错误 = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+// 运行: go test -bench=Mul -benchmem ./week2/compound_types/matrix
+func BenchmarkMul512(b *testing.B) {
+	x, y := random(512, 512, 1), random(512, 512, 2)
+	b.ResetTimer()
+	for range b.N {
+		_, _ = x.Mul(y)
+	}
+}
+
+func BenchmarkMul512Int(b *testing.B) {
+	x, _ := New[int](512, 512)
+	for i := range x.data {
+		x.data[i] = i % 17
+	}
+	y := x.Transpose()
+	b.ResetTimer()
+	for range b.N {
+		_, _ = x.Mul(y)
+	}
+}
+
+// BenchmarkMulNaive512 是 i-j-k 顺序的串行版本，用来对比循环顺序和并行带来的差距
+func BenchmarkMulNaive512(b *testing.B) {
+	x, y := random(512, 512, 1), random(512, 512, 2)
+	b.ResetTimer()
+	for range b.N {
+		_ = mulNaive(x, y)
+	}
+}
+
+// 没有列的矩阵也是合法的，按行复制时不能因为列下标 0 越界而 panic
+func TestZeroColumns(t *testing.T) {
+	m, err := New[int](2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := m.ToRows(); len(rows) != 2 || len(rows[0]) != 0 || len(rows[1]) != 0 {
+		t.Errorf("New(2, 0).ToRows() = %v, want 两个空行", rows)
+	}
+	f, err := FromRows([][]int{{}, {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, c := f.Dims(); r != 2 || c != 0 || len(f.Row(1)) != 0 {
+		t.Errorf("FromRows 得到 %d×%d 矩阵, want 2×0", r, c)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Row(2) 应该因为行下标越界而 panic")
+		}
+	}()
+	m.Row(2)
+}
+
+// 乘法必须保留 IEEE 754 语义：0 × +Inf 是 NaN，不能因为左边是 0 就跳过
+func TestMulIEEE(t *testing.T) {
+	a, _ := FromRows([][]float64{{0, 0}, {1, 0}})
+	b, _ := FromRows([][]float64{{0, math.Inf(1)}, {0, 0}})
+	got, err := a.Mul(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := got.At(0, 1); !math.IsNaN(v) {
+		t.Errorf("(0, 1) = %v, want NaN", v)
+	}
+	if v := got.At(1, 1); !math.IsInf(v, 1) {
+		t.Errorf("(1, 1) = %v, want +Inf", v)
+	}
+}
@@ -0,0 +1,74 @@
+package matrix
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// parallelThreshold 是并行计算乘法的最小工作量 (乘加次数)。
+// 小矩阵启动 goroutine 的开销比计算本身还大，直接串行计算。
+const parallelThreshold = 64 * 64 * 64
+
+// Mul 返回矩阵乘积 m × b，要求 m 的列数等于 b 的行数。
+//
+// 朴素的三重循环按 i-j-k 的顺序计算 out[i][j] = Σ m[i][k]·b[k][j]，
+// 最内层循环沿着 b 的列跳跃访问内存，几乎每次都会缓存未命中。
+// 这里把循环顺序换成 i-k-j：最内层循环顺序读取 b 的第 k 行、顺序写入 out 的第 i 行，
+// 结果完全相同，速度却快得多 (见 matrix_test.go 中的基准测试)。
+// 矩阵较大时，按行把输出分给多个 goroutine 并行计算，各自写入不同的行，不需要加锁。
+func (m *Matrix[T]) Mul(b *Matrix[T]) (*Matrix[T], error) {
+	if m.cols != b.rows {
+		return nil, fmt.Errorf("%w: %d×%d 与 %d×%d 不能相乘", ErrDimensionMismatch, m.rows, m.cols, b.rows, b.cols)
+	}
+	out := &Matrix[T]{rows: m.rows, cols: b.cols, data: make([]T, m.rows*b.cols)}
+	workers := runtime.GOMAXPROCS(0)
+	if m.rows*m.cols*b.cols < parallelThreshold || workers == 1 || m.rows < 2 {
+		mulRows(out, m, b, 0, m.rows)
+		return out, nil
+	}
+	chunk := (m.rows + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < m.rows; lo += chunk {
+		hi := min(lo+chunk, m.rows)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mulRows(out, m, b, lo, hi)
+		}()
+	}
+	wg.Wait()
+	return out, nil
+}
+
+// mulRows 计算 out 的第 lo 到 hi-1 行。
+// 这里不能跳过 a == 0 的项：浮点数中 0 × Inf = NaN，跳过会改变 IEEE 754 的结果
+func mulRows[T Number](out, m, b *Matrix[T], lo, hi int) {
+	n := b.cols
+	for i := lo; i < hi; i++ {
+		outRow := out.data[i*n : (i+1)*n]
+		for k := range m.cols {
+			a := m.data[i*m.cols+k]
+			bRow := b.data[k*n : (k+1)*n]
+			for j, v := range bRow {
+				outRow[j] += a * v
+			}
+		}
+	}
+}
+
+// MulVec 返回矩阵与列向量的乘积 m·x
+func (m *Matrix[T]) MulVec(x []T) ([]T, error) {
+	if len(x) != m.cols {
+		return nil, fmt.Errorf("%w: %d×%d 矩阵不能与长度为 %d 的向量相乘", ErrDimensionMismatch, m.rows, m.cols, len(x))
+	}
+	out := make([]T, m.rows)
+	for i := range m.rows {
+		var sum T
+		for j, v := range m.data[i*m.cols : (i+1)*m.cols] {
+			sum += v * x[j]
+		}
+		out[i] = sum
+	}
+	return out, nil
+}
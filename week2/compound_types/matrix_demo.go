@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Mag1cFall/go-get-started/week2/compound_types/matrix"
+)
+
+// --- 4. 运行时决定大小的矩阵 ---
+// [2][3]int 的大小写死在类型里；matrix 包用一维切片存放元素，行数和列数在运行时决定。
+func demoMatrix() {
+	fmt.Println("\n--- 4. 矩阵 (matrix 包) ---")
+	a, err := matrix.FromRows([][]float64{
+		{2, 1, 1},
+		{4, -6, 0},
+		{-2, 7, 2},
+	})
+	if err != nil {
+		fmt.Println("创建矩阵失败:", err)
+		return
+	}
+	fmt.Print("矩阵 A:\n", a)
+	fmt.Print("A 的转置:\n", a.Transpose())
+
+	det, _ := a.Det()
+	fmt.Println("det(A) =", det)
+
+	// 解方程组 A·x = b
+	x, err := a.Solve([]float64{5, -2, 9})
+	if err != nil {
+		fmt.Println("解方程失败:", err)
+		return
+	}
+	fmt.Printf("A·x = [5 -2 9] 的解: %.4g\n", x)
+
+	inv, _ := a.Inverse()
+	prod, _ := a.Mul(inv)
+	fmt.Printf("A·A⁻¹ 的第一行 (应为单位矩阵): %.3g\n", prod.Row(0))
+
+	// 维度不匹配时返回错误而不是 panic
+	b, _ := matrix.New[float64](2, 2)
+	if _, err := a.Mul(b); err != nil {
+		fmt.Println("A×B 出错:", err)
+	}
+}
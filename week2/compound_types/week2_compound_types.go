@@ -195,5 +195,8 @@ func main() {
 		fmt.Println("国家:", country)
 	}
 
+	demoMatrix()
+	demoCollections()
+
 	fmt.Println("\n--- 复合类型学习结束 ---")
 }
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// --- 4. 泛型向量 ---
+// Point 只能存放整数坐标。如果还想要 float64 或 float32 的版本，
+// 不必为每种数字类型各写一遍，可以用类型参数 (泛型) 定义一次:
+// Vec2[T] 中的 T 是类型参数，Number 约束规定了 T 只能是哪些类型。
+// 泛型类型也可以有方法，但方法本身不能再声明新的类型参数。
+
+// Number 是所有内置数字类型的约束。~int 表示 "底层类型是 int 的任何类型"，
+// 所以 type Meter float64 这样的自定义类型也满足约束。
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// ErrZeroVector 表示对零向量做了没有意义的运算，例如归一化或求夹角
+var ErrZeroVector = errors.New("零向量没有方向")
+
+// Vec2 是二维向量。加减、点积、叉积在 T 上计算，结果仍然是 T
+// (整数向量的运算可能溢出，与普通整数运算相同)；
+// 长度、归一化、插值、夹角、投影的结果一般不是整数，统一返回 float64。
+type Vec2[T Number] struct {
+	X, Y T
+}
+
+// Vec3 是三维向量，方法与 Vec2 相同，叉积返回向量而不是标量
+type Vec3[T Number] struct {
+	X, Y, Z T
+}
+
+// --- 与 Point 的转换 ---
+
+// Vec2From 把 Point 转换为任意数字类型的二维向量，例如 Vec2From[float64](p)
+func Vec2From[T Number](p Point) Vec2[T] {
+	return Vec2[T]{X: T(p.X), Y: T(p.Y)}
+}
+
+// Vec3From 把 Point 转换为三维向量，z 是第三个坐标
+func Vec3From[T Number](p Point, z T) Vec3[T] {
+	return Vec3[T]{X: T(p.X), Y: T(p.Y), Z: z}
+}
+
+// Vec 返回与 Point 坐标相同的整数向量
+func (p Point) Vec() Vec2[int] {
+	return Vec2From[int](p)
+}
+
+// Point 把向量四舍五入为整数坐标的 Point
+func (v Vec2[T]) Point() Point {
+	return Point{X: int(math.Round(float64(v.X))), Y: int(math.Round(float64(v.Y)))}
+}
+
+// --- Vec2 的方法 ---
+
+// Add、Sub、Scale 分别是向量加法、减法和数乘
+func (v Vec2[T]) Add(w Vec2[T]) Vec2[T] { return Vec2[T]{v.X + w.X, v.Y + w.Y} }
+func (v Vec2[T]) Sub(w Vec2[T]) Vec2[T] { return Vec2[T]{v.X - w.X, v.Y - w.Y} }
+func (v Vec2[T]) Scale(k T) Vec2[T]     { return Vec2[T]{v.X * k, v.Y * k} }
+
+// Dot 返回点积 v·w
+func (v Vec2[T]) Dot(w Vec2[T]) T { return v.X*w.X + v.Y*w.Y }
+
+// Cross 返回二维叉积 (即三维叉积的 z 分量)，大于 0 表示 w 在 v 的逆时针方向
+func (v Vec2[T]) Cross(w Vec2[T]) T { return v.X*w.Y - v.Y*w.X }
+
+// NormSq 返回长度的平方，比较长短时用它可以避免开方
+func (v Vec2[T]) NormSq() T { return v.Dot(v) }
+
+// Norm 返回向量长度
+func (v Vec2[T]) Norm() float64 {
+	f := v.Float()
+	return math.Hypot(f.X, f.Y)
+}
+
+// Float 把向量转换为 float64 分量
+func (v Vec2[T]) Float() Vec2[float64] {
+	return Vec2[float64]{float64(v.X), float64(v.Y)}
+}
+
+// Normalize 返回方向相同的单位向量，零向量返回 ErrZeroVector
+func (v Vec2[T]) Normalize() (Vec2[float64], error) {
+	n := v.Norm()
+	if n == 0 {
+		return Vec2[float64]{}, ErrZeroVector
+	}
+	f := v.Float()
+	return Vec2[float64]{f.X / n, f.Y / n}, nil
+}
+
+// Lerp 在 v 和 w 之间线性插值，t=0 得到 v，t=1 得到 w
+func (v Vec2[T]) Lerp(w Vec2[T], t float64) Vec2[float64] {
+	a, b := v.Float(), w.Float()
+	return Vec2[float64]{a.X + (b.X-a.X)*t, a.Y + (b.Y-a.Y)*t}
+}
+
+// AngleTo 返回 v 与 w 之间的夹角 (弧度，范围 [0, π])
+func (v Vec2[T]) AngleTo(w Vec2[T]) (float64, error) {
+	a, b := v.Float(), w.Float()
+	if a == (Vec2[float64]{}) || b == (Vec2[float64]{}) {
+		return 0, ErrZeroVector
+	}
+	// atan2(|叉积|, 点积) 在夹角接近 0 或 π 时比 acos 精确
+	return math.Atan2(math.Abs(a.Cross(b)), a.Dot(b)), nil
+}
+
+// Project 返回 v 在 w 方向上的投影向量
+func (v Vec2[T]) Project(w Vec2[T]) (Vec2[float64], error) {
+	a, b := v.Float(), w.Float()
+	d := b.Dot(b)
+	if d == 0 {
+		return Vec2[float64]{}, fmt.Errorf("不能投影到零向量上: %w", ErrZeroVector)
+	}
+	return b.Scale(a.Dot(b) / d), nil
+}
+
+// --- Vec3 的方法 ---
+
+// Add、Sub、Scale 分别是向量加法、减法和数乘
+func (v Vec3[T]) Add(w Vec3[T]) Vec3[T] { return Vec3[T]{v.X + w.X, v.Y + w.Y, v.Z + w.Z} }
+func (v Vec3[T]) Sub(w Vec3[T]) Vec3[T] { return Vec3[T]{v.X - w.X, v.Y - w.Y, v.Z - w.Z} }
+func (v Vec3[T]) Scale(k T) Vec3[T]     { return Vec3[T]{v.X * k, v.Y * k, v.Z * k} }
+
+// Dot 返回点积 v·w
+func (v Vec3[T]) Dot(w Vec3[T]) T { return v.X*w.X + v.Y*w.Y + v.Z*w.Z }
+
+// Cross 返回叉积 v×w，它同时垂直于 v 和 w，方向由右手定则决定
+func (v Vec3[T]) Cross(w Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		v.Y*w.Z - v.Z*w.Y,
+		v.Z*w.X - v.X*w.Z,
+		v.X*w.Y - v.Y*w.X,
+	}
+}
+
+// NormSq 返回长度的平方
+func (v Vec3[T]) NormSq() T { return v.Dot(v) }
+
+// Norm 返回向量长度。与 math.Hypot 相同，先除以绝对值最大的分量再平方，
+// 否则分量超过约 1e154 时平方会上溢为 +Inf，小于约 1e-154 时会下溢为 0。
+func (v Vec3[T]) Norm() float64 {
+	f := v.Float()
+	if math.IsInf(f.X, 0) || math.IsInf(f.Y, 0) || math.IsInf(f.Z, 0) {
+		return math.Inf(1)
+	}
+	m := max(math.Abs(f.X), math.Abs(f.Y), math.Abs(f.Z))
+	if m == 0 || math.IsNaN(m) {
+		return m
+	}
+	s := Vec3[float64]{f.X / m, f.Y / m, f.Z / m}
+	return m * math.Sqrt(s.Dot(s))
+}
+
+// Float 把向量转换为 float64 分量
+func (v Vec3[T]) Float() Vec3[float64] {
+	return Vec3[float64]{float64(v.X), float64(v.Y), float64(v.Z)}
+}
+
+// Normalize 返回方向相同的单位向量，零向量返回 ErrZeroVector
+func (v Vec3[T]) Normalize() (Vec3[float64], error) {
+	n := v.Norm()
+	if n == 0 {
+		return Vec3[float64]{}, ErrZeroVector
+	}
+	f := v.Float()
+	return Vec3[float64]{f.X / n, f.Y / n, f.Z / n}, nil // 长度很小时 1/n 会溢出，所以逐个分量相除
+}
+
+// Lerp 在 v 和 w 之间线性插值，t=0 得到 v，t=1 得到 w
+func (v Vec3[T]) Lerp(w Vec3[T], t float64) Vec3[float64] {
+	a, b := v.Float(), w.Float()
+	return a.Add(b.Sub(a).Scale(t))
+}
+
+// AngleTo 返回 v 与 w 之间的夹角 (弧度，范围 [0, π])。
+// 先归一化再求叉积和点积，分量很大或很小时也不会溢出。
+func (v Vec3[T]) AngleTo(w Vec3[T]) (float64, error) {
+	a, err := v.Normalize()
+	if err != nil {
+		return 0, err
+	}
+	b, err := w.Normalize()
+	if err != nil {
+		return 0, err
+	}
+	return math.Atan2(a.Cross(b).Norm(), a.Dot(b)), nil
+}
+
+// Project 返回 v 在 w 方向上的投影向量，即 (v·u)u，u 是 w 方向的单位向量。
+// 不使用 (v·w / w·w)w，因为 w·w 可能溢出。
+func (v Vec3[T]) Project(w Vec3[T]) (Vec3[float64], error) {
+	u, err := w.Normalize()
+	if err != nil {
+		return Vec3[float64]{}, fmt.Errorf("不能投影到零向量上: %w", err)
+	}
+	return u.Scale(v.Float().Dot(u)), nil
+}
+
+func demoVectors() {
+	fmt.Println("\n--- 4. 泛型向量 ---")
+	pA, pB := Point{X: 1, Y: 2}, Point{X: 4, Y: 6}
+	a, b := pA.Vec(), pB.Vec() // Vec2[int]
+	fmt.Printf("a = %+v, b = %+v\n", a, b)
+	fmt.Printf("a + b = %+v, b - a = %+v, a·b = %d, a×b = %d\n", a.Add(b), b.Sub(a), a.Dot(b), a.Cross(b))
+	fmt.Printf("|b - a| = %.2f (与 pA.Distance(pB) 相同: %.2f)\n", b.Sub(a).Norm(), pA.Distance(pB))
+
+	u, _ := b.Normalize()
+	fmt.Printf("b 的单位向量: (%.3f, %.3f)\n", u.X, u.Y)
+	mid := a.Lerp(b, 0.5)
+	fmt.Printf("a 与 b 的中点: %+v, 取整为 Point: %+v\n", mid, mid.Point())
+	if angle, err := a.AngleTo(b); err == nil {
+		fmt.Printf("a 与 b 的夹角: %.2f°\n", angle*180/math.Pi)
+	}
+	proj, _ := a.Project(Vec2[int]{X: 1})
+	fmt.Printf("a 在 x 轴上的投影: %+v\n", proj)
+
+	// 同样的代码也适用于 float64 和三维向量
+	x := Vec3[float64]{X: 1}
+	y := Vec3[float64]{Y: 1}
+	fmt.Printf("x × y = %+v\n", x.Cross(y))
+	p3 := Vec3From(pA, 5) // 类型参数由 z 的类型推断为 int
+	fmt.Printf("Vec3From(pA, 5) = %+v, 长度 %.3f\n", p3, p3.Norm())
+
+	if _, err := (Vec3[int]{}).Normalize(); err != nil {
+		fmt.Println("归一化零向量:", err, "| errors.Is(err, ErrZeroVector) =", errors.Is(err, ErrZeroVector))
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// approx 判断 got 与 want 的相对误差不超过 tol
+func approx(got, want, tol float64) bool {
+	if got == want {
+		return true
+	}
+	return math.Abs(got-want) <= tol*math.Max(math.Abs(want), 1e-300)
+}
+
+func approxVec(a, b Vec3[float64], tol float64) bool {
+	return math.Abs(a.X-b.X) <= tol && math.Abs(a.Y-b.Y) <= tol && math.Abs(a.Z-b.Z) <= tol
+}
+
+func TestVec3Norm(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    Vec3[float64]
+		want float64
+	}{
+		{"勾股数", Vec3[float64]{3, 4, 12}, 13},
+		{"负分量", Vec3[float64]{-2, 3, -6}, 7},
+		{"零向量", Vec3[float64]{}, 0},
+		// 直接平方会上溢为 +Inf 或下溢为 0
+		{"很大的分量", Vec3[float64]{3e200, 4e200, 0}, 5e200},
+		{"很小的分量", Vec3[float64]{3e-200, 0, 4e-200}, 5e-200},
+		{"最大的 float64", Vec3[float64]{math.MaxFloat64, 0, 0}, math.MaxFloat64},
+		{"次正规数", Vec3[float64]{0, 5e-324, 0}, 5e-324},
+		{"无穷大", Vec3[float64]{1, math.Inf(-1), 0}, math.Inf(1)},
+	}
+	for _, tc := range testCases {
+		if got := tc.v.Norm(); !approx(got, tc.want, 1e-15) {
+			t.Errorf("%s: Norm(%v) = %v; want %v", tc.name, tc.v, got, tc.want)
+		}
+	}
+	if got := (Vec3[float64]{math.NaN(), 1, 1}).Norm(); !math.IsNaN(got) {
+		t.Errorf("Norm(NaN 分量) = %v; want NaN", got)
+	}
+	if got := (Vec3[int]{2, 3, 6}).Norm(); got != 7 {
+		t.Errorf("整数向量 Norm = %v; want 7", got)
+	}
+}
+
+func TestVec3Normalize(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    Vec3[float64]
+		want Vec3[float64]
+	}{
+		{"普通向量", Vec3[float64]{0, 3, 4}, Vec3[float64]{0, 0.6, 0.8}},
+		{"很大的分量", Vec3[float64]{1e300, 1e300, 0}, Vec3[float64]{math.Sqrt2 / 2, math.Sqrt2 / 2, 0}},
+		{"次正规数", Vec3[float64]{5e-324, 0, 0}, Vec3[float64]{1, 0, 0}},
+	}
+	for _, tc := range testCases {
+		u, err := tc.v.Normalize()
+		if err != nil || !approxVec(u, tc.want, 1e-15) {
+			t.Errorf("%s: Normalize(%v) = %v, %v; want %v", tc.name, tc.v, u, err, tc.want)
+		}
+		if n := u.Norm(); !approx(n, 1, 1e-15) {
+			t.Errorf("%s: 单位向量的长度 = %v", tc.name, n)
+		}
+	}
+}
+
+func TestVec3AngleTo(t *testing.T) {
+	x := Vec3[float64]{1, 0, 0}
+	testCases := []struct {
+		name string
+		v, w Vec3[float64]
+		want float64
+	}{
+		{"垂直", x, Vec3[float64]{0, 0, 2}, math.Pi / 2},
+		{"相同方向", x, Vec3[float64]{5, 0, 0}, 0},
+		{"相反方向", x, Vec3[float64]{-3, 0, 0}, math.Pi},
+		// 夹角接近 0 或 π 时 acos(点积) 会损失全部精度，atan2 仍然准确
+		{"接近 0", x, Vec3[float64]{1, 1e-10, 0}, 1e-10},
+		{"接近 π", x, Vec3[float64]{-1, 0, 1e-10}, math.Pi - 1e-10},
+		{"很大的分量", Vec3[float64]{1e200, 0, 0}, Vec3[float64]{1e200, 1e200, 0}, math.Pi / 4},
+		{"很小的分量", Vec3[float64]{1e-200, 0, 0}, Vec3[float64]{0, 1e-200, 0}, math.Pi / 2},
+	}
+	for _, tc := range testCases {
+		got, err := tc.v.AngleTo(tc.w)
+		if err != nil || !approx(got, tc.want, 1e-12) {
+			t.Errorf("%s: AngleTo = %v, %v; want %v", tc.name, got, err, tc.want)
+		}
+		// 夹角与顺序无关
+		if back, _ := tc.w.AngleTo(tc.v); back != got {
+			t.Errorf("%s: 交换顺序后 AngleTo = %v; want %v", tc.name, back, got)
+		}
+	}
+}
+
+func TestVec3Project(t *testing.T) {
+	v := Vec3[float64]{3, 4, 5}
+	testCases := []struct {
+		name string
+		w    Vec3[float64]
+		want Vec3[float64]
+	}{
+		{"投影到 x 轴", Vec3[float64]{2, 0, 0}, Vec3[float64]{3, 0, 0}},
+		{"投影到反方向", Vec3[float64]{-1, 0, 0}, Vec3[float64]{3, 0, 0}},
+		{"投影到对角线", Vec3[float64]{1, 1, 0}, Vec3[float64]{3.5, 3.5, 0}},
+		{"垂直时为零向量", Vec3[float64]{0, 5, -4}, Vec3[float64]{}},
+		// w·w 会上溢，结果与 w 的长度无关
+		{"很长的 w", Vec3[float64]{0, 0, 1e300}, Vec3[float64]{0, 0, 5}},
+		{"很短的 w", Vec3[float64]{0, 1e-300, 0}, Vec3[float64]{0, 4, 0}},
+	}
+	for _, tc := range testCases {
+		got, err := v.Project(tc.w)
+		if err != nil || !approxVec(got, tc.want, 1e-12) {
+			t.Errorf("%s: Project = %v, %v; want %v", tc.name, got, err, tc.want)
+		}
+	}
+}
+
+func TestVec3ZeroVector(t *testing.T) {
+	var zero Vec3[float64]
+	x := Vec3[float64]{1, 0, 0}
+	if _, err := zero.Normalize(); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("Normalize(零向量) 的错误 = %v; want ErrZeroVector", err)
+	}
+	if _, err := zero.AngleTo(x); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("零向量.AngleTo 的错误 = %v; want ErrZeroVector", err)
+	}
+	if _, err := x.AngleTo(zero); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("AngleTo(零向量) 的错误 = %v; want ErrZeroVector", err)
+	}
+	if _, err := x.Project(zero); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("Project(零向量) 的错误 = %v; want ErrZeroVector", err)
+	}
+	// 零向量投影到其它向量上是合法的
+	if p, err := zero.Project(x); err != nil || p != zero {
+		t.Errorf("零向量.Project = %v, %v; want 零向量", p, err)
+	}
+	// 分量很小但不为零的向量不是零向量
+	if _, err := (Vec3[float64]{1e-200, 1e-200, 0}).Normalize(); err != nil {
+		t.Errorf("Normalize(很小的向量) 的错误 = %v; want nil", err)
+	}
+}
@@ -159,6 +159,8 @@ func main() {
 	pA.Move(10, 20) // 值类型调用指针接收者方法
 	fmt.Printf("pA 移动后: %+v\n", pA)
 
+	demoVectors()
+
 	fmt.Println("\n--- 方法学习结束 ---")
 }
 
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// --- 10. 地址校验与规范化 ---
+// Address 的三个字段都是 string，什么都能存。不同国家的邮政编码格式各不相同，
+// 信封上各行的顺序也不同 (中国从大到小写，欧美从小到大写)。
+// PostalAddress 通过嵌入 Address 复用它的字段，再加上国家代码，
+// 就可以按国家规则校验、规范化并生成邮寄标签，而原来使用 Address 的代码不受影响。
+
+// PostalAddress 是带国家代码的地址，Country 使用 ISO 3166-1 的两位字母代码，如 "CN"、"US"
+type PostalAddress struct {
+	Address
+	Country string
+}
+
+// ErrInvalidAddress 是所有地址校验错误的哨兵值，可以用 errors.Is 判断
+var ErrInvalidAddress = errors.New("地址无效")
+
+// FieldError 描述某一个字段的问题
+type FieldError struct {
+	Field   string // 字段名，如 "ZipCode"
+	Value   string // 用户输入的原始值
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s (输入为 %q)", e.Field, e.Message, e.Value)
+}
+
+// AddressError 汇总一个地址的所有字段错误，而不是遇到第一个错误就返回，
+// 这样表单可以一次性把所有问题都标注出来。
+type AddressError struct {
+	Fields []*FieldError
+}
+
+func (e *AddressError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return ErrInvalidAddress.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap 让 errors.Is(err, ErrInvalidAddress) 成立，
+// 也让 errors.As(err, &fieldErr) 能取出第一个字段错误
+func (e *AddressError) Unwrap() []error {
+	errs := []error{ErrInvalidAddress}
+	for _, f := range e.Fields {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// Field 返回指定字段的错误，没有时返回 nil
+func (e *AddressError) Field(name string) *FieldError {
+	for _, f := range e.Fields {
+		if f.Field == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// countryRule 是一个国家的邮编格式和标签格式
+type countryRule struct {
+	name    string         // 国家的中文名称，用于错误提示
+	postal  *regexp.Regexp // 规范化之后的邮编必须匹配的格式
+	example string         // 出错时提示的邮编示例
+	latin   bool           // 城市名使用拉丁字母，需要统一大小写
+	// normalizePostal 把用户输入的邮编整理成标准写法，例如补上空格或连字符
+	normalizePostal func(string) string
+	// label 按该国的习惯排列邮寄标签的各行 (不含收件人)
+	label func(a Address) []string
+}
+
+// removeSpaces 去掉所有空白字符并转为大写
+func removeSpaces(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+// splitPostal 在去掉空白后的邮编第 i 个字符前插入分隔符 sep (长度不对时原样返回，交给正则表达式报错)
+func splitPostal(s string, n, i int, sep string) string {
+	s = strings.ReplaceAll(removeSpaces(s), sep, "")
+	if len(s) != n {
+		return s
+	}
+	return s[:i] + sep + s[i:]
+}
+
+var countryRules = map[string]countryRule{
+	"CN": {
+		name:            "中国",
+		postal:          regexp.MustCompile(`^\d{6}$`),
+		example:         "100000",
+		normalizePostal: removeSpaces,
+		label: func(a Address) []string {
+			return []string{"中国 " + a.City, a.Street, "邮政编码 " + a.ZipCode}
+		},
+	},
+	"US": {
+		name:    "美国",
+		postal:  regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+		example: "94103 或 94103-1234",
+		latin:   true,
+		normalizePostal: func(s string) string {
+			if t := strings.ReplaceAll(removeSpaces(s), "-", ""); len(t) == 9 {
+				return t[:5] + "-" + t[5:] // ZIP+4
+			}
+			return removeSpaces(s)
+		},
+		label: func(a Address) []string {
+			return []string{strings.ToUpper(a.Street), strings.ToUpper(a.City) + " " + a.ZipCode, "UNITED STATES"}
+		},
+	},
+	"GB": {
+		name: "英国",
+		// 外码 (1-2 个字母 + 1 位数字 + 可选的字母或数字) + 空格 + 内码 (1 位数字 + 2 个字母)
+		postal:  regexp.MustCompile(`^(GIR 0AA|[A-Z]{1,2}\d[A-Z\d]? \d[A-Z]{2})$`),
+		example: "SW1A 1AA",
+		latin:   true,
+		normalizePostal: func(s string) string {
+			t := removeSpaces(s)
+			if len(t) < 5 || len(t) > 7 {
+				return t
+			}
+			return t[:len(t)-3] + " " + t[len(t)-3:] // 内码固定为最后 3 个字符
+		},
+		label: func(a Address) []string {
+			return []string{a.Street, strings.ToUpper(a.City), a.ZipCode, "UNITED KINGDOM"}
+		},
+	},
+	"JP": {
+		name:    "日本",
+		postal:  regexp.MustCompile(`^\d{3}-\d{4}$`),
+		example: "100-0001",
+		normalizePostal: func(s string) string {
+			return splitPostal(strings.TrimPrefix(strings.TrimSpace(s), "〒"), 7, 3, "-")
+		},
+		label: func(a Address) []string {
+			return []string{"〒" + a.ZipCode, a.City, a.Street, "JAPAN"}
+		},
+	},
+	"DE": {
+		name:            "德国",
+		postal:          regexp.MustCompile(`^\d{5}$`),
+		example:         "10115",
+		latin:           true,
+		normalizePostal: removeSpaces,
+		label: func(a Address) []string {
+			return []string{a.Street, a.ZipCode + " " + a.City, "GERMANY"}
+		},
+	},
+	"CA": {
+		name: "加拿大",
+		// 不使用 D、F、I、O、Q、U，首字母也不使用 W、Z
+		postal:          regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] \d[ABCEGHJ-NPRSTV-Z]\d$`),
+		example:         "K1A 0B1",
+		latin:           true,
+		normalizePostal: func(s string) string { return splitPostal(s, 6, 3, " ") },
+		label: func(a Address) []string {
+			return []string{strings.ToUpper(a.Street), strings.ToUpper(a.City) + " " + a.ZipCode, "CANADA"}
+		},
+	},
+}
+
+// countryAliases 把常见的别名映射到两位代码
+var countryAliases = map[string]string{
+	"CHN": "CN", "CHINA": "CN", "中国": "CN",
+	"USA": "US", "UNITED STATES": "US", "美国": "US",
+	"UK": "GB", "GBR": "GB", "UNITED KINGDOM": "GB", "英国": "GB",
+	"JPN": "JP", "JAPAN": "JP", "日本": "JP",
+	"DEU": "DE", "GERMANY": "DE", "德国": "DE",
+	"CAN": "CA", "CANADA": "CA", "加拿大": "CA",
+}
+
+// SupportedCountries 返回支持的国家代码，按字母排序
+func SupportedCountries() []string {
+	codes := make([]string, 0, len(countryRules))
+	for c := range countryRules {
+		codes = append(codes, c)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// collapseSpaces 去掉首尾空白，并把中间连续的空白合并为一个空格
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// titleCase 把全小写或全大写的拉丁字母城市名改为首字母大写，例如 "new york" -> "New York"；
+// 大小写混合的输入 (如 "McAllen") 被认为是用户有意为之，保持不变。
+func titleCase(s string) string {
+	if s != strings.ToLower(s) && s != strings.ToUpper(s) {
+		return s
+	}
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// Normalize 校验并规范化地址：合并多余的空白，统一国家代码和邮编的写法，
+// 拉丁字母国家的城市名统一为首字母大写。所有字段的问题会汇总在一个 *AddressError 中返回。
+func (a PostalAddress) Normalize() (PostalAddress, error) {
+	var errs []*FieldError
+	fail := func(field, value, format string, args ...any) {
+		errs = append(errs, &FieldError{Field: field, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+
+	out := PostalAddress{
+		Address: Address{
+			Street:  collapseSpaces(a.Street),
+			City:    collapseSpaces(a.City),
+			ZipCode: a.ZipCode,
+		},
+		Country: strings.ToUpper(collapseSpaces(a.Country)),
+	}
+	if code, ok := countryAliases[out.Country]; ok {
+		out.Country = code
+	}
+	rule, known := countryRules[out.Country]
+	switch {
+	case out.Country == "":
+		fail("Country", a.Country, "不能为空")
+	case !known:
+		fail("Country", a.Country, "不支持的国家, 目前支持 %s", strings.Join(SupportedCountries(), "、"))
+	}
+
+	if out.Street == "" {
+		fail("Street", a.Street, "不能为空")
+	} else if n := utf8.RuneCountInString(out.Street); n > 100 {
+		fail("Street", a.Street, "不能超过 100 个字符, 实际 %d 个", n)
+	}
+	if out.City == "" {
+		fail("City", a.City, "不能为空")
+	} else if known && rule.latin {
+		out.City = titleCase(out.City)
+	}
+
+	if strings.TrimSpace(a.ZipCode) == "" {
+		fail("ZipCode", a.ZipCode, "不能为空")
+	} else if known {
+		out.ZipCode = rule.normalizePostal(a.ZipCode)
+		if !rule.postal.MatchString(out.ZipCode) {
+			fail("ZipCode", a.ZipCode, "不是有效的%s邮编, 示例: %s", rule.name, rule.example)
+		}
+	}
+
+	if len(errs) > 0 {
+		return PostalAddress{}, &AddressError{Fields: errs}
+	}
+	return out, nil
+}
+
+// Validate 只校验地址，不关心规范化的结果
+func (a PostalAddress) Validate() error {
+	_, err := a.Normalize()
+	return err
+}
+
+// Label 生成按目的国习惯排列的多行邮寄标签，第一行是收件人
+func (a PostalAddress) Label(recipient string) (string, error) {
+	n, err := a.Normalize()
+	if err != nil {
+		return "", err
+	}
+	lines := append([]string{collapseSpaces(recipient)}, countryRules[n.Country].label(n.Address)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+func demoAddress() {
+	fmt.Println("\n--- 10. 地址校验与规范化 ---")
+	inputs := []struct {
+		name string
+		addr PostalAddress
+	}{
+		{"张三", PostalAddress{Address{Street: "  朝阳区  建国路 88 号 ", City: "北京市", ZipCode: "100 022"}, "china"}},
+		{"Rob Pike", PostalAddress{Address{Street: "1600 Amphitheatre   Pkwy", City: "mountain view", ZipCode: "940431351"}, "usa"}},
+		{"Ada Lovelace", PostalAddress{Address{Street: "10 Downing Street", City: "London", ZipCode: "sw1a2aa"}, "uk"}},
+		{"山田太郎", PostalAddress{Address{Street: "千代田1-1", City: "東京都千代田区", ZipCode: "〒1000001"}, "JP"}},
+	}
+	for _, in := range inputs {
+		label, err := in.addr.Label(in.name)
+		if err != nil {
+			fmt.Println("地址无效:", err)
+			continue
+		}
+		fmt.Println(label)
+		fmt.Println()
+	}
+
+	// 多个字段同时出错时一次性报告
+	bad := PostalAddress{Address{Street: "", City: "Toronto", ZipCode: "12345"}, "CA"}
+	err := bad.Validate()
+	fmt.Println("校验结果:", err)
+	var addrErr *AddressError
+	if errors.As(err, &addrErr) {
+		for _, f := range addrErr.Fields {
+			fmt.Printf("  字段 %s: %s\n", f.Field, f.Message)
+		}
+	}
+	fmt.Println("errors.Is(err, ErrInvalidAddress) =", errors.Is(err, ErrInvalidAddress))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePostalCode(t *testing.T) {
+	testCases := []struct {
+		country, zip string
+		want         string // 规范化之后的邮编，空表示应该校验失败
+	}{
+		// 美国: 5 位或 ZIP+4，9 位数字自动拆成 5-4
+		{"US", "94103", "94103"},
+		{"US", "941031234", "94103-1234"},
+		{"US", "94103 1234", "94103-1234"},
+		{"US", "94103-1234", "94103-1234"},
+		{"US", "9410", ""},
+		{"US", "9410312345", ""},
+		// 英国: 内码固定为最后 3 个字符，前面补一个空格
+		{"GB", "sw1a2aa", "SW1A 2AA"},
+		{"GB", "SW1A  2AA", "SW1A 2AA"},
+		{"GB", "m11ae", "M1 1AE"},
+		{"GB", "cr26xh", "CR2 6XH"},
+		{"GB", "SW1A2A", ""},
+		// GIR 0AA 是不符合一般规则的特殊邮编
+		{"GB", "gir0aa", "GIR 0AA"},
+		{"GB", "GIR 0AA", "GIR 0AA"},
+		{"GB", "GIR 1AA", ""},
+		// 加拿大: 不使用 D、F、I、O、Q、U，首字母也不使用 W、Z
+		{"CA", "k1a0b1", "K1A 0B1"},
+		{"CA", "H3Z 2Y7", "H3Z 2Y7"},
+		{"CA", "D1A 0B1", ""},
+		{"CA", "W1A 0B1", ""},
+		{"CA", "Z1A 0B1", ""},
+		{"CA", "K1O 0B1", ""},
+		{"CA", "K1A 0U1", ""},
+		// 日本: 去掉 〒 符号，7 位数字拆成 3-4
+		{"JP", "〒1000001", "100-0001"},
+		{"JP", " 〒100-0001", "100-0001"},
+		{"JP", "1000001", "100-0001"},
+		{"JP", "〒100001", ""},
+		{"CN", "100 022", "100022"},
+		{"DE", "1011", ""},
+	}
+	for _, tc := range testCases {
+		a := PostalAddress{Address{Street: "1 Main St", City: "Town", ZipCode: tc.zip}, tc.country}
+		n, err := a.Normalize()
+		if tc.want == "" {
+			var addrErr *AddressError
+			if !errors.As(err, &addrErr) || addrErr.Field("ZipCode") == nil {
+				t.Errorf("%s %q: 错误 = %v; want ZipCode 字段的错误", tc.country, tc.zip, err)
+			}
+			continue
+		}
+		if err != nil || n.ZipCode != tc.want {
+			t.Errorf("%s %q: 规范化为 %q, %v; want %q", tc.country, tc.zip, n.ZipCode, err, tc.want)
+		}
+	}
+}
+
+func TestNormalizeFields(t *testing.T) {
+	a := PostalAddress{Address{Street: "  1600  Amphitheatre Pkwy ", City: "mountain  view", ZipCode: "94043"}, " usa "}
+	n, err := a.Normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PostalAddress{Address{Street: "1600 Amphitheatre Pkwy", City: "Mountain View", ZipCode: "94043"}, "US"}
+	if n != want {
+		t.Errorf("Normalize = %+v; want %+v", n, want)
+	}
+	// 大小写混合的城市名保持不变，非拉丁字母国家不改变城市名
+	for _, tc := range []struct{ country, city, want string }{
+		{"US", "McAllen", "McAllen"},
+		{"GB", "LONDON", "London"},
+		{"JP", "東京都", "東京都"},
+	} {
+		a := PostalAddress{Address{Street: "x", City: tc.city, ZipCode: map[string]string{"US": "78501", "GB": "SW1A 1AA", "JP": "100-0001"}[tc.country]}, tc.country}
+		if n, err := a.Normalize(); err != nil || n.City != tc.want {
+			t.Errorf("%s 城市 %q 规范化为 %q, %v; want %q", tc.country, tc.city, n.City, err, tc.want)
+		}
+	}
+}
+
+func TestAddressError(t *testing.T) {
+	err := PostalAddress{Address{Street: "", City: "Toronto", ZipCode: "12345"}, "CA"}.Validate()
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("错误 = %v; want errors.Is(err, ErrInvalidAddress)", err)
+	}
+	var addrErr *AddressError
+	if !errors.As(err, &addrErr) {
+		t.Fatalf("errors.As(%v, *AddressError) 失败", err)
+	}
+	if len(addrErr.Fields) != 2 || addrErr.Field("Street") == nil || addrErr.Field("ZipCode") == nil || addrErr.Field("City") != nil {
+		t.Errorf("字段错误 = %v; want Street 和 ZipCode", err)
+	}
+	// Unwrap 返回的列表中包含每个字段错误，errors.As 取出第一个
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr != addrErr.Fields[0] {
+		t.Errorf("errors.As(*FieldError) = %v; want 第一个字段错误 %v", fieldErr, addrErr.Fields[0])
+	}
+	if fieldErr.Value != "" || addrErr.Field("ZipCode").Value != "12345" {
+		t.Errorf("字段错误应该保留原始输入: %+v", addrErr.Fields)
+	}
+
+	err = PostalAddress{Address{Street: "x", City: "y", ZipCode: "z"}, "FR"}.Validate()
+	if !errors.As(err, &addrErr) || addrErr.Field("Country") == nil || addrErr.Field("ZipCode") != nil {
+		t.Errorf("不支持的国家: 错误 = %v; want 只报告 Country (不知道规则时不校验邮编)", err)
+	}
+	if err := (PostalAddress{Address{Street: "x", City: "y", ZipCode: "100022"}, "中国"}).Validate(); err != nil {
+		t.Errorf("中文国家别名: 错误 = %v; want nil", err)
+	}
+}
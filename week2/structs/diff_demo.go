@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Mag1cFall/go-get-started/week2/structs/structdiff"
+)
+
+// --- 11. 结构体的差异与合并 ---
+// == 只能回答 "是否相同"，而且结构体含有切片或 map 时根本不能用 ==。
+// structdiff 包用反射逐个字段比较，告诉我们 "哪里不同"，并能把这些差异应用到另一个结构体上，
+// 适合记录修改历史，或者在保存前检查别人是否已经改过同一个字段。
+
+// EmployeeProfile 在 Employee 的基础上加了切片、map 和指针字段，用来演示各种路径
+type EmployeeProfile struct {
+	Employee
+	Skills []string
+	Meta   map[string]string
+	Mentor *Person
+}
+
+// clone 复制切片和 map，避免两个 profile 共享底层数据
+func (p EmployeeProfile) clone() EmployeeProfile {
+	p.Skills = slices.Clone(p.Skills)
+	p.Meta = maps.Clone(p.Meta)
+	if p.Mentor != nil {
+		m := *p.Mentor
+		p.Mentor = &m
+	}
+	return p
+}
+
+func demoDiff() {
+	fmt.Println("\n--- 11. 结构体的差异与合并 ---")
+	base := EmployeeProfile{
+		Employee: Employee{
+			Person:      Person{FirstName: "Gary", LastName: "Oldman", Age: 45, Email: "gary@example.com", IsActive: true},
+			Department:  "Engineering",
+			Salary:      90000,
+			ContactInfo: Address{Street: "123 Tech Road", City: "GoCity", ZipCode: "12345"},
+		},
+		Skills: []string{"Go", "SQL", "Docker"},
+		Meta:   map[string]string{"level": "P6", "team": "infra"},
+	}
+
+	updated := base.clone()
+	updated.Age = 46
+	updated.ContactInfo.City = "GoLand"
+	updated.Skills = []string{"Go", "Rust"}
+	updated.Meta["level"] = "P7"
+	delete(updated.Meta, "team")
+	updated.Meta["oncall"] = "yes"
+	updated.Mentor = &Person{FirstName: "Rob", LastName: "Pike"}
+
+	changes, err := structdiff.Diff(base, updated)
+	if err != nil {
+		fmt.Println("比较失败:", err)
+		return
+	}
+	fmt.Printf("base -> updated 共 %d 处变更:\n%v\n", len(changes), changes)
+
+	// 把变更应用到 base 的副本上，结果应当与 updated 完全相同
+	target := base.clone()
+	if err := structdiff.Apply(&target, changes); err != nil {
+		fmt.Println("应用失败:", err)
+		return
+	}
+	rest, _ := structdiff.Diff(target, updated)
+	fmt.Println("应用之后与 updated 的差异数:", len(rest))
+
+	// 三方合并：other 也是从 base 改出来的，只要改的不是同一个字段就能合并
+	other := base.clone()
+	other.Salary = 95000
+	if err := structdiff.Merge(&other, base, updated); err != nil {
+		fmt.Println("合并失败:", err)
+	} else {
+		fmt.Printf("合并成功: 年龄 %d, 城市 %s, 薪水 %.0f\n", other.Age, other.ContactInfo.City, other.Salary)
+	}
+
+	// 同一个字段被两边改成不同的值时报告冲突，并且不做任何修改
+	other = base.clone()
+	other.ContactInfo.City = "Gopherville"
+	err = structdiff.Merge(&other, base, updated)
+	fmt.Println("合并失败:", err)
+	fmt.Println("errors.Is(err, ErrConflict) =", errors.Is(err, structdiff.ErrConflict), "| 年龄仍为", other.Age)
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// --- 7. 员工通讯录与组织架构 ---
+// 结构体只描述 "一个员工长什么样"，管理一群员工还需要另一个结构体来保存它们之间的关系。
+// Directory 用 map 按编号保存员工，再用两个 map 记录汇报关系：
+// manager 记录 "谁是我的上级"，reports 记录 "谁直接向我汇报"，两个方向都可以 O(1) 查到。
+
+// EmployeeID 是员工在通讯录中的编号，从 1 开始分配
+type EmployeeID int
+
+var (
+	// ErrEmployeeNotFound 表示编号不存在
+	ErrEmployeeNotFound = errors.New("员工不存在")
+	// ErrReportingCycle 表示设置的汇报关系会形成环，例如 A 汇报给 B，B 又汇报给 A
+	ErrReportingCycle = errors.New("汇报关系形成环")
+)
+
+// FullName 返回 "名 姓" 形式的全名
+func (p Person) FullName() string {
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
+
+// Directory 是员工通讯录。汇报关系始终是一片森林 (每棵树的根是没有上级的员工)，
+// SetManager 会拒绝任何会形成环的修改。
+type Directory struct {
+	nextID    EmployeeID
+	employees map[EmployeeID]Employee
+	manager   map[EmployeeID]EmployeeID
+	reports   map[EmployeeID][]EmployeeID // 按编号升序排列，保证输出顺序稳定
+}
+
+// NewDirectory 创建空的通讯录
+func NewDirectory() *Directory {
+	return &Directory{
+		nextID:    1,
+		employees: map[EmployeeID]Employee{},
+		manager:   map[EmployeeID]EmployeeID{},
+		reports:   map[EmployeeID][]EmployeeID{},
+	}
+}
+
+// Add 添加员工并返回分配的编号，新员工没有上级
+func (d *Directory) Add(e Employee) EmployeeID {
+	id := d.nextID
+	d.nextID++
+	d.employees[id] = e
+	return id
+}
+
+// Get 返回员工信息的副本
+func (d *Directory) Get(id EmployeeID) (Employee, bool) {
+	e, ok := d.employees[id]
+	return e, ok
+}
+
+// Update 替换员工信息，汇报关系不变
+func (d *Directory) Update(id EmployeeID, e Employee) error {
+	if _, ok := d.employees[id]; !ok {
+		return fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	d.employees[id] = e
+	return nil
+}
+
+// Len 返回员工人数
+func (d *Directory) Len() int { return len(d.employees) }
+
+// Remove 删除员工。被删除员工的直接下属改为向其原来的上级汇报 (原来没有上级时，这些下属也不再有上级)。
+func (d *Directory) Remove(id EmployeeID) error {
+	if _, ok := d.employees[id]; !ok {
+		return fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	boss, hasBoss := d.manager[id]
+	d.unlink(id)
+	for _, r := range d.reports[id] {
+		delete(d.manager, r)
+		if hasBoss {
+			d.link(r, boss)
+		}
+	}
+	delete(d.reports, id)
+	delete(d.employees, id)
+	return nil
+}
+
+// SetManager 让 id 向 managerID 汇报。如果 managerID 本身直接或间接向 id 汇报，
+// 修改会形成环，返回的错误中会列出环上的所有人。
+func (d *Directory) SetManager(id, managerID EmployeeID) error {
+	for _, x := range []EmployeeID{id, managerID} {
+		if _, ok := d.employees[x]; !ok {
+			return fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, x)
+		}
+	}
+	// 从新上级一路向上走，如果走到了 id 自己，说明会形成环
+	path := []EmployeeID{id}
+	for m, ok := managerID, true; ok; m, ok = d.manager[m] {
+		path = append(path, m)
+		if m == id {
+			names := make([]string, len(path))
+			for i, p := range path {
+				names[i] = d.employees[p].FullName()
+			}
+			return fmt.Errorf("%w: %s", ErrReportingCycle, strings.Join(names, " -> "))
+		}
+	}
+	d.unlink(id)
+	d.link(id, managerID)
+	return nil
+}
+
+// ClearManager 取消 id 的上级，使其成为组织架构中的一个根
+func (d *Directory) ClearManager(id EmployeeID) error {
+	if _, ok := d.employees[id]; !ok {
+		return fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	d.unlink(id)
+	return nil
+}
+
+func (d *Directory) link(id, managerID EmployeeID) {
+	d.manager[id] = managerID
+	rs := d.reports[managerID]
+	i, _ := slices.BinarySearch(rs, id)
+	d.reports[managerID] = slices.Insert(rs, i, id)
+}
+
+func (d *Directory) unlink(id EmployeeID) {
+	m, ok := d.manager[id]
+	if !ok {
+		return
+	}
+	delete(d.manager, id)
+	d.reports[m] = slices.DeleteFunc(d.reports[m], func(r EmployeeID) bool { return r == id })
+}
+
+// Manager 返回直接上级，没有上级时 ok 为 false
+func (d *Directory) Manager(id EmployeeID) (EmployeeID, bool) {
+	m, ok := d.manager[id]
+	return m, ok
+}
+
+// DirectReports 返回直接下属的编号
+func (d *Directory) DirectReports(id EmployeeID) []EmployeeID {
+	return slices.Clone(d.reports[id])
+}
+
+// ChainOfCommand 返回从直接上级开始、一直到最高层的汇报链
+func (d *Directory) ChainOfCommand(id EmployeeID) ([]EmployeeID, error) {
+	if _, ok := d.employees[id]; !ok {
+		return nil, fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	var chain []EmployeeID
+	for m, ok := d.manager[id]; ok; m, ok = d.manager[m] {
+		chain = append(chain, m)
+	}
+	return chain, nil
+}
+
+// SpanOfControl 返回管理幅度：direct 是直接下属人数，total 是所有直接和间接下属的人数
+func (d *Directory) SpanOfControl(id EmployeeID) (direct, total int, err error) {
+	if _, ok := d.employees[id]; !ok {
+		return 0, 0, fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	d.walk(id, 0, func(_ EmployeeID, depth int) bool {
+		if depth > 0 {
+			total++
+		}
+		return true
+	})
+	return len(d.reports[id]), total, nil
+}
+
+// Roots 返回没有上级的员工，按编号排序
+func (d *Directory) Roots() []EmployeeID {
+	var roots []EmployeeID
+	for id := range d.employees {
+		if _, ok := d.manager[id]; !ok {
+			roots = append(roots, id)
+		}
+	}
+	slices.Sort(roots)
+	return roots
+}
+
+// Walk 按深度优先的先序遍历整个组织架构：先访问上级，再依次访问每个下属。
+// depth 是在树中的层级 (根为 0)，fn 返回 false 时停止遍历。
+func (d *Directory) Walk(fn func(id EmployeeID, depth int) bool) {
+	for _, root := range d.Roots() {
+		if !d.walk(root, 0, fn) {
+			return
+		}
+	}
+}
+
+func (d *Directory) walk(id EmployeeID, depth int, fn func(EmployeeID, int) bool) bool {
+	if !fn(id, depth) {
+		return false
+	}
+	for _, r := range d.reports[id] {
+		if !d.walk(r, depth+1, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteOrgChart 以树的形式输出组织架构
+func (d *Directory) WriteOrgChart(w io.Writer) error {
+	var err error
+	var draw func(id EmployeeID, prefix string, last, root bool)
+	draw = func(id EmployeeID, prefix string, last, root bool) {
+		e := d.employees[id]
+		branch, childPrefix := "", ""
+		if !root {
+			branch, childPrefix = "├── ", prefix+"│   "
+			if last {
+				branch, childPrefix = "└── ", prefix+"    "
+			}
+		}
+		if _, werr := fmt.Fprintf(w, "%s%s%s (%s)\n", prefix, branch, e.FullName(), e.Department); werr != nil && err == nil {
+			err = werr
+		}
+		rs := d.reports[id]
+		for i, r := range rs {
+			draw(r, childPrefix, i == len(rs)-1, false)
+		}
+	}
+	for _, root := range d.Roots() {
+		draw(root, "", true, true)
+	}
+	return err
+}
+
+// DepartmentStats 是一个部门的汇总数据
+type DepartmentStats struct {
+	Department    string
+	Headcount     int
+	TotalSalary   float64
+	AverageSalary float64
+}
+
+// Departments 按部门汇总整个通讯录
+func (d *Directory) Departments() []DepartmentStats {
+	ids := make([]EmployeeID, 0, len(d.employees))
+	for id := range d.employees {
+		ids = append(ids, id)
+	}
+	return d.rollup(ids)
+}
+
+// DepartmentRollup 按部门汇总 id 及其所有直接和间接下属，
+// 可以看出一个管理者手下的人分布在哪些部门、各花了多少薪资。
+func (d *Directory) DepartmentRollup(id EmployeeID) ([]DepartmentStats, error) {
+	if _, ok := d.employees[id]; !ok {
+		return nil, fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	var ids []EmployeeID
+	d.walk(id, 0, func(x EmployeeID, _ int) bool {
+		ids = append(ids, x)
+		return true
+	})
+	return d.rollup(ids), nil
+}
+
+// rollup 按部门名称汇总，结果按部门名称排序
+func (d *Directory) rollup(ids []EmployeeID) []DepartmentStats {
+	byDept := map[string]*DepartmentStats{}
+	for _, id := range ids {
+		e := d.employees[id]
+		s, ok := byDept[e.Department]
+		if !ok {
+			s = &DepartmentStats{Department: e.Department}
+			byDept[e.Department] = s
+		}
+		s.Headcount++
+		s.TotalSalary += e.Salary
+	}
+	out := make([]DepartmentStats, 0, len(byDept))
+	for _, s := range byDept {
+		s.AverageSalary = s.TotalSalary / float64(s.Headcount)
+		out = append(out, *s)
+	}
+	slices.SortFunc(out, func(a, b DepartmentStats) int { return cmp.Compare(a.Department, b.Department) })
+	return out
+}
+
+// Query 是搜索条件，空字段表示不限制，多个字段同时设置时必须全部满足。
+// 所有比较都不区分大小写；Name 匹配全名中的任意一段，Department 和 City 要求完全相同。
+type Query struct {
+	Name       string
+	Department string
+	City       string
+}
+
+// Search 返回满足条件的员工编号，按编号排序
+func (d *Directory) Search(q Query) []EmployeeID {
+	name := strings.ToLower(strings.TrimSpace(q.Name))
+	var out []EmployeeID
+	for id, e := range d.employees {
+		if name != "" && !strings.Contains(strings.ToLower(e.FullName()), name) {
+			continue
+		}
+		if q.Department != "" && !strings.EqualFold(e.Department, strings.TrimSpace(q.Department)) {
+			continue
+		}
+		if q.City != "" && !strings.EqualFold(e.ContactInfo.City, strings.TrimSpace(q.City)) {
+			continue
+		}
+		out = append(out, id)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func demoDirectory() {
+	fmt.Println("\n--- 7. 员工通讯录与组织架构 ---")
+	d := NewDirectory()
+	add := func(first, last, dept, city string, salary float64) EmployeeID {
+		return d.Add(Employee{
+			Person:      Person{FirstName: first, LastName: last, IsActive: true},
+			Department:  dept,
+			Salary:      salary,
+			ContactInfo: Address{City: city},
+		})
+	}
+	ceo := add("Grace", "Hopper", "Executive", "Beijing", 300000)
+	cto := add("Ken", "Thompson", "Engineering", "Beijing", 220000)
+	cfo := add("Ada", "Lovelace", "Finance", "Shanghai", 200000)
+	dev1 := add("Rob", "Pike", "Engineering", "Shanghai", 150000)
+	dev2 := add("Robert", "Griesemer", "Engineering", "Beijing", 150000)
+	acct := add("Linus", "Pauling", "Finance", "Shanghai", 90000)
+	ops := add("Margaret", "Hamilton", "Operations", "Shenzhen", 120000)
+
+	for _, rel := range [][2]EmployeeID{{cto, ceo}, {cfo, ceo}, {dev1, cto}, {dev2, cto}, {acct, cfo}, {ops, cto}} {
+		if err := d.SetManager(rel[0], rel[1]); err != nil {
+			fmt.Println("设置上级失败:", err)
+		}
+	}
+	if err := d.WriteOrgChart(os.Stdout); err != nil {
+		fmt.Println("输出失败:", err)
+	}
+
+	name := func(id EmployeeID) string {
+		e, _ := d.Get(id)
+		return e.FullName()
+	}
+	chain, _ := d.ChainOfCommand(dev1)
+	names := make([]string, len(chain))
+	for i, id := range chain {
+		names[i] = name(id)
+	}
+	fmt.Printf("%s 的汇报链: %s\n", name(dev1), strings.Join(names, " -> "))
+
+	direct, total, _ := d.SpanOfControl(cto)
+	fmt.Printf("%s 的管理幅度: 直接下属 %d 人, 全部下属 %d 人\n", name(cto), direct, total)
+
+	rollup, _ := d.DepartmentRollup(cto)
+	for _, s := range rollup {
+		fmt.Printf("  %s 团队中的 %-12s %d 人, 薪资合计 %.0f\n", name(cto), s.Department, s.Headcount, s.TotalSalary)
+	}
+
+	for _, id := range d.Search(Query{Department: "engineering", City: "beijing"}) {
+		fmt.Println("北京的工程师:", name(id))
+	}
+
+	// CEO 向 Rob 汇报会形成环: Grace -> Rob -> Ken -> Grace
+	err := d.SetManager(ceo, dev1)
+	fmt.Println("设置上级:", err, "| errors.Is(err, ErrReportingCycle) =", errors.Is(err, ErrReportingCycle))
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestDirectory 建立如下的组织架构，返回通讯录和按名字索引的编号：
+//
+//	ceo
+//	├── cto
+//	│   ├── dev1
+//	│   └── dev2
+//	└── cfo
+//	    └── acct
+func newTestDirectory(t *testing.T) (*Directory, map[string]EmployeeID) {
+	t.Helper()
+	d := NewDirectory()
+	people := []struct {
+		name, dept string
+		salary     float64
+	}{
+		{"ceo", "Executive", 300},
+		{"cto", "Engineering", 200},
+		{"cfo", "Finance", 180},
+		{"dev1", "Engineering", 100},
+		{"dev2", "Engineering", 120},
+		{"acct", "Finance", 80},
+	}
+	ids := map[string]EmployeeID{}
+	for _, p := range people {
+		ids[p.name] = d.Add(Employee{Person: Person{FirstName: p.name}, Department: p.dept, Salary: p.salary})
+	}
+	for _, rel := range [][2]string{{"cto", "ceo"}, {"cfo", "ceo"}, {"dev1", "cto"}, {"dev2", "cto"}, {"acct", "cfo"}} {
+		if err := d.SetManager(ids[rel[0]], ids[rel[1]]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d, ids
+}
+
+func TestSetManagerCycle(t *testing.T) {
+	testCases := []struct {
+		name, id, manager string
+		wantPath          string // 错误信息中的环
+	}{
+		{"自己管理自己", "cto", "cto", "cto -> cto"},
+		{"直接下属", "cto", "dev1", "cto -> dev1 -> cto"},
+		{"间接下属", "ceo", "dev2", "ceo -> dev2 -> cto -> ceo"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ids := newTestDirectory(t)
+			before, _ := d.Manager(ids[tc.id])
+			err := d.SetManager(ids[tc.id], ids[tc.manager])
+			if !errors.Is(err, ErrReportingCycle) {
+				t.Fatalf("SetManager(%s, %s) 的错误 = %v; want ErrReportingCycle", tc.id, tc.manager, err)
+			}
+			if !strings.HasSuffix(err.Error(), tc.wantPath) {
+				t.Errorf("错误信息 = %q; want 以 %q 结尾", err, tc.wantPath)
+			}
+			// 被拒绝的修改不能改变原来的汇报关系
+			if after, _ := d.Manager(ids[tc.id]); after != before {
+				t.Errorf("失败之后上级从 %d 变成了 %d", before, after)
+			}
+		})
+	}
+
+	d, ids := newTestDirectory(t)
+	// 换到另一棵子树不会形成环，并且要从原上级的下属列表中移除
+	if err := d.SetManager(ids["dev1"], ids["cfo"]); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.DirectReports(ids["cto"]); !slices.Equal(got, []EmployeeID{ids["dev2"]}) {
+		t.Errorf("cto 的直接下属 = %v; want [dev2]", got)
+	}
+	if got := d.DirectReports(ids["cfo"]); !slices.Equal(got, []EmployeeID{ids["dev1"], ids["acct"]}) {
+		t.Errorf("cfo 的直接下属 = %v; want [dev1 acct] (按编号排序)", got)
+	}
+	if err := d.SetManager(ids["dev1"], 99); !errors.Is(err, ErrEmployeeNotFound) {
+		t.Errorf("上级不存在时的错误 = %v; want ErrEmployeeNotFound", err)
+	}
+}
+
+func TestRemoveReparents(t *testing.T) {
+	testCases := []struct {
+		name      string
+		remove    string
+		reparent  []string // 被删除员工的下属
+		wantBoss  string   // 下属的新上级，空表示成为根
+		wantRoots []string
+	}{
+		{"删除中层", "cto", []string{"dev1", "dev2"}, "ceo", []string{"ceo"}},
+		{"删除根", "ceo", []string{"cto", "cfo"}, "", []string{"cto", "cfo"}},
+		{"删除叶子", "acct", nil, "", []string{"ceo"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ids := newTestDirectory(t)
+			oldBoss, hadBoss := d.Manager(ids[tc.remove])
+			if err := d.Remove(ids[tc.remove]); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := d.Get(ids[tc.remove]); ok || d.Len() != 5 {
+				t.Fatalf("删除之后仍能找到 %s, 或人数 %d 不是 5", tc.remove, d.Len())
+			}
+			for _, r := range tc.reparent {
+				boss, ok := d.Manager(ids[r])
+				if tc.wantBoss == "" && ok {
+					t.Errorf("%s 的上级 = %d; want 没有上级", r, boss)
+				}
+				if tc.wantBoss != "" && boss != ids[tc.wantBoss] {
+					t.Errorf("%s 的上级 = %d; want %s (%d)", r, boss, tc.wantBoss, ids[tc.wantBoss])
+				}
+			}
+			if hadBoss && slices.Contains(d.DirectReports(oldBoss), ids[tc.remove]) {
+				t.Errorf("原上级的下属列表中仍有 %s", tc.remove)
+			}
+			var roots []EmployeeID
+			for _, r := range tc.wantRoots {
+				roots = append(roots, ids[r])
+			}
+			if got := d.Roots(); !slices.Equal(got, roots) {
+				t.Errorf("Roots = %v; want %v", got, roots)
+			}
+		})
+	}
+
+	d, _ := newTestDirectory(t)
+	if err := d.Remove(99); !errors.Is(err, ErrEmployeeNotFound) {
+		t.Errorf("删除不存在的员工的错误 = %v; want ErrEmployeeNotFound", err)
+	}
+}
+
+func TestSpanOfControl(t *testing.T) {
+	d, ids := newTestDirectory(t)
+	testCases := []struct {
+		name          string
+		direct, total int
+	}{
+		{"ceo", 2, 5},
+		{"cto", 2, 2},
+		{"cfo", 1, 1},
+		{"dev1", 0, 0},
+	}
+	for _, tc := range testCases {
+		direct, total, err := d.SpanOfControl(ids[tc.name])
+		if err != nil || direct != tc.direct || total != tc.total {
+			t.Errorf("SpanOfControl(%s) = %d, %d, %v; want %d, %d", tc.name, direct, total, err, tc.direct, tc.total)
+		}
+	}
+	if _, _, err := d.SpanOfControl(99); !errors.Is(err, ErrEmployeeNotFound) {
+		t.Errorf("SpanOfControl(99) 的错误 = %v; want ErrEmployeeNotFound", err)
+	}
+}
+
+func TestDepartmentRollup(t *testing.T) {
+	d, ids := newTestDirectory(t)
+	testCases := []struct {
+		name string
+		want []DepartmentStats
+	}{
+		{"cto", []DepartmentStats{
+			{Department: "Engineering", Headcount: 3, TotalSalary: 420, AverageSalary: 140},
+		}},
+		{"ceo", []DepartmentStats{
+			{Department: "Engineering", Headcount: 3, TotalSalary: 420, AverageSalary: 140},
+			{Department: "Executive", Headcount: 1, TotalSalary: 300, AverageSalary: 300},
+			{Department: "Finance", Headcount: 2, TotalSalary: 260, AverageSalary: 130},
+		}},
+		{"acct", []DepartmentStats{
+			{Department: "Finance", Headcount: 1, TotalSalary: 80, AverageSalary: 80},
+		}},
+	}
+	for _, tc := range testCases {
+		got, err := d.DepartmentRollup(ids[tc.name])
+		if err != nil || !slices.Equal(got, tc.want) {
+			t.Errorf("DepartmentRollup(%s) = %+v, %v; want %+v", tc.name, got, err, tc.want)
+		}
+	}
+	// 整个通讯录的汇总与从唯一的根开始汇总相同
+	if got, want := d.Departments(), testCases[1].want; !slices.Equal(got, want) {
+		t.Errorf("Departments = %+v; want %+v", got, want)
+	}
+	if _, err := d.DepartmentRollup(99); !errors.Is(err, ErrEmployeeNotFound) {
+		t.Errorf("DepartmentRollup(99) 的错误 = %v; want ErrEmployeeNotFound", err)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// --- 8. 定点数金额 ---
+// float64 是二进制浮点数，0.1 这样的十进制小数无法精确表示，
+// 0.1 + 0.2 == 0.3 的结果是 false。在工资、账务这类必须 "分毫不差" 的场景，
+// 常见做法是用整数存放最小货币单位 (分)，所有加减运算都是精确的整数运算，
+// 只有乘以比例 (税率、扣款比例) 时才需要舍入，而且舍入规则是明确可控的。
+
+// Money 是以分为单位的金额
+type Money int64
+
+// 与 time.Duration 的 time.Second 类似，可以写 5000 * Yuan 这样的常量表达式
+const (
+	Cent Money = 1
+	Yuan Money = 100
+)
+
+// Rate 是以基点 (万分之一) 为单位的比例，例如 3% = 300 个基点
+type Rate int64
+
+const (
+	BasisPoint Rate = 1
+	Percent    Rate = 100
+)
+
+// ErrInvalidMoney 表示无法解析的金额字符串
+var ErrInvalidMoney = errors.New("无效的金额")
+
+// MoneyFromFloat 把以元为单位的浮点数四舍五入到分，用于和现有的 float64 字段 (如 Employee.Salary) 交互
+func MoneyFromFloat(yuan float64) Money {
+	return Money(math.Round(yuan * 100))
+}
+
+// ParseMoney 解析 "1234"、"1,234.5"、"-0.05" 这样的金额，最多两位小数
+func ParseMoney(s string) (Money, error) {
+	t := strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	neg := strings.HasPrefix(t, "-")
+	t = strings.TrimPrefix(t, "-")
+	whole, frac, hasDot := strings.Cut(t, ".")
+	if whole == "" || (hasDot && (frac == "" || len(frac) > 2)) {
+		return 0, fmt.Errorf("%w %q: 格式应为 123.45，最多两位小数", ErrInvalidMoney, s)
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil || strings.ContainsAny(whole+frac, "+-") {
+		return 0, fmt.Errorf("%w %q", ErrInvalidMoney, s)
+	}
+	if neg {
+		n = -n
+	}
+	return Money(n), nil
+}
+
+// Float 返回以元为单位的浮点数，只应该用于展示或统计，不应该再参与金额计算
+func (m Money) Float() float64 { return float64(m) / 100 }
+
+// String 输出带千位分隔符的金额，例如 -12,345.60
+func (m Money) String() string {
+	sign := ""
+	u := uint64(m)
+	if m < 0 {
+		sign, u = "-", uint64(-m)
+	}
+	whole := strconv.FormatUint(u/100, 10)
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(c)
+	}
+	fmt.Fprintf(&sb, ".%02d", u%100)
+	return sb.String()
+}
+
+// MulRate 返回 m 乘以比例 r 的结果，四舍五入到分 (0.5 分向远离 0 的方向舍入)
+func (m Money) MulRate(r Rate) Money {
+	return roundDiv(int64(m)*int64(r), 10000)
+}
+
+// roundDiv 计算 a / b 并四舍五入，b 必须为正
+func roundDiv(a, b int64) Money {
+	if a < 0 {
+		return -Money((-a + b/2) / b)
+	}
+	return Money((a + b/2) / b)
+}
+
+// Allocate 把金额尽量平均地分成 n 份，多出来的几分钱依次分给前面几份，
+// 保证各份之和严格等于 m (直接用 m / n 再乘回去会丢失余数)。
+func (m Money) Allocate(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]Money, n)
+	q, r := m/Money(n), m%Money(n)
+	step := Cent
+	if r < 0 {
+		step, r = -Cent, -r
+	}
+	for i := range parts {
+		parts[i] = q
+		if Money(i) < r {
+			parts[i] += step
+		}
+	}
+	return parts
+}
+
+// String 输出百分比形式，例如 2.5%
+func (r Rate) String() string {
+	return strconv.FormatFloat(float64(r)/100, 'f', -1, 64) + "%"
+}
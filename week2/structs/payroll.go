@@ -0,0 +1,397 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+)
+
+// --- 9. 工资计算 ---
+// Payroll 根据通讯录中每个员工的年薪 (Employee.Salary)，按发薪周期计算从应发工资到实发工资的全过程:
+//
+//	应发 = 基本工资 + 奖金
+//	应纳税所得额 = 应发 - 税前扣除 (社保、公积金等) - 基本减除费用
+//	实发 = 应发 - 税前扣除 - 个人所得税 - 税后扣除
+//
+// 个人所得税使用 "累计预扣法"：每期先算出年初至今的累计应纳税所得额，
+// 按年度累进税率表算出累计应纳税额，再减去之前各期已经预扣的税款。
+// 这样年中发放大额奖金时，只有奖金本身适用更高的税率，全年预扣的总额与按年计算的结果一致。
+
+// PayPeriod 是发薪周期，值为一年中的发薪次数
+type PayPeriod int
+
+const (
+	Monthly     PayPeriod = 12
+	SemiMonthly PayPeriod = 24
+	BiWeekly    PayPeriod = 26
+	Weekly      PayPeriod = 52
+)
+
+var (
+	// ErrInvalidTaxTable 表示税率表配置有误
+	ErrInvalidTaxTable = errors.New("无效的税率表")
+	// ErrInvalidPeriod 表示期数超出了一年的范围
+	ErrInvalidPeriod = errors.New("无效的发薪期")
+)
+
+// TaxBracket 是累进税率表中的一档：不超过 UpTo 的部分适用 Rate，最后一档的 UpTo 为 0，表示没有上限
+type TaxBracket struct {
+	UpTo Money
+	Rate Rate
+}
+
+// TaxTable 是按 UpTo 从小到大排列的年度累进税率表
+type TaxTable []TaxBracket
+
+// NewTaxTable 检查并创建税率表：上限必须严格递增，只有最后一档可以没有上限，税率在 0 到 100% 之间
+func NewTaxTable(brackets ...TaxBracket) (TaxTable, error) {
+	if len(brackets) == 0 {
+		return nil, fmt.Errorf("%w: 至少需要一档", ErrInvalidTaxTable)
+	}
+	for i, b := range brackets {
+		if b.Rate < 0 || b.Rate > 100*Percent {
+			return nil, fmt.Errorf("%w: 第 %d 档税率 %v 不在 0 到 100%% 之间", ErrInvalidTaxTable, i+1, b.Rate)
+		}
+		last := i == len(brackets)-1
+		switch {
+		case last && b.UpTo != 0:
+			return nil, fmt.Errorf("%w: 最后一档的上限必须为 0 (没有上限)", ErrInvalidTaxTable)
+		case !last && b.UpTo <= 0:
+			return nil, fmt.Errorf("%w: 第 %d 档的上限必须为正数", ErrInvalidTaxTable, i+1)
+		case !last && i > 0 && b.UpTo <= brackets[i-1].UpTo:
+			return nil, fmt.Errorf("%w: 第 %d 档的上限 %v 没有大于上一档的 %v", ErrInvalidTaxTable, i+1, b.UpTo, brackets[i-1].UpTo)
+		}
+	}
+	return slices.Clone(TaxTable(brackets)), nil
+}
+
+// Tax 计算应纳税所得额 income 的税额。每一档分别计算后再一次性舍入到分，避免每档各舍入一次累积误差。
+func (t TaxTable) Tax(income Money) Money {
+	var sum, lower int64 // sum 的单位是 分×基点
+	for _, b := range t {
+		if income <= Money(lower) {
+			break
+		}
+		upper := int64(income)
+		if b.UpTo != 0 {
+			upper = min(upper, int64(b.UpTo))
+		}
+		sum += (upper - lower) * int64(b.Rate)
+		lower = upper
+	}
+	return roundDiv(sum, 10000)
+}
+
+// Deduction 是一项扣款，金额为 Amount 加上应发工资乘以 Rate (两者通常只设置一个)。
+// PreTax 为 true 的扣款 (如社保、公积金) 在计税前扣除，会减少应纳税所得额。
+type Deduction struct {
+	Name   string
+	Amount Money
+	Rate   Rate
+	PreTax bool
+}
+
+func (d Deduction) amount(gross Money) Money {
+	return d.Amount + gross.MulRate(d.Rate)
+}
+
+// Bonus 是在第 Period 期 (从 1 开始) 随工资一起发放的奖金
+type Bonus struct {
+	Name   string
+	Amount Money
+	Period int
+}
+
+// PayrollConfig 是工资计算的规则
+type PayrollConfig struct {
+	Period            PayPeriod
+	Brackets          TaxTable
+	StandardDeduction Money // 每年的基本减除费用，按期平均分摊
+	Deductions        []Deduction
+}
+
+// ChinaPayrollConfig 返回按中国个人所得税综合所得税率表 (按年) 和常见社保比例配置的月薪规则。
+// 社保和公积金的缴费基数上下限因城市而异，这里为了简单直接以应发工资为基数。
+func ChinaPayrollConfig() PayrollConfig {
+	table, err := NewTaxTable(
+		TaxBracket{UpTo: 36000 * Yuan, Rate: 3 * Percent},
+		TaxBracket{UpTo: 144000 * Yuan, Rate: 10 * Percent},
+		TaxBracket{UpTo: 300000 * Yuan, Rate: 20 * Percent},
+		TaxBracket{UpTo: 420000 * Yuan, Rate: 25 * Percent},
+		TaxBracket{UpTo: 660000 * Yuan, Rate: 30 * Percent},
+		TaxBracket{UpTo: 960000 * Yuan, Rate: 35 * Percent},
+		TaxBracket{Rate: 45 * Percent},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return PayrollConfig{
+		Period:            Monthly,
+		Brackets:          table,
+		StandardDeduction: 60000 * Yuan,
+		Deductions: []Deduction{
+			{Name: "养老保险", Rate: 8 * Percent, PreTax: true},
+			{Name: "医疗保险", Rate: 2 * Percent, PreTax: true},
+			{Name: "失业保险", Rate: 50 * BasisPoint, PreTax: true},
+			{Name: "住房公积金", Rate: 12 * Percent, PreTax: true},
+		},
+	}
+}
+
+// LineItem 是工资条上的一行
+type LineItem struct {
+	Name   string
+	Amount Money
+}
+
+// Payslip 是一名员工一期的工资条
+type Payslip struct {
+	Employee   EmployeeID
+	Name       string
+	Department string
+	Period     int
+	Base       Money
+	Bonuses    []LineItem
+	Gross      Money // 应发
+	PreTax     []LineItem
+	Taxable    Money // 本期应纳税所得额，可能为 0
+	Tax        Money
+	PostTax    []LineItem
+	Net        Money // 实发
+}
+
+// Payroll 为通讯录中的员工计算工资
+type Payroll struct {
+	cfg     PayrollConfig
+	dir     *Directory
+	bonuses map[EmployeeID][]Bonus
+}
+
+// NewPayroll 创建工资计算器，cfg 中的发薪周期和税率表必须有效
+func NewPayroll(dir *Directory, cfg PayrollConfig) (*Payroll, error) {
+	if cfg.Period <= 0 {
+		return nil, fmt.Errorf("发薪周期必须为正数, 得到 %d", cfg.Period)
+	}
+	if _, err := NewTaxTable(cfg.Brackets...); err != nil {
+		return nil, err
+	}
+	return &Payroll{cfg: cfg, dir: dir, bonuses: map[EmployeeID][]Bonus{}}, nil
+}
+
+func (p *Payroll) checkPeriod(period int) error {
+	if period < 1 || period > int(p.cfg.Period) {
+		return fmt.Errorf("%w: 第 %d 期, 一年共 %d 期", ErrInvalidPeriod, period, p.cfg.Period)
+	}
+	return nil
+}
+
+// AddBonus 为员工登记一笔奖金
+func (p *Payroll) AddBonus(id EmployeeID, b Bonus) error {
+	if _, ok := p.dir.Get(id); !ok {
+		return fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	if err := p.checkPeriod(b.Period); err != nil {
+		return err
+	}
+	p.bonuses[id] = append(p.bonuses[id], b)
+	return nil
+}
+
+// Payslips 计算员工从第 1 期到第 through 期的工资条。
+// 累计预扣法要求按顺序计算，所以即使只需要最后一期，也要从第 1 期算起。
+func (p *Payroll) Payslips(id EmployeeID, through int) ([]Payslip, error) {
+	e, ok := p.dir.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: 编号 %d", ErrEmployeeNotFound, id)
+	}
+	if err := p.checkPeriod(through); err != nil {
+		return nil, err
+	}
+	n := int(p.cfg.Period)
+	base := MoneyFromFloat(e.Salary).Allocate(n)
+	allowance := p.cfg.StandardDeduction.Allocate(n)
+
+	slips := make([]Payslip, 0, through)
+	var cumTaxable, withheld Money
+	for period := 1; period <= through; period++ {
+		s := Payslip{Employee: id, Name: e.FullName(), Department: e.Department, Period: period, Base: base[period-1]}
+		s.Gross = s.Base
+		for _, b := range p.bonuses[id] {
+			if b.Period == period {
+				s.Bonuses = append(s.Bonuses, LineItem{b.Name, b.Amount})
+				s.Gross += b.Amount
+			}
+		}
+
+		var preTax, postTax Money
+		for _, d := range p.cfg.Deductions {
+			item := LineItem{d.Name, d.amount(s.Gross)}
+			if d.PreTax {
+				s.PreTax = append(s.PreTax, item)
+				preTax += item.Amount
+			} else {
+				s.PostTax = append(s.PostTax, item)
+				postTax += item.Amount
+			}
+		}
+
+		// 累计预扣：本期税额 = 累计应纳税额 - 已预扣税额，结果为负时本期不扣税也不退税。
+		// 累计应纳税所得额本身不能在每期截断为 0：低收入月份没用完的减除费用要留给后面的月份抵扣，
+		// 只在计算税额时把负的累计值当作 0。
+		s.Taxable = max(s.Gross-preTax-allowance[period-1], 0)
+		cumTaxable += s.Gross - preTax - allowance[period-1]
+		s.Tax = max(p.cfg.Brackets.Tax(max(cumTaxable, 0))-withheld, 0)
+		withheld += s.Tax
+
+		s.Net = s.Gross - preTax - s.Tax - postTax
+		slips = append(slips, s)
+	}
+	return slips, nil
+}
+
+// Payslip 返回员工第 period 期的工资条
+func (p *Payroll) Payslip(id EmployeeID, period int) (Payslip, error) {
+	slips, err := p.Payslips(id, period)
+	if err != nil {
+		return Payslip{}, err
+	}
+	return slips[len(slips)-1], nil
+}
+
+// Write 以工资条的格式输出。金额放在名称前面并右对齐：
+// 中文字符的显示宽度是英文的两倍，放在后面的名称不需要对齐。
+func (s Payslip) Write(w io.Writer) error {
+	lines := []LineItem{{"基本工资", s.Base}}
+	lines = append(lines, s.Bonuses...)
+	lines = append(lines, LineItem{"应发合计", s.Gross})
+	for _, d := range s.PreTax {
+		lines = append(lines, LineItem{d.Name, -d.Amount})
+	}
+	lines = append(lines, LineItem{"个人所得税", -s.Tax})
+	for _, d := range s.PostTax {
+		lines = append(lines, LineItem{d.Name, -d.Amount})
+	}
+	lines = append(lines, LineItem{"实发工资", s.Net})
+
+	if _, err := fmt.Fprintf(w, "%s (%s) 第 %d 期工资条\n", s.Name, s.Department, s.Period); err != nil {
+		return err
+	}
+	for _, l := range lines {
+		if _, err := fmt.Fprintf(w, "  %14s  %s\n", l.Amount, l.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DepartmentCost 是一个部门在某个时间段内的人工成本汇总
+type DepartmentCost struct {
+	Department string
+	Headcount  int
+	Gross      Money
+	Deductions Money // 税前和税后扣除合计
+	Tax        Money
+	Net        Money
+}
+
+// DepartmentCosts 汇总第 from 到第 to 期 (包含两端) 各部门的工资支出，按部门名称排序
+func (p *Payroll) DepartmentCosts(from, to int) ([]DepartmentCost, error) {
+	if err := p.checkPeriod(from); err != nil {
+		return nil, err
+	}
+	if err := p.checkPeriod(to); err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("%w: 起始期 %d 晚于结束期 %d", ErrInvalidPeriod, from, to)
+	}
+	byDept := map[string]*DepartmentCost{}
+	var err error
+	p.dir.Walk(func(id EmployeeID, _ int) bool {
+		var slips []Payslip
+		slips, err = p.Payslips(id, to)
+		if err != nil {
+			return false
+		}
+		e, _ := p.dir.Get(id)
+		c, ok := byDept[e.Department]
+		if !ok {
+			c = &DepartmentCost{Department: e.Department}
+			byDept[e.Department] = c
+		}
+		c.Headcount++
+		for _, s := range slips[from-1:] {
+			c.Gross += s.Gross
+			c.Tax += s.Tax
+			c.Net += s.Net
+			c.Deductions += s.Gross - s.Tax - s.Net
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DepartmentCost, 0, len(byDept))
+	for _, c := range byDept {
+		out = append(out, *c)
+	}
+	slices.SortFunc(out, func(a, b DepartmentCost) int { return cmp.Compare(a.Department, b.Department) })
+	return out, nil
+}
+
+func demoPayroll() {
+	fmt.Println("\n--- 8. 定点数金额 ---")
+	x, y := 0.1, 0.2 // 用变量而不是常量：常量表达式 0.1+0.2 在编译时按任意精度计算，看不出误差
+	fmt.Println("float64: 0.1 + 0.2 =", x+y)
+	a, _ := ParseMoney("0.10")
+	b, _ := ParseMoney("0.20")
+	fmt.Println("Money:   0.10 + 0.20 =", a+b)
+	fmt.Println("100 元分成 3 份:", (100 * Yuan).Allocate(3))
+
+	fmt.Println("\n--- 9. 工资计算 ---")
+	d := NewDirectory()
+	boss := d.Add(Employee{Person: Person{FirstName: "Grace", LastName: "Hopper"}, Department: "Executive", Salary: 600000})
+	dev := d.Add(Employee{Person: Person{FirstName: "Rob", LastName: "Pike"}, Department: "Engineering", Salary: 240000})
+	dev2 := d.Add(Employee{Person: Person{FirstName: "Ken", LastName: "Thompson"}, Department: "Engineering", Salary: 180000})
+	_ = d.SetManager(dev, boss)
+	_ = d.SetManager(dev2, dev)
+
+	pay, err := NewPayroll(d, ChinaPayrollConfig())
+	if err != nil {
+		fmt.Println("创建工资计算器失败:", err)
+		return
+	}
+	if err := pay.AddBonus(dev, Bonus{Name: "年中奖", Amount: 30000 * Yuan, Period: 6}); err != nil {
+		fmt.Println("登记奖金失败:", err)
+	}
+
+	slips, err := pay.Payslips(dev, 12)
+	if err != nil {
+		fmt.Println("计算失败:", err)
+		return
+	}
+	if err := slips[5].Write(os.Stdout); err != nil {
+		fmt.Println("输出失败:", err)
+	}
+	var tax Money
+	for _, s := range slips {
+		tax += s.Tax
+	}
+	fmt.Println("全年预扣个税:", tax, "| 第 1 期:", slips[0].Tax, "第 12 期:", slips[11].Tax, "(累计预扣法下税率逐步升档)")
+
+	costs, err := pay.DepartmentCosts(1, 12)
+	if err != nil {
+		fmt.Println("汇总失败:", err)
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "部门\t人数\t应发\t扣款\t个税\t实发\t")
+	for _, c := range costs {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t\n", c.Department, c.Headcount, c.Gross, c.Deductions, c.Tax, c.Net)
+	}
+	_ = tw.Flush()
+}
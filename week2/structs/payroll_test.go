@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoney(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Money
+		str  string
+	}{
+		{"1234", 1234 * Yuan, "1,234.00"},
+		{"1,234.5", 123450, "1,234.50"},
+		{"-0.05", -5, "-0.05"},
+		{"1000000.99", 100000099, "1,000,000.99"},
+	}
+	for _, tc := range testCases {
+		m, err := ParseMoney(tc.in)
+		if err != nil || m != tc.want {
+			t.Errorf("ParseMoney(%q) = %d, %v; want %d", tc.in, m, err, tc.want)
+			continue
+		}
+		if got := m.String(); got != tc.str {
+			t.Errorf("Money(%d).String() = %s; want %s", m, got, tc.str)
+		}
+	}
+	for _, in := range []string{"", "1.234", "1.", "abc", "--1", "1.-5"} {
+		if _, err := ParseMoney(in); !errors.Is(err, ErrInvalidMoney) {
+			t.Errorf("ParseMoney(%q) 的错误 = %v; want ErrInvalidMoney", in, err)
+		}
+	}
+
+	// 0.5 分向远离 0 的方向舍入
+	if got := Money(5).MulRate(50 * Percent); got != 3 {
+		t.Errorf("0.05 × 50%% = %d 分; want 3", got)
+	}
+	if got := Money(-5).MulRate(50 * Percent); got != -3 {
+		t.Errorf("-0.05 × 50%% = %d 分; want -3", got)
+	}
+
+	for _, m := range []Money{100 * Yuan, -100 * Yuan, 2} {
+		parts := m.Allocate(3)
+		var sum Money
+		for _, p := range parts {
+			sum += p
+		}
+		if sum != m || parts[0]-parts[2] > Cent || parts[2]-parts[0] > Cent {
+			t.Errorf("%v.Allocate(3) = %v", m, parts)
+		}
+	}
+}
+
+func TestTaxTable(t *testing.T) {
+	table := ChinaPayrollConfig().Brackets
+	testCases := []struct {
+		income, want Money
+	}{
+		{0, 0},
+		{-1000 * Yuan, 0},
+		{36000 * Yuan, 1080 * Yuan},
+		{100000 * Yuan, 1080*Yuan + 6400*Yuan}, // 36000×3% + 64000×10%
+		{1000000 * Yuan, 250080*Yuan + 40000*45*Yuan/100}, // 前六档合计 250,080，超出 960,000 的部分 45%
+	}
+	for _, tc := range testCases {
+		if got := table.Tax(tc.income); got != tc.want {
+			t.Errorf("Tax(%v) = %v; want %v", tc.income, got, tc.want)
+		}
+	}
+
+	if _, err := NewTaxTable(TaxBracket{UpTo: 100, Rate: Percent}, TaxBracket{UpTo: 50, Rate: Percent}, TaxBracket{Rate: Percent}); !errors.Is(err, ErrInvalidTaxTable) {
+		t.Errorf("上限不递增时应返回 ErrInvalidTaxTable, 得到 %v", err)
+	}
+	if _, err := NewTaxTable(TaxBracket{UpTo: 100, Rate: Percent}); !errors.Is(err, ErrInvalidTaxTable) {
+		t.Errorf("最后一档有上限时应返回 ErrInvalidTaxTable, 得到 %v", err)
+	}
+}
+
+// payrollFor 创建只有一名员工的工资计算器
+func payrollFor(t *testing.T, salary float64, bonuses ...Bonus) (*Payroll, EmployeeID) {
+	t.Helper()
+	d := NewDirectory()
+	id := d.Add(Employee{Person: Person{FirstName: "Test", LastName: "User"}, Department: "QA", Salary: salary})
+	p, err := NewPayroll(d, ChinaPayrollConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range bonuses {
+		if err := p.AddBonus(id, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p, id
+}
+
+func totalTax(t *testing.T, p *Payroll, id EmployeeID) Money {
+	t.Helper()
+	slips, err := p.Payslips(id, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tax Money
+	for _, s := range slips {
+		tax += s.Tax
+	}
+	return tax
+}
+
+func TestPayslipsCumulativeWithholding(t *testing.T) {
+	// 年薪 36,000 加 10,000 奖金，扣除社保公积金 (22.5%) 后低于全年 60,000 的减除费用，
+	// 全年不应预扣任何税款：前几个月没用完的减除费用要抵扣第 6 期的奖金
+	p, id := payrollFor(t, 36000, Bonus{Name: "奖金", Amount: 10000 * Yuan, Period: 6})
+	if tax := totalTax(t, p, id); tax != 0 {
+		t.Errorf("年应纳税所得额为负时预扣了 %v", tax)
+	}
+
+	// 全年预扣总额应等于按全年收入一次性计算的税额
+	p, id = payrollFor(t, 240000, Bonus{Name: "年中奖", Amount: 30000 * Yuan, Period: 6})
+	gross := 270000 * Yuan
+	annual := ChinaPayrollConfig().Brackets.Tax(gross - gross.MulRate(2250*BasisPoint) - 60000*Yuan)
+	if tax := totalTax(t, p, id); tax != annual {
+		t.Errorf("全年预扣 %v; want %v", tax, annual)
+	}
+}
@@ -0,0 +1,316 @@
+package structdiff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Conflict 是一处无法应用的变更
+type Conflict struct {
+	Change Change
+	Reason string
+}
+
+// ConflictError 列出所有冲突。只要有冲突，Apply 就不会修改目标。
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	msgs := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		msgs[i] = fmt.Sprintf("%v: %s", c.Change.Path, c.Reason)
+	}
+	return ErrConflict.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ConflictError) Unwrap() error { return ErrConflict }
+
+// Apply 把变更依次应用到 dst 指向的结构体上。
+//
+// 应用之前会先检查每一处变更的旧值是否与 dst 中的当前值一致 (Added 要求键或下标尚不存在，
+// Removed 要求要删除的值仍然存在)，以及新值能否赋给目标的类型，
+// 任何一处不满足都会返回 *ConflictError，dst 保持不变。
+// 这样在 "读取 -> 修改 -> 写回" 的过程中，如果别人已经改过同一个字段，就不会悄悄覆盖对方的修改。
+//
+// 注意：与普通赋值一样，dst 中与其他变量共享的切片和 map 会被一起修改。
+func Apply(dst any, cs ChangeSet) error {
+	root := reflect.ValueOf(dst)
+	if root.Kind() != reflect.Pointer || root.IsNil() || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: dst 必须是指向结构体的非 nil 指针, 得到 %T", ErrTypeMismatch, dst)
+	}
+	root = root.Elem()
+	if err := check(root, cs); err != nil {
+		return err
+	}
+	for _, c := range cs {
+		if err := set(root, c.Path, c); err != nil {
+			return fmt.Errorf("应用 %v 失败: %w", c.Path, err)
+		}
+	}
+	return nil
+}
+
+// Merge 把 base 到 updated 之间的变更应用到 dst 上，相当于一次三方合并：
+// dst 是从 base 出发、可能已经被别人修改过的版本。
+func Merge(dst, base, updated any) error {
+	if t := reflect.TypeOf(dst); t == nil || t.Kind() != reflect.Pointer || t.Elem() != indirect(reflect.TypeOf(base)) {
+		return fmt.Errorf("%w: dst 的类型 %T 与 base 的类型 %T 不对应", ErrTypeMismatch, dst, base)
+	}
+	cs, err := Diff(base, updated)
+	if err != nil {
+		return err
+	}
+	return Apply(dst, cs)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// check 在不修改 root 的前提下检查所有变更能否应用。
+// 对切片的 Added / Removed 需要模拟长度的变化，因为同一个切片上可能有连续多次追加或删除。
+func check(root reflect.Value, cs ChangeSet) error {
+	var conflicts []Conflict
+	fail := func(c Change, format string, args ...any) {
+		conflicts = append(conflicts, Conflict{Change: c, Reason: fmt.Sprintf(format, args...)})
+	}
+	lengths := map[string]int{} // 切片路径 -> 应用之前的变更后的长度
+
+	for _, c := range cs {
+		if len(c.Path) == 0 {
+			fail(c, "路径为空")
+			continue
+		}
+		if c.Op == Changed {
+			cur, ok := get(root, c.Path)
+			if !ok {
+				fail(c, "路径不存在")
+			} else if !reflect.DeepEqual(cur.Interface(), c.From) {
+				fail(c, "期望当前值为 %#v, 实际为 %#v", c.From, cur.Interface())
+			} else if _, err := convert(c.To, cur.Type()); err != nil {
+				fail(c, "%v", err)
+			}
+			continue
+		}
+
+		parentPath, last := c.Path[:len(c.Path)-1], c.Path[len(c.Path)-1]
+		parent, ok := get(root, parentPath)
+		if !ok {
+			fail(c, "路径不存在")
+			continue
+		}
+		switch {
+		case last.kind == indexStep && parent.Kind() == reflect.Slice:
+			key := parentPath.String()
+			n, seen := lengths[key]
+			if !seen {
+				n = parent.Len()
+			}
+			if c.Op == Added {
+				if last.Index != n {
+					fail(c, "只能追加到下标 %d", n)
+				} else if _, err := convert(c.To, parent.Type().Elem()); err != nil {
+					fail(c, "%v", err)
+				}
+				lengths[key] = n + 1
+			} else {
+				if n == 0 {
+					fail(c, "切片已经为空")
+				} else if last.Index != n-1 {
+					fail(c, "只能删除最后一个元素 (下标 %d)", n-1)
+				} else if cur := parent.Index(last.Index).Interface(); !reflect.DeepEqual(cur, c.From) {
+					fail(c, "期望当前值为 %#v, 实际为 %#v", c.From, cur)
+				}
+				lengths[key] = n - 1
+			}
+		case last.kind == keyStep && parent.Kind() == reflect.Map:
+			k, err := convert(last.Key, parent.Type().Key())
+			if err != nil {
+				fail(c, "%v", err)
+				continue
+			}
+			cur := parent.MapIndex(k)
+			switch {
+			case c.Op == Added && cur.IsValid():
+				fail(c, "键已经存在, 当前值为 %#v", cur.Interface())
+			case c.Op == Added:
+				if _, err := convert(c.To, parent.Type().Elem()); err != nil {
+					fail(c, "%v", err)
+				}
+			case c.Op == Removed && !cur.IsValid():
+				fail(c, "键不存在")
+			case c.Op == Removed && !reflect.DeepEqual(cur.Interface(), c.From):
+				fail(c, "期望当前值为 %#v, 实际为 %#v", c.From, cur.Interface())
+			}
+		default:
+			fail(c, "%v 只能用于 map 的键或切片的下标", c.Op)
+		}
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// field 返回结构体 v 中名为 name 的字段，只接受 Diff 会比较的字段 (导出的字段和嵌入的结构体)
+func field(v reflect.Value, name string) (reflect.Value, bool) {
+	sf, ok := v.Type().FieldByName(name)
+	if !ok || !visible(sf) {
+		return reflect.Value{}, false
+	}
+	f, err := v.FieldByIndexErr(sf.Index) // 提升的字段可能要经过 nil 的嵌入指针
+	return f, err == nil
+}
+
+// get 沿着路径读取值，途中遇到 nil 指针、不存在的字段、越界的下标或不存在的键时返回 false
+func get(v reflect.Value, path Path) (reflect.Value, bool) {
+	for _, s := range path {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		switch {
+		case s.kind == fieldStep && v.Kind() == reflect.Struct:
+			var ok bool
+			if v, ok = field(v, s.Field); !ok {
+				return reflect.Value{}, false
+			}
+		case s.kind == indexStep && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+			if s.Index < 0 || s.Index >= v.Len() {
+				return reflect.Value{}, false
+			}
+			v = v.Index(s.Index)
+		case s.kind == keyStep && v.Kind() == reflect.Map:
+			k, err := convert(s.Key, v.Type().Key())
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(k)
+			if !v.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	// 未导出的嵌入结构体本身不能读取，只有它的导出字段可以
+	return v, v.CanInterface()
+}
+
+// convert 把 any 转换为类型 t 的 reflect.Value，nil 转换为零值
+func convert(x any, t reflect.Type) (reflect.Value, error) {
+	if x == nil {
+		return reflect.Zero(t), nil
+	}
+	v := reflect.ValueOf(x)
+	if !v.Type().AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("%w: 不能把 %v 赋值给 %v", ErrTypeMismatch, v.Type(), t)
+	}
+	return v, nil
+}
+
+// set 沿着路径修改 v (v 必须可以赋值)。途中的 nil 指针和 nil map 会被自动创建；
+// map 的元素和接口中的值不能直接修改，需要复制出来、修改后再写回去。
+func set(v reflect.Value, path Path, c Change) error {
+	if len(path) == 0 {
+		if !v.CanSet() {
+			return fmt.Errorf("%w: %v 类型的值不能修改", ErrInvalidPath, v.Type())
+		}
+		x, err := convert(c.To, v.Type())
+		if err != nil {
+			return err
+		}
+		v.Set(x)
+		return nil
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if !v.CanSet() {
+				return fmt.Errorf("%w: 不能为 %v 分配内存", ErrInvalidPath, v.Type())
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Errorf("%w: 接口值为 nil", ErrInvalidPath)
+		}
+		inner := reflect.New(v.Elem().Type()).Elem()
+		inner.Set(v.Elem())
+		if err := set(inner, path, c); err != nil {
+			return err
+		}
+		v.Set(inner)
+		return nil
+	}
+
+	s, rest := path[0], path[1:]
+	switch {
+	case s.kind == fieldStep && v.Kind() == reflect.Struct:
+		// 未导出的嵌入结构体不能整体赋值，但可以继续访问它的导出字段
+		f, ok := field(v, s.Field)
+		if !ok {
+			return fmt.Errorf("%w: %v 没有可导出的字段 %s", ErrInvalidPath, v.Type(), s.Field)
+		}
+		return set(f, rest, c)
+
+	case s.kind == indexStep && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+		if len(rest) == 0 && c.Op == Added && v.Kind() == reflect.Slice {
+			x, err := convert(c.To, v.Type().Elem())
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.Append(v, x))
+			return nil
+		}
+		if s.Index < 0 || s.Index >= v.Len() {
+			return fmt.Errorf("%w: 下标 %d 越界, 长度为 %d", ErrInvalidPath, s.Index, v.Len())
+		}
+		if len(rest) == 0 && c.Op == Removed && v.Kind() == reflect.Slice {
+			v.Set(reflect.AppendSlice(v.Slice(0, s.Index), v.Slice(s.Index+1, v.Len())))
+			return nil
+		}
+		return set(v.Index(s.Index), rest, c)
+
+	case s.kind == keyStep && v.Kind() == reflect.Map:
+		k, err := convert(s.Key, v.Type().Key())
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		if len(rest) == 0 {
+			if c.Op == Removed {
+				v.SetMapIndex(k, reflect.Value{}) // 传入零值 Value 表示删除
+				return nil
+			}
+			x, err := convert(c.To, v.Type().Elem())
+			if err != nil {
+				return err
+			}
+			v.SetMapIndex(k, x)
+			return nil
+		}
+		cur := v.MapIndex(k)
+		if !cur.IsValid() {
+			return fmt.Errorf("%w: 键 %#v 不存在", ErrInvalidPath, s.Key)
+		}
+		elem := reflect.New(cur.Type()).Elem()
+		elem.Set(cur)
+		if err := set(elem, rest, c); err != nil {
+			return err
+		}
+		v.SetMapIndex(k, elem)
+		return nil
+	}
+	return fmt.Errorf("%w: 不能在 %v 上访问 %v", ErrInvalidPath, v.Type(), Path{s})
+}
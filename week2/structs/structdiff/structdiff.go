@@ -0,0 +1,294 @@
+// Package structdiff 用反射比较两个同类型的结构体，列出所有不同之处，并能把这些差异应用到另一个结构体上。
+//
+// 比较时会递归进入嵌套结构体、嵌入字段、指针、切片、数组和 map，每一处差异记录为一个 Change，
+// 其中的 Path 描述了从顶层结构体到该值的访问路径，例如 Person.Age、Tags[2]、Scores["math"]。
+//
+// 规则:
+//   - 未导出的字段无法通过反射读写，会被跳过；但嵌入的结构体即使类型名未导出也会深入比较
+//   - 指针、map 和切片的循环引用会被识别出来，不会无限递归
+//   - 只有未导出字段的结构体 (如 time.Time) 被视为一个整体，只比较是否相等，不再深入
+//   - 函数和 channel 类型的字段被跳过
+//   - 切片按下标逐个比较，多出来的元素记为 Added 或 Removed；map 按键比较
+//     (NaN 键不等于任何键，总是记为 Removed 或 Added，而且这样的变更无法被 Apply 应用)
+//   - nil 切片与空切片、nil map 与空 map 视为相同
+package structdiff
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrTypeMismatch 表示参与比较或应用的值类型不一致
+	ErrTypeMismatch = errors.New("类型不匹配")
+	// ErrConflict 表示应用变更时，目标中的当前值与变更记录的旧值不同
+	ErrConflict = errors.New("变更冲突")
+	// ErrInvalidPath 表示变更的路径在目标类型中不存在
+	ErrInvalidPath = errors.New("无效的路径")
+)
+
+// Op 是变更的种类
+type Op int
+
+const (
+	Changed Op = iota // 值被修改
+	Added             // map 中新增的键，或切片末尾新增的元素
+	Removed           // map 中删除的键，或切片末尾删除的元素
+)
+
+func (op Op) String() string {
+	switch op {
+	case Changed:
+		return "~"
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "Op(" + strconv.Itoa(int(op)) + ")"
+	}
+}
+
+// Step 是路径中的一步：访问字段、切片下标或 map 的键，三者只有一个有意义
+type Step struct {
+	Field string
+	Index int
+	Key   any
+	kind  stepKind
+}
+
+type stepKind int
+
+const (
+	fieldStep stepKind = iota
+	indexStep
+	keyStep
+)
+
+// Path 是从顶层值到某个字段的访问路径
+type Path []Step
+
+// String 把路径写成 Go 表达式的形式，例如 ContactInfo.City、Tags[0]、Meta["owner"]
+func (p Path) String() string {
+	var sb strings.Builder
+	for _, s := range p {
+		switch s.kind {
+		case fieldStep:
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(s.Field)
+		case indexStep:
+			fmt.Fprintf(&sb, "[%d]", s.Index)
+		case keyStep:
+			fmt.Fprintf(&sb, "[%#v]", s.Key)
+		}
+	}
+	return sb.String()
+}
+
+// with 返回追加了一步的新路径。必须复制，否则兄弟节点会共享同一个底层数组
+func (p Path) with(s Step) Path {
+	return append(slices.Clip(p), s)
+}
+
+// Change 是一处差异。Added 时 From 为 nil，Removed 时 To 为 nil。
+type Change struct {
+	Path Path
+	Op   Op
+	From any
+	To   any
+}
+
+func (c Change) String() string {
+	switch c.Op {
+	case Added:
+		return fmt.Sprintf("+ %v: %#v", c.Path, c.To)
+	case Removed:
+		return fmt.Sprintf("- %v: %#v", c.Path, c.From)
+	default:
+		return fmt.Sprintf("~ %v: %#v -> %#v", c.Path, c.From, c.To)
+	}
+}
+
+// ChangeSet 是 Diff 的结果，顺序与字段的声明顺序一致
+type ChangeSet []Change
+
+func (cs ChangeSet) String() string {
+	lines := make([]string, len(cs))
+	for i, c := range cs {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff 比较 a 和 b，返回把 a 变成 b 所需的变更。a 和 b 必须是同一种结构体类型，或指向它的指针。
+func Diff(a, b any) (ChangeSet, error) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return nil, fmt.Errorf("%w: %T 与 %T", ErrTypeMismatch, a, b)
+	}
+	for va.Kind() == reflect.Pointer {
+		if va.IsNil() || vb.IsNil() {
+			return nil, fmt.Errorf("%w: 不能比较 nil 指针", ErrTypeMismatch)
+		}
+		va, vb = va.Elem(), vb.Elem()
+	}
+	if va.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: 只能比较结构体, 得到 %v", ErrTypeMismatch, va.Type())
+	}
+	// 从原始的值开始比较，顶层的指针也要登记，a.Next = a 才能在第一次回到 a 时被发现
+	d := differ{active: map[visit]bool{}}
+	d.diff(nil, reflect.ValueOf(a), reflect.ValueOf(b))
+	return d.cs, nil
+}
+
+// visible 报告字段是否参与比较：导出的字段，以及嵌入的结构体 (即使类型名未导出，
+// 它的导出字段也会被提升到外层，和外层自己的字段一样可以读写)
+func visible(f reflect.StructField) bool {
+	return f.IsExported() || f.Anonymous && f.Type.Kind() == reflect.Struct
+}
+
+// opaque 报告结构体是否没有可以比较的字段，这样的结构体只能整体比较
+func opaque(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if visible(t.Field(i)) {
+			return false
+		}
+	}
+	return t.NumField() > 0
+}
+
+// visit 是一对指针、map 或切片。沿着当前的递归路径再次遇到同一对时说明出现了循环引用
+// (例如 a.Next = a)，直接跳过，否则会无限递归下去。reflect.DeepEqual 也是这样处理的。
+// 只跳过正在比较的 (位于递归栈上的) 那一对：同一个对象被两个字段共享时，两条路径上的差异都要报告。
+type visit struct {
+	a, b   uintptr
+	na, nb int // 切片的长度，同一底层数组上长度不同的切片不是同一个值
+	typ    reflect.Type
+}
+
+type differ struct {
+	cs     ChangeSet
+	active map[visit]bool
+}
+
+// visitKey 返回 a 和 b 这一对引用的标识，不是引用类型或为 nil 时 ok 为 false
+func visitKey(a, b reflect.Value) (v visit, ok bool) {
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			return visit{}, false
+		}
+	case reflect.Slice:
+		if a.Len() == 0 || b.Len() == 0 {
+			return visit{}, false
+		}
+		v.na, v.nb = a.Len(), b.Len()
+	default:
+		return visit{}, false
+	}
+	v.a, v.b, v.typ = a.Pointer(), b.Pointer(), a.Type()
+	return v, true
+}
+
+func (d *differ) diff(path Path, a, b reflect.Value) {
+	changed := func() {
+		if !a.CanInterface() {
+			return // 通过未导出的嵌入字段取到的未导出值，无法读取
+		}
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.cs = append(d.cs, Change{Path: path, Op: Changed, From: a.Interface(), To: b.Interface()})
+		}
+	}
+	if key, ok := visitKey(a, b); ok {
+		if d.active[key] {
+			return
+		}
+		d.active[key] = true
+		defer delete(d.active, key)
+	}
+	switch a.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return
+	case reflect.Struct:
+		if opaque(a.Type()) {
+			changed()
+			return
+		}
+		for i := range a.NumField() {
+			f := a.Type().Field(i)
+			if !visible(f) {
+				continue
+			}
+			// 嵌入字段的名字就是类型名，路径 Person.Age 与 Go 中的 emp.Person.Age 写法一致
+			d.diff(path.with(Step{Field: f.Name, kind: fieldStep}), a.Field(i), b.Field(i))
+		}
+	case reflect.Pointer, reflect.Interface:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil() || b.IsNil():
+			changed()
+		case a.Kind() == reflect.Interface && a.Elem().Type() != b.Elem().Type():
+			changed() // 接口中的动态类型不同，无法逐字段比较
+		default:
+			d.diff(path, a.Elem(), b.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		n := min(a.Len(), b.Len())
+		for i := range n {
+			d.diff(path.with(Step{Index: i, kind: indexStep}), a.Index(i), b.Index(i))
+		}
+		for i := n; i < b.Len(); i++ {
+			d.cs = append(d.cs, Change{Path: path.with(Step{Index: i, kind: indexStep}), Op: Added, To: b.Index(i).Interface()})
+		}
+		// 从后往前删除，按顺序应用时每次删除的都是最后一个元素
+		for i := a.Len() - 1; i >= n; i-- {
+			d.cs = append(d.cs, Change{Path: path.with(Step{Index: i, kind: indexStep}), Op: Removed, From: a.Index(i).Interface()})
+		}
+	case reflect.Map:
+		for _, e := range mapEntries(a, b) {
+			p := path.with(Step{Key: e.key.Interface(), kind: keyStep})
+			switch {
+			case !e.b.IsValid():
+				d.cs = append(d.cs, Change{Path: p, Op: Removed, From: e.a.Interface()})
+			case !e.a.IsValid():
+				d.cs = append(d.cs, Change{Path: p, Op: Added, To: e.b.Interface()})
+			default:
+				d.diff(p, e.a, e.b)
+			}
+		}
+	default:
+		changed()
+	}
+}
+
+// mapEntry 是两个 map 中同一个键对应的值，键只在一侧存在时另一侧为零值 Value
+type mapEntry struct {
+	key  reflect.Value
+	a, b reflect.Value
+}
+
+// mapEntries 返回两个 map 的键的并集，按格式化后的字符串排序，保证结果的顺序稳定。
+// 值直接取自 MapRange 而不是再用键查找一次：NaN 不等于自身，MapIndex(NaN) 永远找不到，
+// 所以 NaN 键总是只出现在一侧 (一个 map 中可以有多个 NaN 键)。
+func mapEntries(a, b reflect.Value) []mapEntry {
+	var entries []mapEntry
+	for it := a.MapRange(); it.Next(); {
+		entries = append(entries, mapEntry{key: it.Key(), a: it.Value(), b: b.MapIndex(it.Key())})
+	}
+	for it := b.MapRange(); it.Next(); {
+		if !a.MapIndex(it.Key()).IsValid() {
+			entries = append(entries, mapEntry{key: it.Key(), b: it.Value()})
+		}
+	}
+	slices.SortStableFunc(entries, func(x, y mapEntry) int {
+		return cmp.Compare(fmt.Sprint(x.key.Interface()), fmt.Sprint(y.key.Interface()))
+	})
+	return entries
+}
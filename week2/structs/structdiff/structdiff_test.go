@@ -0,0 +1,220 @@
+package structdiff
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City string
+	Zip  string
+}
+
+type inner struct {
+	Level int
+}
+
+type record struct {
+	inner   // 类型名未导出的嵌入结构体，Level 被提升为 record 的字段
+	Name    string
+	Home    address
+	Tags    []string
+	Meta    map[string]int
+	Boss    *record
+	Created time.Time
+	secret  int
+}
+
+func sample() record {
+	return record{
+		inner:   inner{Level: 1},
+		Name:    "Gary",
+		Home:    address{City: "GoCity", Zip: "12345"},
+		Tags:    []string{"a", "b", "c"},
+		Meta:    map[string]int{"x": 1, "y": 2},
+		Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		secret:  7,
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a, b := sample(), sample()
+	b.Level = 2
+	b.Home.City = "GoLand"
+	b.Tags = []string{"a", "B"}
+	b.Meta = map[string]int{"x": 1, "y": 3, "z": 4}
+	b.Boss = &record{Name: "Rob"}
+	b.Created = b.Created.Add(time.Hour)
+	b.secret = 8 // 未导出的字段被忽略
+
+	cs, err := Diff(a, &b)
+	if err == nil {
+		t.Fatal("值和指针类型不同, 应当返回错误")
+	}
+	if cs, err = Diff(&a, &b); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"inner.Level",
+		"Home.City",
+		"Tags[1]",
+		"Tags[2]",
+		`Meta["y"]`,
+		`Meta["z"]`,
+		"Boss",
+		"Created",
+	}
+	if len(cs) != len(want) {
+		t.Fatalf("得到 %d 处变更, want %d:\n%v", len(cs), len(want), cs)
+	}
+	for i, c := range cs {
+		if got := c.Path.String(); got != want[i] {
+			t.Errorf("第 %d 处变更的路径 = %s; want %s", i, got, want[i])
+		}
+	}
+	if cs[3].Op != Removed || cs[5].Op != Added {
+		t.Errorf("Tags[2] 应为 Removed, Meta[\"z\"] 应为 Added:\n%v", cs)
+	}
+
+	if _, err := Diff(1, 2); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("比较非结构体的错误 = %v; want ErrTypeMismatch", err)
+	}
+}
+
+func TestDiffCycle(t *testing.T) {
+	a, b := &record{Name: "a"}, &record{Name: "b"}
+	a.Boss, b.Boss = a, b // 自己引用自己
+
+	done := make(chan ChangeSet)
+	go func() {
+		cs, _ := Diff(a, b)
+		done <- cs
+	}()
+	select {
+	case cs := <-done:
+		if len(cs) != 1 || cs[0].Path.String() != "Name" {
+			t.Errorf("Diff = %v; want 只有 Name 一处变更", cs)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("循环引用导致 Diff 无法结束")
+	}
+
+	// 同一个对象被两个字段共享时，两条路径上的变更都要报告
+	type pair struct{ X, Y *inner }
+	sa, sb := &inner{1}, &inner{2}
+	cs, err := Diff(pair{sa, sa}, pair{sb, sb})
+	if err != nil || len(cs) != 2 {
+		t.Errorf("共享指针的变更 = %v, %v; want X.Level 和 Y.Level", cs, err)
+	}
+}
+
+func TestApplyAndMerge(t *testing.T) {
+	base, updated := sample(), sample()
+	updated.Level = 3
+	updated.Home.City = "GoLand"
+	updated.Tags = append(updated.Tags, "d", "e")
+	updated.Meta = map[string]int{"y": 2, "z": 26}
+	updated.Boss = &record{Name: "Rob", Tags: []string{"boss"}}
+
+	cs, err := Diff(base, updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := sample()
+	if err := Apply(&dst, cs); err != nil {
+		t.Fatal(err)
+	}
+	dst.secret = updated.secret
+	if !reflect.DeepEqual(dst, updated) {
+		t.Errorf("Apply 之后\n%+v\nwant\n%+v", dst, updated)
+	}
+
+	// 三方合并：不同字段上的修改可以合并
+	other := sample()
+	other.Name = "Gopher"
+	if err := Merge(&other, base, updated); err != nil {
+		t.Fatal(err)
+	}
+	if other.Name != "Gopher" || other.Home.City != "GoLand" || other.Level != 3 {
+		t.Errorf("合并结果 = %+v", other)
+	}
+
+	// 同一字段上的不同修改是冲突，dst 保持不变
+	other = sample()
+	other.Home.City = "Elsewhere"
+	before := sample()
+	before.Home.City = "Elsewhere"
+	err = Merge(&other, base, updated)
+	var ce *ConflictError
+	if !errors.Is(err, ErrConflict) || !errors.As(err, &ce) || len(ce.Conflicts) != 1 {
+		t.Fatalf("Merge 的错误 = %v; want 一处冲突", err)
+	}
+	if !reflect.DeepEqual(other, before) {
+		t.Errorf("冲突时 dst 被修改了: %+v", other)
+	}
+}
+
+func TestApplyRejectsBadChangeSet(t *testing.T) {
+	dst := sample()
+	// 第一处变更合法，第二处的新值类型不对：两处都不能应用
+	cs := ChangeSet{
+		{Path: Path{{Field: "Name", kind: fieldStep}}, Op: Changed, From: "Gary", To: "Rob"},
+		{Path: Path{{Field: "Home", kind: fieldStep}, {Field: "City", kind: fieldStep}}, Op: Changed, From: "GoCity", To: 42},
+	}
+	if err := Apply(&dst, cs); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Apply 的错误 = %v; want ErrConflict", err)
+	}
+	if !reflect.DeepEqual(dst, sample()) {
+		t.Errorf("失败时 dst 被修改了: %+v", dst)
+	}
+
+	if err := Apply(dst, cs[:1]); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("dst 不是指针时的错误 = %v; want ErrTypeMismatch", err)
+	}
+	cs = ChangeSet{{Path: Path{{Field: "secret", kind: fieldStep}}, Op: Changed, From: 7, To: 8}}
+	if err := Apply(&dst, cs); !errors.Is(err, ErrConflict) {
+		t.Errorf("修改未导出字段的错误 = %v; want ErrConflict", err)
+	}
+}
+
+// NaN 不等于自身，用 MapIndex 查找 NaN 键永远得到零值 Value，Diff 不能因此 panic
+func TestDiffNaNMapKey(t *testing.T) {
+	type scores struct {
+		M map[float64]int
+	}
+	nan := math.NaN()
+	cs, err := Diff(scores{M: map[float64]int{nan: 1, 2: 2}}, scores{M: map[float64]int{2: 3, nan: 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var added, removed, changed int
+	for _, c := range cs {
+		switch c.Op {
+		case Added:
+			added++
+			if c.To != 4 {
+				t.Errorf("Added 的值 = %v, want 4", c.To)
+			}
+		case Removed:
+			removed++
+			if c.From != 1 {
+				t.Errorf("Removed 的值 = %v, want 1", c.From)
+			}
+		case Changed:
+			changed++
+			if c.From != 2 || c.To != 3 {
+				t.Errorf("Changed = %v -> %v, want 2 -> 3", c.From, c.To)
+			}
+		}
+	}
+	if added != 1 || removed != 1 || changed != 1 {
+		t.Errorf("Diff = %v, want NaN 键各一次 Added 和 Removed, 键 2 一次 Changed", cs)
+	}
+
+	if _, err := Diff(scores{M: map[float64]int{nan: 1}}, scores{M: map[float64]int{}}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -153,6 +153,11 @@ func main() {
 	// nc2 := NonComparableStruct{Name: "Test", Tags: []string{"a"}}
 	// fmt.Println(nc1 == nc2) // 这会导致编译错误
 
+	demoDirectory()
+	demoPayroll()
+	demoAddress()
+	demoDiff()
+
 	fmt.Println("\n--- 结构体学习结束 ---")
 }
 
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// --- 9. 类型分派注册表 ---
+// Type Switch 适合类型集合固定的场景：每增加一种新形状，都要回到同一个 switch 里加一个 case。
+// 如果希望 "新增类型的人自己注册处理逻辑"，就可以把 switch 换成一张 "类型 -> 处理函数" 的表。
+// 借助泛型，注册时仍然能拿到具体类型的值，不需要在处理函数里再做类型断言。
+
+// ErrNoHandler 表示没有处理器能处理给定的值，并且没有设置兜底处理器
+var ErrNoHandler = errors.New("没有匹配的处理器")
+
+// ifaceHandler 是为接口类型注册的处理器，按注册顺序逐个尝试
+type ifaceHandler[R any] struct {
+	typ reflect.Type
+	fn  func(any) R
+}
+
+// Dispatcher 根据值的动态类型选择处理函数，R 是处理函数的返回值类型。
+// 查找顺序:
+//
+//  1. 值为 nil 接口时使用 OnNil 注册的处理器，没有注册时直接跳到第 5 步
+//  2. 与动态类型完全相同的具体类型处理器 (Circle 与 *Circle 是两个不同的类型)
+//  3. 接口类型处理器，按注册顺序第一个被实现的胜出
+//  4. 非 nil 指针 *T 没有匹配时，解引用后再按 T 查找具体类型处理器
+//  5. Fallback 注册的兜底处理器
+//
+// Dispatcher 可以被多个 goroutine 同时使用。
+type Dispatcher[R any] struct {
+	mu       sync.RWMutex
+	exact    map[reflect.Type]func(any) R
+	ifaces   []ifaceHandler[R]
+	onNil    func() R
+	fallback func(any) R
+}
+
+// NewDispatcher 创建一个空的分派器
+func NewDispatcher[R any]() *Dispatcher[R] {
+	return &Dispatcher[R]{exact: map[reflect.Type]func(any) R{}}
+}
+
+// On 为类型 T 注册处理器。T 可以是具体类型、指针类型或接口类型，同一个类型只能注册一次。
+// Go 的方法不能有自己的类型参数，所以这里是一个普通函数而不是 Dispatcher 的方法。
+func On[T, R any](d *Dispatcher[R], fn func(T) R) error {
+	t := reflect.TypeFor[T]()
+	wrapped := func(v any) R { return fn(v.(T)) }
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t.Kind() == reflect.Interface {
+		for _, h := range d.ifaces {
+			if h.typ == t {
+				return fmt.Errorf("接口类型 %v 已经注册过处理器", t)
+			}
+		}
+		d.ifaces = append(d.ifaces, ifaceHandler[R]{typ: t, fn: wrapped})
+		return nil
+	}
+	if _, ok := d.exact[t]; ok {
+		return fmt.Errorf("类型 %v 已经注册过处理器", t)
+	}
+	d.exact[t] = wrapped
+	return nil
+}
+
+// OnNil 设置值为 nil 接口时的处理器
+func (d *Dispatcher[R]) OnNil(fn func() R) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onNil = fn
+}
+
+// Fallback 设置兜底处理器，没有其它处理器匹配时调用
+func (d *Dispatcher[R]) Fallback(fn func(any) R) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fallback = fn
+}
+
+// Dispatch 找到 v 对应的处理器并调用它。没有匹配的处理器时返回包装了 ErrNoHandler 的错误。
+func (d *Dispatcher[R]) Dispatch(v any) (R, error) {
+	fn, arg := d.lookup(v)
+	if fn == nil {
+		var zero R
+		return zero, fmt.Errorf("%w: %T", ErrNoHandler, v)
+	}
+	// 处理器在锁外调用，这样它内部也可以再调用 Dispatch 或注册新的处理器
+	return fn(arg), nil
+}
+
+// lookup 返回处理器以及应该传给它的参数 (解引用指针时参数会变成指向的值)
+func (d *Dispatcher[R]) lookup(v any) (func(any) R, any) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if v == nil {
+		if d.onNil == nil {
+			return d.fallback, nil
+		}
+		onNil := d.onNil
+		return func(any) R { return onNil() }, nil
+	}
+	t := reflect.TypeOf(v)
+	if fn, ok := d.exact[t]; ok {
+		return fn, v
+	}
+	for _, h := range d.ifaces {
+		if t.Implements(h.typ) {
+			return h.fn, v
+		}
+	}
+	if t.Kind() == reflect.Pointer {
+		if rv := reflect.ValueOf(v); !rv.IsNil() {
+			if fn, ok := d.exact[t.Elem()]; ok {
+				return fn, rv.Elem().Interface()
+			}
+		}
+	}
+	return d.fallback, v
+}
+
+// typeDescriber 是 switchType 中 type switch 的注册表版本，新类型可以随时用 On 注册自己的描述
+var typeDescriber = func() *Dispatcher[string] {
+	d := NewDispatcher[string]()
+	for _, err := range []error{
+		On(d, func(v int) string { return fmt.Sprintf("是 int 类型, 值为 %d", v) }),
+		On(d, func(v string) string { return fmt.Sprintf("是 string 类型, 值为 \"%s\"", v) }),
+		On(d, func(v Rectangle) string { return fmt.Sprintf("是 Rectangle 类型, 面积为 %.2f", v.Area()) }),
+		On(d, func(v Circle) string { return fmt.Sprintf("是 Circle 类型, 面积为 %.2f", v.Area()) }),
+	} {
+		if err != nil {
+			panic(err)
+		}
+	}
+	d.OnNil(func() string { return "是 nil" }) // 当接口变量本身为 nil 时
+	d.Fallback(func(v any) string { return fmt.Sprintf("是未知类型 %T", v) })
+	return d
+}()
+
+// checkType 打印值的类型描述。描述来自 typeDescriber 注册表，
+// 支持新类型时只需要用 On 注册处理器，不需要修改这个函数。
+func checkType(i any) {
+	fmt.Printf("  检查类型: 值=%v, ", i)
+	desc, _ := typeDescriber.Dispatch(i) // 设置了兜底处理器，不会返回错误
+	fmt.Println(desc)
+}
+
+func demoDispatch() {
+	fmt.Println("\n--- 9. 类型分派注册表 ---")
+	values := []any{123, Triangle{Base: 4, Height: 6}, &Rectangle{Width: 2, Height: 3}, &Square{Side: 3}, 3.14, nil}
+	fmt.Println("扩展前:")
+	for _, v := range values {
+		checkType(v) // *Rectangle 没有单独注册，解引用后使用 Rectangle 的处理器
+	}
+
+	// 为新类型注册处理器，checkType 本身不需要修改 (而 switchType 只能回到 switch 里加 case)
+	for _, err := range []error{
+		On(typeDescriber, func(v Triangle) string { return fmt.Sprintf("是 Triangle 类型, 面积为 %.2f", v.Area()) }),
+		On(typeDescriber, func(v float64) string { return fmt.Sprintf("是 float64 类型, 值为 %g", v) }),
+		// 接口类型的处理器匹配所有实现了该接口的类型，包括只有指针实现了接口的 *Square
+		On(typeDescriber, func(s Shape) string { return fmt.Sprintf("实现了 Shape (%T), 面积为 %.2f", s, s.Area()) }),
+	} {
+		if err != nil {
+			fmt.Println("注册失败:", err)
+		}
+	}
+	fmt.Println("扩展后:")
+	for _, v := range values {
+		checkType(v) // *Rectangle 实现了 Shape，接口处理器优先于解引用
+	}
+
+	// 重复注册会报错
+	err := On(typeDescriber, func(v int) string { return "" })
+	fmt.Println("重复注册:", err)
+
+	// 没有兜底处理器时，无法处理的值返回 ErrNoHandler
+	areas := NewDispatcher[float64]()
+	_ = On(areas, func(s Shape) float64 { return s.Area() })
+	if a, err := areas.Dispatch(Circle{Radius: 1}); err == nil {
+		fmt.Printf("面积分派: %.4f\n", a)
+	}
+	_, err = areas.Dispatch("不是形状")
+	fmt.Println("面积分派:", err, "| errors.Is(err, ErrNoHandler) =", errors.Is(err, ErrNoHandler))
+
+	// 没有 OnNil 时，nil 交给兜底处理器
+	areas.Fallback(func(any) float64 { return 0 })
+	a, err := areas.Dispatch(nil)
+	fmt.Println("面积分派 nil (只有兜底处理器):", a, err)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newTestDispatcher 为每一步查找规则注册一个处理器，返回值标明命中的是哪一个
+func newTestDispatcher(withNil, withFallback bool) *Dispatcher[string] {
+	d := NewDispatcher[string]()
+	for _, err := range []error{
+		On(d, func(r Rectangle) string { return fmt.Sprintf("Rectangle %g", r.Width) }),
+		On(d, func(c *Circle) string { return fmt.Sprintf("*Circle %g", c.Radius) }),
+		On(d, func(n int) string { return fmt.Sprintf("int %d", n) }),
+		On(d, func(s Shape) string { return fmt.Sprintf("Shape %T", s) }),
+		On(d, func(s fmt.Stringer) string { return "Stringer " + s.String() }),
+	} {
+		if err != nil {
+			panic(err)
+		}
+	}
+	if withNil {
+		d.OnNil(func() string { return "nil" })
+	}
+	if withFallback {
+		d.Fallback(func(v any) string { return fmt.Sprintf("fallback %T", v) })
+	}
+	return d
+}
+
+func TestDispatchLookupOrder(t *testing.T) {
+	n := 7
+	var nilInt *int
+	tests := []struct {
+		name                  string
+		withNil, withFallback bool
+		v                     any
+		want                  string
+		wantErr               error
+	}{
+		{"具体类型优先于接口", true, true, Rectangle{Width: 2}, "Rectangle 2", nil},
+		{"指针类型单独注册", true, true, &Circle{Radius: 3}, "*Circle 3", nil},
+		{"值类型没有注册时使用接口", true, true, Circle{Radius: 3}, "Shape main.Circle", nil},
+		{"多个接口按注册顺序", true, true, Triangle{Base: 1, Height: 2}, "Shape main.Triangle", nil},
+		{"只实现了后注册的接口", true, true, time.Second, "Stringer 1s", nil},
+		{"接口优先于解引用", true, true, &Rectangle{Width: 2}, "Shape *main.Rectangle", nil},
+		{"解引用后按具体类型查找", true, true, &n, "int 7", nil},
+		{"nil 指针不解引用", true, true, nilInt, "fallback *int", nil},
+		{"nil 接口", true, true, nil, "nil", nil},
+		{"没有 OnNil 时 nil 使用兜底处理器", false, true, nil, "fallback <nil>", nil},
+		{"兜底处理器", true, true, "text", "fallback string", nil},
+		{"没有兜底处理器", true, false, "text", "", ErrNoHandler},
+		{"nil 既没有 OnNil 也没有兜底处理器", false, false, nil, "", ErrNoHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestDispatcher(tt.withNil, tt.withFallback).Dispatch(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Dispatch(%#v) error = %v, want %v", tt.v, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Dispatch(%#v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnRejectsDuplicates(t *testing.T) {
+	d := newTestDispatcher(true, true)
+	if err := On(d, func(Rectangle) string { return "" }); err == nil {
+		t.Error("重复注册具体类型应该报错")
+	}
+	if err := On(d, func(Shape) string { return "" }); err == nil {
+		t.Error("重复注册接口类型应该报错")
+	}
+	// *Rectangle 与 Rectangle 是不同的类型，可以分别注册
+	if err := On(d, func(*Rectangle) string { return "*Rectangle" }); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := d.Dispatch(&Rectangle{}); got != "*Rectangle" {
+		t.Errorf("注册 *Rectangle 之后 Dispatch = %q, want 具体类型优先", got)
+	}
+}
+
+// checkType 只依赖注册表：为新类型注册处理器之后，不需要修改 checkType 就能识别它
+func TestCheckTypeUsesRegistry(t *testing.T) {
+	type point struct{ X, Y int }
+	if got, _ := typeDescriber.Dispatch(point{}); got != "是未知类型 main.point" {
+		t.Fatalf("注册之前 = %q", got)
+	}
+	if err := On(typeDescriber, func(p point) string { return fmt.Sprintf("是 point 类型 (%d, %d)", p.X, p.Y) }); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := typeDescriber.Dispatch(point{1, 2}); got != "是 point 类型 (1, 2)" {
+		t.Errorf("注册之后 = %q", got)
+	}
+}
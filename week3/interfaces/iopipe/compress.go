@@ -0,0 +1,38 @@
+package iopipe
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+)
+
+// 压缩和解压阶段。压缩器在 Close 时才会写出尾部 (包含校验和)，
+// 解压器在读到结尾时校验数据完整性，校验失败的错误从 Read 返回。
+// 它们都实现了 io.Closer，由 ChainReader / ChainWriter 返回值的 Close 负责关闭。
+
+// GzipCompress 返回 gzip 压缩阶段，level 使用 gzip.DefaultCompression 等常量
+func GzipCompress(level int) WriterStage {
+	return func(w Writer) (Writer, error) {
+		return gzip.NewWriterLevel(w, level)
+	}
+}
+
+// GzipDecompress 返回 gzip 解压阶段。创建时会读取 gzip 头部，数据不是 gzip 格式时返回 gzip.ErrHeader。
+func GzipDecompress() ReaderStage {
+	return func(r Reader) (Reader, error) {
+		return gzip.NewReader(r)
+	}
+}
+
+// ZlibCompress 返回 zlib 压缩阶段，level 使用 zlib.DefaultCompression 等常量
+func ZlibCompress(level int) WriterStage {
+	return func(w Writer) (Writer, error) {
+		return zlib.NewWriterLevel(w, level)
+	}
+}
+
+// ZlibDecompress 返回 zlib 解压阶段。创建时会读取 zlib 头部，数据不是 zlib 格式时返回 zlib.ErrHeader。
+func ZlibDecompress() ReaderStage {
+	return func(r Reader) (Reader, error) {
+		return zlib.NewReader(r)
+	}
+}
@@ -0,0 +1,149 @@
+package iopipe
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// CountingReader 统计经过它读取的字节数。
+// 计数使用原子操作，可以在另一个 goroutine 中随时调用 N 查看进度。
+type CountingReader struct {
+	r Reader
+	n atomic.Int64
+}
+
+// NewCountingReader 包装 r 并从 0 开始计数
+func NewCountingReader(r Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// N 返回到目前为止读取的字节数
+func (c *CountingReader) N() int64 { return c.n.Load() }
+
+// CountingWriter 统计成功写入底层 Writer 的字节数
+type CountingWriter struct {
+	w Writer
+	n atomic.Int64
+}
+
+// NewCountingWriter 包装 w 并从 0 开始计数
+func NewCountingWriter(w Writer) *CountingWriter {
+	return &CountingWriter{w: w}
+}
+
+func (c *CountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// N 返回到目前为止写入的字节数
+func (c *CountingWriter) N() int64 { return c.n.Load() }
+
+// Progress 是一次进度报告
+type Progress struct {
+	Done    int64         // 已经处理的字节数
+	Total   int64         // 总字节数，未知时为 -1
+	Elapsed time.Duration // 从第一次读写开始经过的时间
+	Final   bool          // 数据流已经结束 (读到 EOF 或出错)
+}
+
+// Percent 返回完成百分比，总量未知时返回 -1
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	return float64(p.Done) / float64(p.Total) * 100
+}
+
+// Rate 返回平均速度 (字节/秒)
+func (p Progress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Done) / p.Elapsed.Seconds()
+}
+
+// progress 是 ProgressReader 和 ProgressWriter 共用的报告逻辑：
+// 两次报告之间至少间隔 every，数据流结束时总会再报告一次。
+type progress struct {
+	total  int64
+	every  time.Duration
+	report func(Progress)
+
+	done  int64
+	start time.Time
+	last  time.Time
+	final bool
+	now   func() time.Time
+}
+
+func newProgress(total int64, every time.Duration, report func(Progress)) progress {
+	return progress{total: total, every: every, report: report, now: time.Now}
+}
+
+func (p *progress) add(n int, err error) {
+	if p.final {
+		return
+	}
+	now := p.now()
+	if p.start.IsZero() {
+		p.start = now
+	}
+	p.done += int64(n)
+	p.final = err != nil
+	if p.final || now.Sub(p.last) >= p.every {
+		p.last = now
+		p.report(Progress{Done: p.done, Total: p.total, Elapsed: now.Sub(p.start), Final: p.final})
+	}
+}
+
+// ProgressReader 在读取过程中定期调用回调函数报告进度
+type ProgressReader struct {
+	r Reader
+	p progress
+}
+
+// NewProgressReader 创建报告进度的 Reader。total 是预期的总字节数 (未知时传 -1)，
+// every 是两次报告之间的最小间隔，传 0 表示每次 Read 都报告。
+// 回调在调用 Read 的 goroutine 中同步执行，不应该做耗时的操作。
+func NewProgressReader(r Reader, total int64, every time.Duration, report func(Progress)) *ProgressReader {
+	return &ProgressReader{r: r, p: newProgress(total, every, report)}
+}
+
+func (pr *ProgressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.p.add(n, err)
+	return n, err
+}
+
+// ProgressWriter 在写入过程中定期调用回调函数报告进度。
+// 写入没有天然的结束标志，所以最后一次报告 (Final 为 true) 在 Close 时发出。
+type ProgressWriter struct {
+	w Writer
+	p progress
+}
+
+// NewProgressWriter 创建报告进度的 Writer，参数含义与 NewProgressReader 相同
+func NewProgressWriter(w Writer, total int64, every time.Duration, report func(Progress)) *ProgressWriter {
+	return &ProgressWriter{w: w, p: newProgress(total, every, report)}
+}
+
+func (pw *ProgressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.p.add(n, err)
+	return n, err
+}
+
+// Close 发出最后一次进度报告，不会关闭底层 Writer
+func (pw *ProgressWriter) Close() error {
+	pw.p.add(0, io.EOF)
+	return nil
+}
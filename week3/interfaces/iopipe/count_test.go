@@ -0,0 +1,83 @@
+package iopipe
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tickReader 每次 Read 之前把假时钟拨快 step，模拟耗时的读取
+type tickReader struct {
+	r     Reader
+	clock *fakeClock
+	step  time.Duration
+}
+
+func (tr *tickReader) Read(p []byte) (int, error) {
+	tr.clock.t = tr.clock.t.Add(tr.step)
+	return tr.r.Read(p)
+}
+
+func TestProgressReader(t *testing.T) {
+	clock := newFakeClock()
+	var reports []Progress
+	src := &tickReader{r: strings.NewReader(strings.Repeat("x", 100)), clock: clock, step: 300 * time.Millisecond}
+	pr := NewProgressReader(src, 100, time.Second, func(p Progress) { reports = append(reports, p) })
+	pr.p.now = clock.now
+
+	buf := make([]byte, 10)
+	for {
+		if _, err := pr.Read(buf); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 每 300ms 读 10 字节：第一次读取立即报告，之后至少间隔 1 秒，读到 EOF 时再报告一次
+	want := []Progress{
+		{Done: 10, Total: 100, Elapsed: 0},
+		{Done: 50, Total: 100, Elapsed: 1200 * time.Millisecond},
+		{Done: 90, Total: 100, Elapsed: 2400 * time.Millisecond},
+		{Done: 100, Total: 100, Elapsed: 3000 * time.Millisecond, Final: true},
+	}
+	if len(reports) != len(want) {
+		t.Fatalf("报告了 %d 次: %+v; want %d 次", len(reports), reports, len(want))
+	}
+	for i := range want {
+		if reports[i] != want[i] {
+			t.Errorf("第 %d 次报告 = %+v, want %+v", i, reports[i], want[i])
+		}
+	}
+	last := reports[len(reports)-1]
+	if last.Percent() != 100 || last.Rate() != 100.0/3 {
+		t.Errorf("Percent = %v, Rate = %v; want 100, %v", last.Percent(), last.Rate(), 100.0/3)
+	}
+}
+
+func TestProgressWriterClose(t *testing.T) {
+	clock := newFakeClock()
+	var reports []Progress
+	pw := NewProgressWriter(io.Discard, -1, time.Minute, func(p Progress) { reports = append(reports, p) })
+	pw.p.now = clock.now
+
+	for range 3 {
+		clock.t = clock.t.Add(time.Second)
+		if _, err := pw.Write([]byte("abcd")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	pw.Close() // 重复 Close 不会再次报告
+
+	if len(reports) != 2 || reports[0].Done != 4 {
+		t.Fatalf("报告 = %+v; want 第一次写入和 Close 各一次", reports)
+	}
+	final := reports[1]
+	if !final.Final || final.Done != 12 || final.Elapsed != 2*time.Second || final.Percent() != -1 {
+		t.Errorf("最后一次报告 = %+v, want Done=12 Elapsed=2s Final=true, 总量未知", final)
+	}
+}
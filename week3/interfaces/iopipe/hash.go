@@ -0,0 +1,54 @@
+package iopipe
+
+import (
+	"encoding/hex"
+	"hash"
+)
+
+// HashingReader 像 io.TeeReader 一样，把读到的每个字节同时送进哈希函数，
+// 读完之后就得到了整个数据流的摘要，不需要把数据再读一遍。
+type HashingReader struct {
+	r Reader
+	h hash.Hash
+}
+
+// NewHashingReader 创建计算 h 摘要的 Reader，例如 NewHashingReader(f, sha256.New())
+func NewHashingReader(r Reader, h hash.Hash) *HashingReader {
+	return &HashingReader{r: r, h: h}
+}
+
+func (hr *HashingReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n]) // hash.Hash 的 Write 从不返回错误
+	return n, err
+}
+
+// Sum 返回到目前为止读到的数据的摘要
+func (hr *HashingReader) Sum() []byte { return hr.h.Sum(nil) }
+
+// HexSum 返回十六进制形式的摘要
+func (hr *HashingReader) HexSum() string { return hex.EncodeToString(hr.Sum()) }
+
+// HashingWriter 把写入的数据同时送进底层 Writer 和哈希函数。
+// 只有底层 Writer 实际接受的那部分数据会被计入摘要。
+type HashingWriter struct {
+	w Writer
+	h hash.Hash
+}
+
+// NewHashingWriter 创建计算 h 摘要的 Writer
+func NewHashingWriter(w Writer, h hash.Hash) *HashingWriter {
+	return &HashingWriter{w: w, h: h}
+}
+
+func (hw *HashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.h.Write(p[:n])
+	return n, err
+}
+
+// Sum 返回到目前为止写入的数据的摘要
+func (hw *HashingWriter) Sum() []byte { return hw.h.Sum(nil) }
+
+// HexSum 返回十六进制形式的摘要
+func (hw *HashingWriter) HexSum() string { return hex.EncodeToString(hw.Sum()) }
@@ -0,0 +1,151 @@
+// Package iopipe 是一组可组合的数据流适配器，全部建立在 Reader / Writer 这两个最小接口之上。
+//
+// week3/interfaces 中声明的 Reader、Writer、ReadWriter 与标准库 io 包中的同名接口方法集完全相同，
+// 根据 Go 接口的隐式实现规则，它们可以互相赋值：*os.File、*bytes.Buffer、net.Conn 等
+// 标准库类型都可以直接传给本包，本包的适配器也可以直接传给 io.Copy 等标准库函数。
+//
+// 每个适配器都包装另一个 Reader 或 Writer，只做一件事 (限速、计数、报告进度、计算哈希、压缩……)，
+// 再用 ChainReader / ChainWriter 把它们串成流水线，并统一处理关闭和错误。
+//
+// 适配器从不关闭它包装的 Reader / Writer：关闭的职责交给流水线，
+// 流水线只关闭它自己创建的阶段 (例如 gzip 压缩器)，数据源和最终目的地仍然由调用方负责。
+package iopipe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Reader 与 io.Reader 相同
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
+
+// Writer 与 io.Writer 相同
+type Writer interface {
+	Write(p []byte) (n int, err error)
+}
+
+// ReadWriter 通过嵌入组合了 Reader 和 Writer
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+// ReadCloser 是可以关闭的 Reader，Close 会释放流水线中各个阶段持有的资源
+type ReadCloser interface {
+	Reader
+	io.Closer
+}
+
+// WriteCloser 是可以关闭的 Writer。对于压缩等带缓冲的阶段，
+// 只有调用 Close 才会把剩余数据写到底层 Writer，所以写完后一定要检查 Close 的错误。
+type WriteCloser interface {
+	Writer
+	io.Closer
+}
+
+// 方法集相同的接口可以互相赋值
+var (
+	_ io.Reader = Reader(nil)
+	_ Reader    = io.Reader(nil)
+	_ io.Writer = Writer(nil)
+	_ Writer    = io.Writer(nil)
+)
+
+// ReaderStage 包装一个 Reader，返回流水线的下一个阶段
+type ReaderStage func(Reader) (Reader, error)
+
+// WriterStage 包装一个 Writer，返回流水线的上一个阶段 (数据先写入返回值，再流向参数)
+type WriterStage func(Writer) (Writer, error)
+
+// WrapReader 把不会失败的 Reader 构造函数转换为 ReaderStage
+func WrapReader(f func(Reader) Reader) ReaderStage {
+	return func(r Reader) (Reader, error) { return f(r), nil }
+}
+
+// WrapWriter 把不会失败的 Writer 构造函数转换为 WriterStage
+func WrapWriter(f func(Writer) Writer) WriterStage {
+	return func(w Writer) (Writer, error) { return f(w), nil }
+}
+
+// chain 是流水线的最外层，closers 按照数据流动的方向排列 (先关闭靠近调用方的阶段)
+type chain struct {
+	closers []io.Closer
+}
+
+// Close 依次关闭所有阶段。某个阶段出错不会影响后面的阶段关闭，所有错误用 errors.Join 合并返回。
+func (c *chain) Close() error {
+	var errs []error
+	for _, cl := range c.closers {
+		if err := cl.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.closers = nil // 重复调用 Close 是安全的
+	return errors.Join(errs...)
+}
+
+type readChain struct {
+	Reader
+	chain
+}
+
+type writeChain struct {
+	Writer
+	chain
+}
+
+// ChainReader 从 src 开始依次套上 stages，返回最后一个阶段。读取时数据的流向是
+// src -> stages[0] -> stages[1] -> ... -> 调用方。
+//
+// 返回值的 Close 会按从外到内的顺序关闭 stages 返回的、实现了 io.Closer 的阶段，但不会关闭 src
+// (src 由调用方负责，例如 os.Stdin 通常不应该被关闭)。
+// 某个阶段创建失败时，已经创建的阶段会被关闭，错误中注明是第几个阶段。
+func ChainReader(src Reader, stages ...ReaderStage) (ReadCloser, error) {
+	r := src
+	var created []io.Closer
+	for i, stage := range stages {
+		next, err := stage(r)
+		if err != nil {
+			c := chain{closers: reversed(created)}
+			return nil, errors.Join(fmt.Errorf("创建第 %d 个读取阶段失败: %w", i, err), c.Close())
+		}
+		if cl, ok := next.(io.Closer); ok {
+			created = append(created, cl)
+		}
+		r = next
+	}
+	return &readChain{Reader: r, chain: chain{closers: reversed(created)}}, nil
+}
+
+// ChainWriter 在 dst 前面依次套上 stages，返回第一个阶段。写入时数据的流向是
+// 调用方 -> stages[0] -> stages[1] -> ... -> dst。
+//
+// 返回值的 Close 按数据流动的方向关闭各个阶段，保证每个阶段在关闭时缓冲的数据
+// 都能写入仍然打开的下一个阶段；dst 本身不会被关闭。
+func ChainWriter(dst Writer, stages ...WriterStage) (WriteCloser, error) {
+	w := dst
+	var created []io.Closer // 从靠近 dst 的阶段开始创建
+	for i := len(stages) - 1; i >= 0; i-- {
+		next, err := stages[i](w)
+		if err != nil {
+			c := chain{closers: reversed(created)}
+			return nil, errors.Join(fmt.Errorf("创建第 %d 个写入阶段失败: %w", i, err), c.Close())
+		}
+		if cl, ok := next.(io.Closer); ok {
+			created = append(created, cl)
+		}
+		w = next
+	}
+	return &writeChain{Writer: w, chain: chain{closers: reversed(created)}}, nil
+}
+
+func reversed(cs []io.Closer) []io.Closer {
+	out := make([]io.Closer, len(cs))
+	for i, c := range cs {
+		out[len(cs)-1-i] = c
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package iopipe
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ErrLineTooLong 表示某一行超过了 LineReader 允许的最大长度
+var ErrLineTooLong = errors.New("行太长")
+
+// DefaultMaxLine 是 LineReader 默认允许的最大行长度 (字节)
+const DefaultMaxLine = 1 << 20
+
+// LineReader 把数据流切分成行。行尾的 "\n" 或 "\r\n" 会被去掉，
+// 最后一行即使没有换行符也会被返回。
+//
+// 与 bufio.Scanner 相比，超长的行会返回带行号的 ErrLineTooLong，
+// 而且可以用 Lines 在 for range 中逐行遍历。
+type LineReader struct {
+	br      *bufio.Reader
+	maxLine int
+	lineNo  int
+	err     error
+}
+
+// NewLineReader 创建 LineReader，maxLine <= 0 时使用 DefaultMaxLine
+func NewLineReader(r Reader, maxLine int) *LineReader {
+	if maxLine <= 0 {
+		maxLine = DefaultMaxLine
+	}
+	return &LineReader{br: bufio.NewReader(r), maxLine: maxLine}
+}
+
+// ReadLine 返回下一行 (不含换行符)。数据读完后返回 io.EOF；
+// 出错后会一直返回同一个错误。
+func (lr *LineReader) ReadLine() (string, error) {
+	if lr.err != nil {
+		return "", lr.err
+	}
+	var line []byte
+	for {
+		chunk, err := lr.br.ReadSlice('\n')
+		if len(line)+len(chunk) > lr.maxLine+2 { // 留出 "\r\n" 的位置
+			lr.err = fmt.Errorf("第 %d 行: %w (超过 %d 字节)", lr.lineNo+1, ErrLineTooLong, lr.maxLine)
+			return "", lr.err
+		}
+		line = append(line, chunk...)
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue // 行比缓冲区长，继续读
+		}
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			lr.err = err
+			return "", err
+		}
+		break
+	}
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) > lr.maxLine {
+		lr.err = fmt.Errorf("第 %d 行: %w (超过 %d 字节)", lr.lineNo+1, ErrLineTooLong, lr.maxLine)
+		return "", lr.err
+	}
+	lr.lineNo++
+	return string(line), nil
+}
+
+// LineNo 返回最近一次成功读取的行号，从 1 开始
+func (lr *LineReader) LineNo() int { return lr.lineNo }
+
+// Lines 返回逐行遍历的迭代器，键是行号。遍历在数据结束或出错时停止，
+// 遍历结束后应该调用 Err 检查是否出错。
+func (lr *LineReader) Lines() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for {
+			line, err := lr.ReadLine()
+			if err != nil {
+				return
+			}
+			if !yield(lr.lineNo, line) {
+				return
+			}
+		}
+	}
+}
+
+// Err 返回读取过程中遇到的第一个错误，正常读到结尾时返回 nil
+func (lr *LineReader) Err() error {
+	if lr.err == io.EOF {
+		return nil
+	}
+	return lr.err
+}
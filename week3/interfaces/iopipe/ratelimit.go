@@ -0,0 +1,105 @@
+package iopipe
+
+import "time"
+
+// limiter 是一个令牌桶：每秒补充 rate 个令牌 (1 个令牌 = 1 字节)，最多积攒 burst 个。
+// 令牌不足时允许先 "透支"，再睡眠到欠账还清为止，这样单次读写的大小不受令牌数量限制。
+type limiter struct {
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+
+	// 测试时可以替换为假时钟
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+func newLimiter(bytesPerSec int) *limiter {
+	if bytesPerSec <= 0 {
+		return nil // 不限速
+	}
+	// 突发量取 1/10 秒的流量，让数据比较均匀地流出，而不是每秒一次性涌出
+	burst := max(bytesPerSec/10, 1)
+	return &limiter{
+		rate:   float64(bytesPerSec),
+		burst:  burst,
+		tokens: float64(burst),
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+}
+
+// wait 消耗 n 个令牌，必要时睡眠
+func (l *limiter) wait(n int) {
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		l.tokens = min(l.tokens, float64(l.burst))
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens < 0 {
+		d := time.Duration(-l.tokens / l.rate * float64(time.Second))
+		l.sleep(d)
+		// 睡眠期间补充的令牌正好还清欠账
+		l.tokens = 0
+		l.last = now.Add(d)
+	}
+}
+
+// RateLimitedReader 把读取速度限制在每秒 bytesPerSec 字节以内
+type RateLimitedReader struct {
+	r Reader
+	l *limiter
+}
+
+// NewRateLimitedReader 创建限速 Reader，bytesPerSec <= 0 表示不限速
+func NewRateLimitedReader(r Reader, bytesPerSec int) *RateLimitedReader {
+	return &RateLimitedReader{r: r, l: newLimiter(bytesPerSec)}
+}
+
+// Read 每次最多读取一个突发量的数据，读到之后再按实际字节数等待
+func (rl *RateLimitedReader) Read(p []byte) (int, error) {
+	if rl.l == nil {
+		return rl.r.Read(p)
+	}
+	if len(p) > rl.l.burst {
+		p = p[:rl.l.burst]
+	}
+	n, err := rl.r.Read(p)
+	if n > 0 {
+		rl.l.wait(n)
+	}
+	return n, err
+}
+
+// RateLimitedWriter 把写入速度限制在每秒 bytesPerSec 字节以内
+type RateLimitedWriter struct {
+	w Writer
+	l *limiter
+}
+
+// NewRateLimitedWriter 创建限速 Writer，bytesPerSec <= 0 表示不限速
+func NewRateLimitedWriter(w Writer, bytesPerSec int) *RateLimitedWriter {
+	return &RateLimitedWriter{w: w, l: newLimiter(bytesPerSec)}
+}
+
+// Write 把 p 切成不超过突发量的小块，每块写入前先等待令牌
+func (rl *RateLimitedWriter) Write(p []byte) (int, error) {
+	if rl.l == nil {
+		return rl.w.Write(p)
+	}
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), rl.l.burst)]
+		rl.l.wait(len(chunk))
+		n, err := rl.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
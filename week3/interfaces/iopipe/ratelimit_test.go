@@ -0,0 +1,114 @@
+package iopipe
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock 是假时钟：sleep 不真正睡眠，只把时间往前拨
+type fakeClock struct {
+	t     time.Time
+	slept time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.t = c.t.Add(d)
+	c.slept += d
+}
+
+func (c *fakeClock) install(l *limiter) {
+	l.now, l.sleep = c.now, c.sleep
+}
+
+// chunkRecorder 记录每次 Write 的大小
+type chunkRecorder struct {
+	bytes.Buffer
+	chunks []int
+}
+
+func (w *chunkRecorder) Write(p []byte) (int, error) {
+	w.chunks = append(w.chunks, len(p))
+	return w.Buffer.Write(p)
+}
+
+func TestRateLimitedWriter(t *testing.T) {
+	clock := newFakeClock()
+	var dst chunkRecorder
+	w := NewRateLimitedWriter(&dst, 1000) // 突发量为 100 字节
+	clock.install(w.l)
+
+	data := strings.Repeat("x", 1000)
+	if n, err := w.Write([]byte(data)); n != len(data) || err != nil {
+		t.Fatalf("Write = %d, %v; want %d, nil", n, err, len(data))
+	}
+	if dst.String() != data {
+		t.Error("写入的数据与输入不一致")
+	}
+	for _, c := range dst.chunks {
+		if c > 100 {
+			t.Fatalf("单次写入 %d 字节, 超过了突发量 100", c)
+		}
+	}
+	// 初始的 100 个令牌可以立即使用，剩下 900 字节按每秒 1000 字节需要 0.9 秒
+	if want := 900 * time.Millisecond; clock.slept != want {
+		t.Errorf("共睡眠 %v, want %v", clock.slept, want)
+	}
+
+	// 空闲很久之后积攒的令牌不超过突发量
+	clock.t = clock.t.Add(time.Hour)
+	before := clock.slept
+	if _, err := w.Write([]byte(strings.Repeat("y", 300))); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := clock.slept-before, 200*time.Millisecond; got != want {
+		t.Errorf("空闲后写入 300 字节睡眠了 %v, want %v", got, want)
+	}
+}
+
+func TestRateLimitedReader(t *testing.T) {
+	clock := newFakeClock()
+	r := NewRateLimitedReader(strings.NewReader(strings.Repeat("x", 500)), 100) // 突发量为 10 字节
+	clock.install(r.l)
+
+	buf := make([]byte, 64)
+	total := 0
+	for {
+		n, err := r.Read(buf)
+		if n > 10 {
+			t.Fatalf("单次读取 %d 字节, 超过了突发量 10", n)
+		}
+		total += n
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if total != 500 {
+		t.Errorf("共读取 %d 字节, want 500", total)
+	}
+	if want := 4900 * time.Millisecond; clock.slept != want {
+		t.Errorf("共睡眠 %v, want %v", clock.slept, want)
+	}
+}
+
+func TestRateLimitUnlimited(t *testing.T) {
+	var dst bytes.Buffer
+	w := NewRateLimitedWriter(&dst, 0)
+	if w.l != nil {
+		t.Fatal("bytesPerSec <= 0 时不应该创建令牌桶")
+	}
+	if _, err := w.Write([]byte("hello")); err != nil || dst.String() != "hello" {
+		t.Errorf("不限速写入 = %q, %v", dst.String(), err)
+	}
+}
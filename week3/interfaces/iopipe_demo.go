@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Mag1cFall/go-get-started/week3/interfaces/iopipe"
+)
+
+// --- 8. 用 Reader / Writer 组合数据流 ---
+// 本文件末尾声明的 Reader、Writer 只有一个方法，正因为要求少，几乎什么都能实现它们。
+// iopipe 包把限速、计数、哈希、压缩等功能各自做成一个小的包装器，再像水管一样接起来。
+
+func demoPipeline() {
+	fmt.Println("\n--- 8. 用 Reader / Writer 组合数据流 ---")
+	text := strings.Repeat("Go 的接口是隐式实现的。\n", 200)
+
+	// 写入方向: 计算原文哈希 -> gzip 压缩 -> 统计压缩后的字节数 -> buf
+	var buf bytes.Buffer
+	var sum *iopipe.HashingWriter
+	var packed *iopipe.CountingWriter
+	w, err := iopipe.ChainWriter(&buf,
+		iopipe.WrapWriter(func(w iopipe.Writer) iopipe.Writer {
+			sum = iopipe.NewHashingWriter(w, sha256.New())
+			return sum
+		}),
+		iopipe.GzipCompress(gzip.BestCompression),
+		iopipe.WrapWriter(func(w iopipe.Writer) iopipe.Writer {
+			packed = iopipe.NewCountingWriter(w)
+			return packed
+		}),
+	)
+	if err != nil {
+		fmt.Println("创建流水线失败:", err)
+		return
+	}
+	var dst Writer = w // 流水线同样满足本文件中的 Writer 接口
+	if _, err := io.Copy(dst, strings.NewReader(text)); err != nil {
+		fmt.Println("写入失败:", err)
+	}
+	if err := w.Close(); err != nil { // 关闭时 gzip 才写出尾部
+		fmt.Println("关闭失败:", err)
+		return
+	}
+	fmt.Printf("原文 %d 字节, 压缩后 %d 字节, SHA-256 %s...\n", len(text), packed.N(), sum.HexSum()[:16])
+
+	// 读取方向: buf -> gzip 解压 -> 限速 -> 按行切分
+	r, err := iopipe.ChainReader(&buf,
+		iopipe.GzipDecompress(),
+		iopipe.WrapReader(func(r iopipe.Reader) iopipe.Reader { return iopipe.NewRateLimitedReader(r, 64<<10) }),
+	)
+	if err != nil {
+		fmt.Println("创建流水线失败:", err)
+		return
+	}
+	defer r.Close()
+	lines := iopipe.NewLineReader(r, 0)
+	count := 0
+	for n, line := range lines.Lines() {
+		if n == 1 {
+			fmt.Println("第一行:", line)
+		}
+		count++
+	}
+	if err := lines.Err(); err != nil {
+		fmt.Println("读取失败:", err)
+		return
+	}
+	fmt.Println("解压后共", count, "行")
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// --- 7. 接口值的 JSON 编解码 ---
+// json.Marshal 编码接口值时只会写出具体类型的字段，类型信息丢失了；
+// 解码时 encoding/json 也不知道该为 Shape 创建哪一种具体类型。
+// 常见的解决办法是在 JSON 中加一个类型标签，例如 {"type":"circle","Radius":7}，
+// 再用一个 "标签 <-> 具体类型" 的注册表来完成双向映射 (encoding/gob 的 Register 也是类似的思路)。
+
+var (
+	// ErrUnknownShapeTag 表示 JSON 中的类型标签没有注册
+	ErrUnknownShapeTag = errors.New("未知的形状类型标签")
+	// ErrNilShape 表示 ShapeList 中有 nil 形状 (包括值为 nil 的指针，如 (*Square)(nil))
+	ErrNilShape = errors.New("形状为 nil")
+)
+
+// ShapeRegistry 记录类型标签与具体类型之间的对应关系，可以安全地被多个 goroutine 同时使用。
+type ShapeRegistry struct {
+	mu     sync.RWMutex
+	byTag  map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+// NewShapeRegistry 创建一个空的注册表
+func NewShapeRegistry() *ShapeRegistry {
+	return &ShapeRegistry{byTag: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+}
+
+// DefaultShapes 是预先注册了 Rectangle、Circle、Triangle 的默认注册表，ShapeList 使用它编解码。
+var DefaultShapes = func() *ShapeRegistry {
+	r := NewShapeRegistry()
+	for tag, sample := range map[string]Shape{"rectangle": Rectangle{}, "circle": Circle{}, "triangle": Triangle{}} {
+		if err := r.Register(tag, sample); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}()
+
+// Register 把 sample 的具体类型注册到 tag 下。
+// 值类型和指针类型 (如 Circle 与 *Circle) 是不同的类型，需要分别注册；解码时会还原为注册时的那一种。
+// 同一个标签或同一个类型重复注册会返回错误。
+func (r *ShapeRegistry) Register(tag string, sample Shape) error {
+	if tag == "" {
+		return errors.New("类型标签不能为空")
+	}
+	if sample == nil {
+		return errors.New("不能注册 nil")
+	}
+	t := reflect.TypeOf(sample)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.byTag[tag]; ok {
+		return fmt.Errorf("类型标签 %q 已经注册给了 %v", tag, old)
+	}
+	if old, ok := r.byType[t]; ok {
+		return fmt.Errorf("类型 %v 已经以标签 %q 注册", t, old)
+	}
+	r.byTag[tag] = t
+	r.byType[t] = tag
+	return nil
+}
+
+// Marshal 把形状编码为带 "type" 标签的 JSON 对象，标签放在最前面。
+// 形状本身必须编码为 JSON 对象，并且不能有名为 "type" 的字段。
+func (r *ShapeRegistry) Marshal(s Shape) ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	r.mu.RLock()
+	tag, ok := r.byType[reflect.TypeOf(s)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("类型 %T 没有注册", s)
+	}
+	body, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	// 值为 nil 的指针会编码为 null，它能解码到 map 中 (得到 nil map)，但不是 JSON 对象
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("类型 %T 没有编码为 JSON 对象: %s", s, body)
+	}
+	if _, clash := fields["type"]; clash {
+		return nil, fmt.Errorf("类型 %T 的 JSON 中已经有 \"type\" 字段", s)
+	}
+	tagJSON, _ := json.Marshal(tag)
+	var buf bytes.Buffer
+	buf.WriteString(`{"type":`)
+	buf.Write(tagJSON)
+	// body 形如 {...}，去掉开头的 '{' 后拼接到标签后面
+	if rest := bytes.TrimSpace(body[1:]); !bytes.Equal(rest, []byte("}")) {
+		buf.WriteByte(',')
+		buf.Write(rest)
+	} else {
+		buf.WriteByte('}')
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal 根据 "type" 标签创建对应的具体类型并解码其余字段。
+// 标签缺失或没有注册时返回包装了 ErrUnknownShapeTag 的错误。
+func (r *ShapeRegistry) Unmarshal(data []byte) (Shape, error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+	var head struct {
+		Type *string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	if head.Type == nil {
+		return nil, fmt.Errorf("%w: 缺少 \"type\" 字段", ErrUnknownShapeTag)
+	}
+	r.mu.RLock()
+	t, ok := r.byTag[*head.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownShapeTag, *head.Type)
+	}
+
+	// 注册的是指针类型 (如 *Square) 时创建它指向的值，最后直接返回指针
+	isPtr := t.Kind() == reflect.Pointer
+	elem := t
+	if isPtr {
+		elem = t.Elem()
+	}
+	v := reflect.New(elem)
+	if err := json.Unmarshal(data, v.Interface()); err != nil { // "type" 字段不属于结构体，会被忽略
+		return nil, fmt.Errorf("解码 %q 失败: %w", *head.Type, err)
+	}
+	if isPtr {
+		return v.Interface().(Shape), nil
+	}
+	return v.Elem().Interface().(Shape), nil
+}
+
+// ShapeList 是可以直接用 json.Marshal / json.Unmarshal 处理的 []Shape，使用 DefaultShapes 注册表。
+type ShapeList []Shape
+
+// MarshalJSON 实现 json.Marshaler。列表中不能有 nil 形状，否则返回包装了 ErrNilShape 的错误。
+func (l ShapeList) MarshalJSON() ([]byte, error) {
+	items := make([]json.RawMessage, len(l))
+	for i, s := range l {
+		if isNilShape(s) {
+			return nil, fmt.Errorf("第 %d 个形状: %w", i, ErrNilShape)
+		}
+		b, err := DefaultShapes.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 个形状: %w", i, err)
+		}
+		items[i] = b
+	}
+	return json.Marshal(items)
+}
+
+// isNilShape 判断 s 是 nil 接口，或者装着值为 nil 的指针
+func isNilShape(s Shape) bool {
+	if s == nil {
+		return true
+	}
+	v := reflect.ValueOf(s)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler。与 MarshalJSON 对称，null 元素返回包装了 ErrNilShape 的错误。
+func (l *ShapeList) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	out := make(ShapeList, len(items))
+	for i, item := range items {
+		s, err := DefaultShapes.Unmarshal(item)
+		if err == nil && s == nil {
+			err = ErrNilShape
+		}
+		if err != nil {
+			return fmt.Errorf("第 %d 个形状: %w", i, err)
+		}
+		out[i] = s
+	}
+	*l = out
+	return nil
+}
+
+// Square 是一个 "用户自定义" 的形状，用来演示注册新类型。
+// 它的 Area 方法使用指针接收者，所以只有 *Square 实现了 Shape。
+type Square struct {
+	Side float64
+}
+
+func (s *Square) Area() float64 { return s.Side * s.Side }
+
+func demoShapeJSON() {
+	fmt.Println("\n--- 7. 接口值的 JSON 编解码 ---")
+	shapes := ShapeList{Rectangle{Width: 10, Height: 5}, Circle{Radius: 7}, Triangle{Base: 4, Height: 6}}
+	data, err := json.Marshal(shapes)
+	if err != nil {
+		fmt.Println("编码失败:", err)
+		return
+	}
+	fmt.Println("编码结果:", string(data))
+
+	var decoded ShapeList
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		fmt.Println("解码失败:", err)
+		return
+	}
+	for _, s := range decoded {
+		fmt.Printf("  解码得到 %T, 面积 %.2f\n", s, s.Area())
+	}
+
+	// 注册自定义类型后即可参与编解码
+	if err := DefaultShapes.Register("square", &Square{}); err != nil {
+		fmt.Println("注册失败:", err)
+	}
+	data, _ = json.Marshal(ShapeList{&Square{Side: 3}})
+	fmt.Println("自定义类型编码结果:", string(data))
+	if err := json.Unmarshal(data, &decoded); err == nil {
+		fmt.Printf("  解码得到 %T, 面积 %.2f\n", decoded[0], decoded[0].Area())
+	}
+
+	// 未注册的标签会返回 ErrUnknownShapeTag
+	err = json.Unmarshal([]byte(`[{"type":"hexagon","Side":1}]`), &decoded)
+	fmt.Println("未知标签:", err, "| errors.Is(err, ErrUnknownShapeTag) =", errors.Is(err, ErrUnknownShapeTag))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShapeListRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ShapeList
+		want string
+	}{
+		{"空列表", ShapeList{}, `[]`},
+		{"默认注册的形状",
+			ShapeList{Rectangle{Width: 10, Height: 5}, Circle{Radius: 7}, Triangle{Base: 4, Height: 6}},
+			`[{"type":"rectangle","Width":10,"Height":5},{"type":"circle","Radius":7},{"type":"triangle","Base":4,"Height":6}]`},
+		{"零值", ShapeList{Circle{}}, `[{"type":"circle","Radius":0}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+			var out ShapeList
+			if err := json.Unmarshal(data, &out); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(out, tt.in) {
+				t.Errorf("Unmarshal = %#v, want %#v", out, tt.in)
+			}
+		})
+	}
+}
+
+// nil 接口和值为 nil 的指针都不能编码，否则会得到 {"type":"square",ull 这样的非法 JSON
+func TestShapeListRejectsNil(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ShapeList
+	}{
+		{"nil 接口", ShapeList{Circle{Radius: 1}, nil}},
+		{"值为 nil 的指针", ShapeList{(*Square)(nil)}},
+		{"值为 nil 的已知类型指针", ShapeList{(*Circle)(nil)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.in)
+			if !errors.Is(err, ErrNilShape) {
+				t.Errorf("Marshal = %s, %v; want ErrNilShape", data, err)
+			}
+		})
+	}
+
+	var out ShapeList
+	if err := json.Unmarshal([]byte(`[{"type":"circle","Radius":1},null]`), &out); !errors.Is(err, ErrNilShape) {
+		t.Errorf("Unmarshal(null 元素) 的错误 = %v, want ErrNilShape", err)
+	}
+	if err := json.Unmarshal([]byte(`[{"type":"hexagon"}]`), &out); !errors.Is(err, ErrUnknownShapeTag) {
+		t.Errorf("Unmarshal(未知标签) 的错误 = %v, want ErrUnknownShapeTag", err)
+	}
+}
+
+func TestRegistryPointerTypes(t *testing.T) {
+	r := NewShapeRegistry()
+	if err := r.Register("square", &Square{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := r.Marshal(&Square{Side: 3})
+	if err != nil || string(data) != `{"type":"square","Side":3}` {
+		t.Fatalf("Marshal(&Square) = %s, %v", data, err)
+	}
+	s, err := r.Unmarshal(data)
+	if sq, ok := s.(*Square); err != nil || !ok || sq.Side != 3 {
+		t.Errorf("Unmarshal = %#v, %v; want &Square{Side: 3}", s, err)
+	}
+	// 注册表本身也不能把值为 nil 的指针编码成残缺的 JSON
+	if data, err := r.Marshal((*Square)(nil)); err == nil || !strings.Contains(err.Error(), "JSON 对象") {
+		t.Errorf("Marshal((*Square)(nil)) = %s, %v; want 错误", data, err)
+	}
+	if data, err := r.Marshal(nil); err != nil || string(data) != "null" {
+		t.Errorf("Marshal(nil) = %s, %v; want null", data, err)
+	}
+}
@@ -165,13 +165,14 @@ func main() {
 	// --- 6. Type Switch (类型选择) ---
 	// Type Switch 是一种更优雅地处理多种可能的具体类型的方式。
 	// 语法类似普通的 switch 语句，但在 case 中使用类型。
+	// (类型集合需要由使用者扩展时，改用第 9 节的类型分派注册表，见 checkType)
 	fmt.Println("\n--- 6. Type Switch ---")
-	checkType(123)
-	checkType("Go Language")
-	checkType(Rectangle{Width: 2, Height: 3})
-	checkType(Circle{Radius: 1.5})
-	checkType(3.14)
-	checkType(nil) // 注意 nil 的情况
+	switchType(123)
+	switchType("Go Language")
+	switchType(Rectangle{Width: 2, Height: 3})
+	switchType(Circle{Radius: 1.5})
+	switchType(3.14)
+	switchType(nil) // 注意 nil 的情况
 
 	// 接口值可以是 nil
 	var nilShape Shape
@@ -182,10 +183,16 @@ func main() {
 		fmt.Println("nilShape 的面积:", nilShape.Area())
 	}
 
+	demoShapeJSON()
+	demoPipeline()
+	demoDispatch()
+
 	fmt.Println("\n--- 接口学习结束 ---")
 }
 
-func checkType(i interface{}) { // i 是一个空接口，可以接收任何类型
+// switchType 演示 type switch 的语法：类型集合固定时，这是最直接的写法。
+// 但每支持一种新类型都要回到这里加一个 case，所以需要扩展的 checkType 改用了注册表 (见 dispatch.go)。
+func switchType(i interface{}) { // i 是一个空接口，可以接收任何类型
 	fmt.Printf("  检查类型: 值=%v, ", i)
 	switch v := i.(type) { // v 会是转换后的具体类型的值
 	case int:
@@ -0,0 +1,412 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultTolerance 是 Flatten 在未指定容差 (tol <= 0) 时使用的默认值：
+// 折线与原曲线之间的最大距离不超过它。
+const DefaultTolerance = 1e-3
+
+// Segment 是路径中的一段曲线，参数 t 在 [0, 1] 之间，t=0 为起点，t=1 为终点。
+// LineSegment、QuadBezier、CubicBezier 和 Arc 都实现了这个接口。
+type Segment interface {
+	Start() Point
+	End() Point
+	At(t float64) Point
+	// Derivative 返回 t 处对参数的导数 (切向量)
+	Derivative(t float64) Point
+	// Split 在参数 t 处把曲线分成两段
+	Split(t float64) (Segment, Segment)
+	Bounds() BBox
+	// Length 返回弧长
+	Length() float64
+	// Flatten 把曲线近似为折线 (包含起点和终点)，折线与曲线的最大距离不超过 tol
+	Flatten(tol float64) []Point
+	// Nearest 返回曲线上离 p 最近的点及其参数
+	Nearest(p Point) (t float64, q Point)
+}
+
+var (
+	_ Segment = LineSegment{}
+	_ Segment = QuadBezier{}
+	_ Segment = CubicBezier{}
+	_ Segment = Arc{}
+)
+
+func lerp(a, b Point, t float64) Point {
+	return a.Add(b.Sub(a).Mul(t))
+}
+
+// --- 线段 ---
+
+// LineSegment 是从 A 到 B 的直线段
+type LineSegment struct {
+	A, B Point
+}
+
+func (l LineSegment) Start() Point             { return l.A }
+func (l LineSegment) End() Point               { return l.B }
+func (l LineSegment) At(t float64) Point       { return lerp(l.A, l.B, t) }
+func (l LineSegment) Derivative(float64) Point { return l.B.Sub(l.A) }
+func (l LineSegment) Bounds() BBox             { return BoxOf(l.A, l.B) }
+func (l LineSegment) Length() float64          { return l.A.Dist(l.B) }
+func (l LineSegment) Flatten(float64) []Point  { return []Point{l.A, l.B} }
+func (l LineSegment) String() string           { return fmt.Sprintf("Line(%v, %v)", l.A, l.B) }
+func (l LineSegment) Split(t float64) (Segment, Segment) {
+	m := l.At(t)
+	return LineSegment{l.A, m}, LineSegment{m, l.B}
+}
+
+// Nearest 把 p 投影到线段上
+func (l LineSegment) Nearest(p Point) (float64, Point) {
+	ab := l.B.Sub(l.A)
+	l2 := ab.Dot(ab)
+	if l2 <= Epsilon*Epsilon {
+		return 0, l.A
+	}
+	t := math.Max(0, math.Min(1, p.Sub(l.A).Dot(ab)/l2))
+	return t, l.At(t)
+}
+
+// --- 二次贝塞尔曲线 ---
+
+// QuadBezier 是二次贝塞尔曲线，P0、P2 是端点，P1 是控制点：
+// B(t) = (1-t)²·P0 + 2(1-t)t·P1 + t²·P2
+type QuadBezier struct {
+	P0, P1, P2 Point
+}
+
+func (q QuadBezier) Start() Point { return q.P0 }
+func (q QuadBezier) End() Point   { return q.P2 }
+
+// At 计算曲线在 t 处的点
+func (q QuadBezier) At(t float64) Point {
+	mt := 1 - t
+	return q.P0.Mul(mt * mt).Add(q.P1.Mul(2 * mt * t)).Add(q.P2.Mul(t * t))
+}
+
+// Derivative 返回 B'(t) = 2(1-t)(P1-P0) + 2t(P2-P1)
+func (q QuadBezier) Derivative(t float64) Point {
+	return q.P1.Sub(q.P0).Mul(2 * (1 - t)).Add(q.P2.Sub(q.P1).Mul(2 * t))
+}
+
+// Split 使用 de Casteljau 算法在 t 处细分曲线，两段合起来与原曲线完全重合
+func (q QuadBezier) Split(t float64) (Segment, Segment) {
+	a, b := lerp(q.P0, q.P1, t), lerp(q.P1, q.P2, t)
+	m := lerp(a, b, t)
+	return QuadBezier{q.P0, a, m}, QuadBezier{m, b, q.P2}
+}
+
+// Bounds 返回精确的外接框：除端点外，极值只可能出现在导数为 0 的参数处
+func (q QuadBezier) Bounds() BBox {
+	b := BoxOf(q.P0, q.P2)
+	// B'(t) = 0  =>  t = (P0-P1) / (P0-2P1+P2)，分别对 x、y 求解
+	for _, c := range [][3]float64{{q.P0.X, q.P1.X, q.P2.X}, {q.P0.Y, q.P1.Y, q.P2.Y}} {
+		if den := c[0] - 2*c[1] + c[2]; math.Abs(den) > Epsilon {
+			if t := (c[0] - c[1]) / den; t > 0 && t < 1 {
+				b = b.ExtendPoint(q.At(t))
+			}
+		}
+	}
+	return b
+}
+
+// Length 用自适应高斯-勒让德积分计算弧长
+func (q QuadBezier) Length() float64 { return arcLength(q.Derivative) }
+
+// Flatten 递归细分曲线，直到控制点到弦的距离不超过 tol
+func (q QuadBezier) Flatten(tol float64) []Point {
+	return flattenBezier([]Point{q.P0, q.P1, q.P2}, tolerance(tol))
+}
+
+// Nearest 返回曲线上离 p 最近的点
+func (q QuadBezier) Nearest(p Point) (float64, Point) { return nearestOn(q.At, p) }
+
+// Cubic 把二次曲线升阶为形状完全相同的三次曲线
+func (q QuadBezier) Cubic() CubicBezier {
+	return CubicBezier{q.P0, lerp(q.P0, q.P1, 2.0/3), lerp(q.P2, q.P1, 2.0/3), q.P2}
+}
+
+// --- 三次贝塞尔曲线 ---
+
+// CubicBezier 是三次贝塞尔曲线，P0、P3 是端点，P1、P2 是控制点：
+// B(t) = (1-t)³·P0 + 3(1-t)²t·P1 + 3(1-t)t²·P2 + t³·P3
+type CubicBezier struct {
+	P0, P1, P2, P3 Point
+}
+
+func (c CubicBezier) Start() Point { return c.P0 }
+func (c CubicBezier) End() Point   { return c.P3 }
+
+// At 计算曲线在 t 处的点
+func (c CubicBezier) At(t float64) Point {
+	mt := 1 - t
+	return c.P0.Mul(mt * mt * mt).
+		Add(c.P1.Mul(3 * mt * mt * t)).
+		Add(c.P2.Mul(3 * mt * t * t)).
+		Add(c.P3.Mul(t * t * t))
+}
+
+// Derivative 返回 B'(t) = 3(1-t)²(P1-P0) + 6(1-t)t(P2-P1) + 3t²(P3-P2)
+func (c CubicBezier) Derivative(t float64) Point {
+	mt := 1 - t
+	return c.P1.Sub(c.P0).Mul(3 * mt * mt).
+		Add(c.P2.Sub(c.P1).Mul(6 * mt * t)).
+		Add(c.P3.Sub(c.P2).Mul(3 * t * t))
+}
+
+// Split 使用 de Casteljau 算法在 t 处细分曲线
+func (c CubicBezier) Split(t float64) (Segment, Segment) {
+	ab, bc, cd := lerp(c.P0, c.P1, t), lerp(c.P1, c.P2, t), lerp(c.P2, c.P3, t)
+	abc, bcd := lerp(ab, bc, t), lerp(bc, cd, t)
+	m := lerp(abc, bcd, t)
+	return CubicBezier{c.P0, ab, abc, m}, CubicBezier{m, bcd, cd, c.P3}
+}
+
+// Bounds 返回精确的外接框。
+// B'(t)/3 = A·t² + B·t + C，其中 A = -P0+3P1-3P2+P3，B = 2(P0-2P1+P2)，C = P1-P0，
+// 对 x、y 分别解这个一元二次方程，取 (0, 1) 内的根。
+func (c CubicBezier) Bounds() BBox {
+	b := BoxOf(c.P0, c.P3)
+	for _, v := range [][4]float64{{c.P0.X, c.P1.X, c.P2.X, c.P3.X}, {c.P0.Y, c.P1.Y, c.P2.Y, c.P3.Y}} {
+		qa := -v[0] + 3*v[1] - 3*v[2] + v[3]
+		qb := 2 * (v[0] - 2*v[1] + v[2])
+		qc := v[1] - v[0]
+		for _, t := range quadRoots(qa, qb, qc) {
+			if t > 0 && t < 1 {
+				b = b.ExtendPoint(c.At(t))
+			}
+		}
+	}
+	return b
+}
+
+// Length 用自适应高斯-勒让德积分计算弧长
+func (c CubicBezier) Length() float64 { return arcLength(c.Derivative) }
+
+// Flatten 递归细分曲线，直到控制点到弦的距离不超过 tol
+func (c CubicBezier) Flatten(tol float64) []Point {
+	return flattenBezier([]Point{c.P0, c.P1, c.P2, c.P3}, tolerance(tol))
+}
+
+// Nearest 返回曲线上离 p 最近的点
+func (c CubicBezier) Nearest(p Point) (float64, Point) { return nearestOn(c.At, p) }
+
+// quadRoots 返回 a·t² + b·t + c = 0 的实根，a 接近 0 时退化为一次方程
+func quadRoots(a, b, c float64) []float64 {
+	if math.Abs(a) <= Epsilon {
+		if math.Abs(b) <= Epsilon {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+	d := b*b - 4*a*c
+	if d < 0 {
+		return nil
+	}
+	sq := math.Sqrt(d)
+	return []float64{(-b + sq) / (2 * a), (-b - sq) / (2 * a)}
+}
+
+// --- 圆弧 ---
+
+// Arc 是圆心为 Center、半径为 Radius 的一段圆弧。
+// 从角度 StartAngle (弧度) 开始，转过 Sweep 弧度：Sweep > 0 为逆时针，< 0 为顺时针。
+type Arc struct {
+	Center     Point
+	Radius     float64
+	StartAngle float64
+	Sweep      float64
+}
+
+func (a Arc) angle(t float64) float64 { return a.StartAngle + a.Sweep*t }
+func (a Arc) Start() Point            { return a.At(0) }
+func (a Arc) End() Point              { return a.At(1) }
+func (a Arc) Length() float64         { return a.Radius * math.Abs(a.Sweep) }
+
+// At 计算圆弧在 t 处的点
+func (a Arc) At(t float64) Point {
+	s, c := math.Sincos(a.angle(t))
+	return Point{a.Center.X + a.Radius*c, a.Center.Y + a.Radius*s}
+}
+
+// Derivative 返回 t 处的切向量，长度为 Radius·|Sweep|
+func (a Arc) Derivative(t float64) Point {
+	s, c := math.Sincos(a.angle(t))
+	k := a.Radius * a.Sweep
+	return Point{-k * s, k * c}
+}
+
+// Split 在 t 处把圆弧分成两段
+func (a Arc) Split(t float64) (Segment, Segment) {
+	first, second := a, a
+	first.Sweep = a.Sweep * t
+	second.StartAngle, second.Sweep = a.angle(t), a.Sweep*(1-t)
+	return first, second
+}
+
+// Bounds 返回外接框：除端点外，圆弧经过 0、π/2、π、3π/2 方向时取到极值
+func (a Arc) Bounds() BBox {
+	b := BoxOf(a.Start(), a.End())
+	lo, hi := a.StartAngle, a.StartAngle+a.Sweep
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	for k := math.Ceil(lo / (Pi / 2)); k*Pi/2 <= hi; k++ {
+		s, c := math.Sincos(k * Pi / 2)
+		b = b.ExtendPoint(Point{a.Center.X + a.Radius*c, a.Center.Y + a.Radius*s})
+	}
+	return b
+}
+
+// Flatten 按弓高误差计算分段数：
+// 每段圆心角为 θ 时，弦与弧的最大距离为 r(1 - cos(θ/2))，令其不超过 tol 即可。
+func (a Arc) Flatten(tol float64) []Point {
+	tol = tolerance(tol)
+	n := 1
+	if a.Radius > tol {
+		step := 2 * math.Acos(1-tol/a.Radius)
+		n = max(1, int(math.Ceil(math.Abs(a.Sweep)/step)))
+	}
+	pts := make([]Point, n+1)
+	for i := range pts {
+		pts[i] = a.At(float64(i) / float64(n))
+	}
+	return pts
+}
+
+// Nearest 返回圆弧上离 p 最近的点：p 的方向落在圆弧范围内时取该方向上的点，否则取较近的端点
+func (a Arc) Nearest(p Point) (float64, Point) {
+	if a.Sweep != 0 && !p.Eq(a.Center) {
+		d := p.Sub(a.Center)
+		rel := math.Atan2(d.Y, d.X) - a.StartAngle
+		if a.Sweep < 0 {
+			rel = -rel
+		}
+		rel = math.Mod(rel, 2*Pi)
+		if rel < 0 {
+			rel += 2 * Pi
+		}
+		if t := rel / math.Abs(a.Sweep); t <= 1 {
+			return t, a.At(t)
+		}
+	}
+	if p.Dist(a.Start()) <= p.Dist(a.End()) {
+		return 0, a.Start()
+	}
+	return 1, a.End()
+}
+
+// --- 数值计算辅助 ---
+
+func tolerance(tol float64) float64 {
+	if tol <= 0 || math.IsNaN(tol) {
+		return DefaultTolerance
+	}
+	return tol
+}
+
+// 5 点高斯-勒让德积分的节点和权重 (区间 [-1, 1])
+var (
+	glNodes   = [5]float64{0, -0.5384693101056831, 0.5384693101056831, -0.9061798459386640, 0.9061798459386640}
+	glWeights = [5]float64{0.5688888888888889, 0.4786286704993665, 0.4786286704993665, 0.2369268850561891, 0.2369268850561891}
+)
+
+// arcLength 对速度 |B'(t)| 在 [0, 1] 上积分得到弧长。
+// 整段的积分与两半之和相差较大时递归细分，保证曲率变化剧烈处的精度。
+func arcLength(deriv func(float64) Point) float64 {
+	var gauss func(a, b float64) float64
+	gauss = func(a, b float64) float64 {
+		half, mid := (b-a)/2, (a+b)/2
+		var sum float64
+		for i, x := range glNodes {
+			sum += glWeights[i] * deriv(mid+half*x).Len()
+		}
+		return sum * half
+	}
+	var adapt func(a, b, whole float64, depth int) float64
+	adapt = func(a, b, whole float64, depth int) float64 {
+		m := (a + b) / 2
+		left, right := gauss(a, m), gauss(m, b)
+		if depth >= 12 || math.Abs(left+right-whole) <= 1e-12*math.Max(1, whole) {
+			return left + right
+		}
+		return adapt(a, m, left, depth+1) + adapt(m, b, right, depth+1)
+	}
+	return adapt(0, 1, gauss(0, 1), 0)
+}
+
+// flattenBezier 对控制点 ctrl 表示的贝塞尔曲线做自适应细分。
+// 贝塞尔曲线总在控制点的凸包内，所以控制点到弦的最大距离是曲线偏离弦的上界。
+func flattenBezier(ctrl []Point, tol float64) []Point {
+	out := []Point{ctrl[0]}
+	var rec func(ctrl []Point, depth int)
+	rec = func(ctrl []Point, depth int) {
+		last := ctrl[len(ctrl)-1]
+		flat := true
+		for _, c := range ctrl[1 : len(ctrl)-1] {
+			if segmentDistance(c, ctrl[0], last) > tol {
+				flat = false
+				break
+			}
+		}
+		if flat || depth >= 16 {
+			out = append(out, last)
+			return
+		}
+		left, right := splitControl(ctrl)
+		rec(left, depth+1)
+		rec(right, depth+1)
+	}
+	rec(ctrl, 0)
+	return out
+}
+
+// splitControl 用 de Casteljau 算法在 t=0.5 处细分任意阶的贝塞尔曲线
+func splitControl(ctrl []Point) (left, right []Point) {
+	n := len(ctrl)
+	left, right = make([]Point, n), make([]Point, n)
+	work := append([]Point(nil), ctrl...)
+	for level := range n {
+		left[level] = work[0]
+		right[n-1-level] = work[n-1-level]
+		for i := 0; i < n-1-level; i++ {
+			work[i] = lerp(work[i], work[i+1], 0.5)
+		}
+	}
+	return left, right
+}
+
+// nearestOn 先等距采样找到最近的采样点，再在其相邻区间内用黄金分割搜索细化。
+func nearestOn(at func(float64) Point, p Point) (float64, Point) {
+	const samples = 64
+	best, bestD := 0.0, math.Inf(1)
+	for i := range samples + 1 {
+		t := float64(i) / samples
+		if d := at(t).Dist(p); d < bestD {
+			best, bestD = t, d
+		}
+	}
+	lo, hi := math.Max(0, best-1.0/samples), math.Min(1, best+1.0/samples)
+	const phi = 0.6180339887498949
+	x1, x2 := hi-phi*(hi-lo), lo+phi*(hi-lo)
+	d1, d2 := at(x1).Dist(p), at(x2).Dist(p)
+	for hi-lo > 1e-12 {
+		if d1 < d2 {
+			hi, x2, d2 = x2, x1, d1
+			x1 = hi - phi*(hi-lo)
+			d1 = at(x1).Dist(p)
+		} else {
+			lo, x1, d1 = x1, x2, d2
+			x2 = lo + phi*(hi-lo)
+			d2 = at(x2).Dist(p)
+		}
+	}
+	t := (lo + hi) / 2
+	if at(t).Dist(p) > bestD { // 细化不应该变差，保险起见与采样结果比较
+		t = best
+	}
+	return t, at(t)
+}
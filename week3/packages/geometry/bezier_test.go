@@ -0,0 +1,130 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+// near 判断两个点在 tol 范围内重合
+func near(p, q Point, tol float64) bool { return p.Dist(q) <= tol }
+
+// boxNear 判断两个外接框在 tol 范围内相同
+func boxNear(a, b BBox, tol float64) bool { return near(a.Min, b.Min, tol) && near(a.Max, b.Max, tol) }
+
+// 测试用的曲线。抛物线 y = x² (0 ≤ x ≤ 1) 可以精确地写成二次贝塞尔曲线，
+// 弧长有解析解 (2√5 + asinh 2) / 4
+var (
+	parabola      = QuadBezier{Pt(0, 0), Pt(0.5, 0), Pt(1, 1)}
+	parabolaLen   = (2*math.Sqrt(5) + math.Asinh(2)) / 4
+	arch          = CubicBezier{Pt(0, 0), Pt(0, 1), Pt(1, 1), Pt(1, 0)}
+	halfCircle    = Arc{Center: Pt(0, 0), Radius: 2, StartAngle: 0, Sweep: Pi}
+	testSegments  = []Segment{LineSegment{Pt(1, 1), Pt(4, 5)}, parabola, arch, halfCircle}
+	segmentLabels = []string{"线段", "二次", "三次", "圆弧"}
+)
+
+func TestSegmentLength(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Segment
+		want float64
+	}{
+		{"线段", LineSegment{Pt(1, 1), Pt(4, 5)}, 5},
+		{"抛物线", parabola, parabolaLen},
+		{"升阶为三次之后长度不变", parabola.Cubic(), parabolaLen},
+		// 控制点等距排在一条直线上时，三次曲线就是匀速的线段
+		{"共线的三次曲线", CubicBezier{Pt(0, 0), Pt(1, 0), Pt(2, 0), Pt(3, 0)}, 3},
+		{"半圆", halfCircle, 2 * Pi},
+		{"顺时针的圆弧", Arc{Radius: 3, StartAngle: 1, Sweep: -Pi / 2}, 1.5 * Pi},
+		{"退化为一个点", CubicBezier{Pt(1, 1), Pt(1, 1), Pt(1, 1), Pt(1, 1)}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Length(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Length = %.12f, want %.12f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentSplit(t *testing.T) {
+	for i, s := range testSegments {
+		for _, u := range []float64{0.25, 0.5, 0.8} {
+			first, second := s.Split(u)
+			if !near(first.Start(), s.Start(), 1e-12) || !near(second.End(), s.End(), 1e-12) ||
+				!near(first.End(), s.At(u), 1e-12) || !near(second.Start(), s.At(u), 1e-12) {
+				t.Errorf("%s Split(%v) 的端点不对: %v, %v", segmentLabels[i], u, first, second)
+			}
+			// 两段上的点与原曲线上对应参数的点重合
+			for _, v := range []float64{0.1, 0.5, 0.9} {
+				if p, q := first.At(v), s.At(u*v); !near(p, q, 1e-12) {
+					t.Errorf("%s Split(%v) 第一段 At(%v) = %v, want %v", segmentLabels[i], u, v, p, q)
+				}
+				if p, q := second.At(v), s.At(u+(1-u)*v); !near(p, q, 1e-12) {
+					t.Errorf("%s Split(%v) 第二段 At(%v) = %v, want %v", segmentLabels[i], u, v, p, q)
+				}
+			}
+			if sum := first.Length() + second.Length(); math.Abs(sum-s.Length()) > 1e-9 {
+				t.Errorf("%s Split(%v) 两段长度之和 = %v, want %v", segmentLabels[i], u, sum, s.Length())
+			}
+		}
+	}
+}
+
+func TestSegmentBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Segment
+		want BBox
+	}{
+		{"线段", LineSegment{Pt(4, 5), Pt(1, 1)}, BBox{Pt(1, 1), Pt(4, 5)}},
+		// 极值在曲线内部，而不是控制点的外接框
+		{"二次曲线的顶点", QuadBezier{Pt(0, 0), Pt(1, 2), Pt(2, 0)}, BBox{Pt(0, 0), Pt(2, 1)}},
+		{"三次曲线的顶点", arch, BBox{Pt(0, 0), Pt(1, 0.75)}},
+		{"半圆", halfCircle, BBox{Pt(-2, 0), Pt(2, 2)}},
+		// 顺时针从 45° 转到 -45°，经过 0° 方向
+		{"跨过 0° 的圆弧", Arc{Radius: 1, StartAngle: Pi / 4, Sweep: -Pi / 2}, BBox{Pt(math.Sqrt2/2, -math.Sqrt2/2), Pt(1, math.Sqrt2/2)}},
+		{"跨过 180° 的圆弧", Arc{Center: Pt(1, 1), Radius: 1, StartAngle: 3 * Pi / 4, Sweep: Pi / 2}, BBox{Pt(0, 1-math.Sqrt2/2), Pt(1-math.Sqrt2/2, 1+math.Sqrt2/2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Bounds(); !boxNear(got, tt.want, 1e-12) {
+				t.Errorf("Bounds = %v, want %v", got, tt.want)
+			}
+			// 采样点都在外接框内
+			b := tt.s.Bounds()
+			for i := range 101 {
+				if p := tt.s.At(float64(i) / 100); p.X < b.Min.X-1e-12 || p.X > b.Max.X+1e-12 || p.Y < b.Min.Y-1e-12 || p.Y > b.Max.Y+1e-12 {
+					t.Fatalf("At(%v) = %v 在外接框 %v 之外", float64(i)/100, p, b)
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentFlatten(t *testing.T) {
+	for i, s := range testSegments {
+		for _, tol := range []float64{0.1, 1e-3} {
+			pts := s.Flatten(tol)
+			if !near(pts[0], s.Start(), 1e-12) || !near(pts[len(pts)-1], s.End(), 1e-12) {
+				t.Errorf("%s Flatten(%v) 的首尾 = %v, %v; want %v, %v", segmentLabels[i], tol, pts[0], pts[len(pts)-1], s.Start(), s.End())
+			}
+			// 每条弦上的点与曲线的距离都不超过 tol
+			for j := 1; j < len(pts); j++ {
+				for _, f := range []float64{0.25, 0.5, 0.75} {
+					p := lerp(pts[j-1], pts[j], f)
+					if _, q := s.Nearest(p); p.Dist(q) > tol*(1+1e-9) {
+						t.Fatalf("%s Flatten(%v) 第 %d 条弦偏离曲线 %v", segmentLabels[i], tol, j, p.Dist(q))
+					}
+				}
+			}
+		}
+	}
+	// 容差越小，折线越接近曲线，分段也越多
+	coarse, fine := halfCircle.Flatten(0.1), halfCircle.Flatten(1e-4)
+	if len(fine) <= len(coarse) {
+		t.Errorf("Flatten(1e-4) 有 %d 个点, 不多于 Flatten(0.1) 的 %d 个", len(fine), len(coarse))
+	}
+	if got := len(parabola.Flatten(0)); got != len(parabola.Flatten(DefaultTolerance)) {
+		t.Errorf("tol <= 0 时应该使用 DefaultTolerance")
+	}
+}
@@ -0,0 +1,492 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrNotSimple 表示参与布尔运算的多边形存在自交。
+var ErrNotSimple = errors.New("多边形存在自交")
+
+// Region 是带洞的多边形：Outer 是外边界 (逆时针)，Holes 是内部的洞 (顺时针)。
+type Region struct {
+	Outer Polygon
+	Holes []Polygon
+}
+
+// MultiPolygon 是若干互不重叠的 Region，是多边形布尔运算的结果类型。
+type MultiPolygon []Region
+
+var (
+	_ Shape = Region{}
+	_ Shape = MultiPolygon{}
+)
+
+// NewRegion 创建带洞的多边形。外边界和洞都必须是面积不为零的简单多边形，且每个洞都要位于外边界内部。
+// 面积为零时返回 ErrDegeneratePolygon，自交时返回 ErrNotSimple。
+// 顶点方向会被统一为：外边界逆时针、洞顺时针。
+func NewRegion(outer Polygon, holes ...Polygon) (Region, error) {
+	if err := checkRing(outer); err != nil {
+		return Region{}, fmt.Errorf("外边界: %w", err)
+	}
+	r := Region{Outer: orient(outer, CounterClockwise)}
+	for i, h := range holes {
+		if err := checkRing(h); err != nil {
+			return Region{}, fmt.Errorf("第 %d 个洞: %w", i, err)
+		}
+		for _, p := range h.Points {
+			if !outer.Contains(p) {
+				return Region{}, fmt.Errorf("第 %d 个洞的顶点 %v 不在外边界内", i, p)
+			}
+		}
+		r.Holes = append(r.Holes, orient(h, Clockwise))
+	}
+	return r, nil
+}
+
+// checkRing 检查环能否作为区域的边界。
+// 面积为零的环 (如宽度为 0 的矩形) 的边会相互重叠，必须先于自交检查排除，否则会被误报为自交。
+func checkRing(pg Polygon) error {
+	if len(dedupe(pg.Points)) < 3 || pg.Area() <= Epsilon {
+		return ErrDegeneratePolygon
+	}
+	if !pg.IsSimple() {
+		return ErrNotSimple
+	}
+	return nil
+}
+
+func orient(pg Polygon, o Orientation) Polygon {
+	if pg.Orientation() != o {
+		return pg.Reversed()
+	}
+	return pg
+}
+
+// Area 返回外边界面积减去所有洞的面积
+func (r Region) Area() float64 {
+	a := r.Outer.Area()
+	for _, h := range r.Holes {
+		a -= h.Area()
+	}
+	return a
+}
+
+// Perimeter 返回外边界与所有洞的周长之和
+func (r Region) Perimeter() float64 {
+	p := r.Outer.Perimeter()
+	for _, h := range r.Holes {
+		p += h.Perimeter()
+	}
+	return p
+}
+
+// Bounds 返回外边界的外接框
+func (r Region) Bounds() BBox {
+	return r.Outer.Bounds()
+}
+
+// Centroid 返回扣除洞之后区域的质心
+func (r Region) Centroid() Point {
+	return weightedCentroid(r.rings())
+}
+
+// Contains 判断点 p 是否在区域内 (含边界)
+func (r Region) Contains(p Point) bool {
+	if !r.Outer.Contains(p) {
+		return false
+	}
+	for _, h := range r.Holes {
+		if h.WindingNumber(p) != 0 && !h.OnBoundary(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// rings 返回外边界和所有洞
+func (r Region) rings() []Polygon {
+	return append([]Polygon{r.Outer}, r.Holes...)
+}
+
+// Area 返回所有区域的面积之和
+func (m MultiPolygon) Area() float64 {
+	var a float64
+	for _, r := range m {
+		a += r.Area()
+	}
+	return a
+}
+
+// Perimeter 返回所有区域的周长之和
+func (m MultiPolygon) Perimeter() float64 {
+	var p float64
+	for _, r := range m {
+		p += r.Perimeter()
+	}
+	return p
+}
+
+// Bounds 返回所有区域的外接框，为空时返回零值
+func (m MultiPolygon) Bounds() BBox {
+	if len(m) == 0 {
+		return BBox{}
+	}
+	b := m[0].Bounds()
+	for _, r := range m[1:] {
+		b = b.Union(r.Bounds())
+	}
+	return b
+}
+
+// Centroid 返回所有区域按面积加权的质心
+func (m MultiPolygon) Centroid() Point {
+	var rings []Polygon
+	for _, r := range m {
+		rings = append(rings, r.rings()...)
+	}
+	return weightedCentroid(rings)
+}
+
+// Contains 判断点 p 是否在任意一个区域内
+func (m MultiPolygon) Contains(p Point) bool {
+	return slices.ContainsFunc(m, func(r Region) bool { return r.Contains(p) })
+}
+
+// weightedCentroid 按有符号面积加权合并各个环的质心 (洞是顺时针的，面积为负，正好被扣除)。
+func weightedCentroid(rings []Polygon) Point {
+	var c Point
+	var total float64
+	for _, pg := range rings {
+		a := pg.SignedArea()
+		c = c.Add(pg.Centroid().Mul(a))
+		total += a
+	}
+	if math.Abs(total) <= Epsilon {
+		if len(rings) == 0 {
+			return Point{}
+		}
+		return rings[0].Bounds().Center()
+	}
+	return c.Mul(1 / total)
+}
+
+// --- 布尔运算 ---
+
+// ClipOp 是多边形布尔运算的类型
+type ClipOp int
+
+const (
+	OpUnion        ClipOp = iota // 并集 A ∪ B
+	OpIntersection               // 交集 A ∩ B
+	OpDifference                 // 差集 A − B
+	OpXor                        // 对称差 (A − B) ∪ (B − A)
+)
+
+// Union 返回 a ∪ b
+func Union(a, b Shape) (MultiPolygon, error) { return Clip(a, b, OpUnion) }
+
+// Intersection 返回 a ∩ b
+func Intersection(a, b Shape) (MultiPolygon, error) { return Clip(a, b, OpIntersection) }
+
+// Difference 返回 a − b
+func Difference(a, b Shape) (MultiPolygon, error) { return Clip(a, b, OpDifference) }
+
+// Xor 返回 a 与 b 的对称差
+func Xor(a, b Shape) (MultiPolygon, error) { return Clip(a, b, OpXor) }
+
+// Clip 对两个形状做布尔运算。
+//
+// 形状先被转换为带洞多边形 (圆和椭圆按 CircleSegments 近似)，然后使用"边分类"的叠加算法：
+//  1. 在所有交点处把两边的边切开，使任意两条子边要么不相交，要么完全重合；
+//  2. 用子边中点判断每条子边位于另一个形状的内部还是外部，重合的边单独处理；
+//  3. 按运算类型挑选子边 (必要时反向)，再把它们首尾相连成环；
+//  4. 逆时针的环是外边界，顺时针的环是洞，把每个洞归到包含它的最小外边界下。
+//
+// 与 Greiner–Hormann 相比，这种做法能自然地处理带洞的多边形和共线重合的边。
+func Clip(a, b Shape, op ClipOp) (MultiPolygon, error) {
+	ra, err := toRegions(a)
+	if err != nil {
+		return nil, err
+	}
+	rb, err := toRegions(b)
+	if err != nil {
+		return nil, err
+	}
+	ea, eb := splitEdges(regionEdges(ra), regionEdges(rb))
+
+	edgesB := make(map[dedge]bool, len(eb))
+	for _, e := range eb {
+		edgesB[e] = true
+	}
+	edgesA := make(map[dedge]bool, len(ea))
+	for _, e := range ea {
+		edgesA[e] = true
+	}
+
+	var out []dedge
+	for _, e := range ea {
+		same, opposite := edgesB[e], edgesB[e.reversed()]
+		switch {
+		case same:
+			if op == OpUnion || op == OpIntersection {
+				out = append(out, e)
+			}
+		case opposite:
+			if op == OpDifference {
+				out = append(out, e)
+			}
+		case regionsContain(rb, e.mid()):
+			switch op {
+			case OpIntersection:
+				out = append(out, e)
+			case OpXor:
+				out = append(out, e.reversed())
+			}
+		default:
+			if op != OpIntersection {
+				out = append(out, e)
+			}
+		}
+	}
+	for _, e := range eb {
+		if edgesA[e] || edgesA[e.reversed()] {
+			continue // 重合的边已经在上面按 A 的边处理过了
+		}
+		if regionsContain(ra, e.mid()) {
+			switch op {
+			case OpIntersection:
+				out = append(out, e)
+			case OpDifference, OpXor:
+				out = append(out, e.reversed())
+			}
+		} else if op == OpUnion || op == OpXor {
+			out = append(out, e)
+		}
+	}
+	return assembleRegions(linkRings(out)), nil
+}
+
+// toRegions 把形状转换为带洞多边形列表。面积为零的形状返回包装了 ErrDegeneratePolygon 的错误。
+func toRegions(s Shape) (MultiPolygon, error) {
+	switch v := s.(type) {
+	case MultiPolygon:
+		return v, nil
+	case Region:
+		return MultiPolygon{v}, nil
+	}
+	pg, err := ToPolygon(s)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewRegion(Polygon{Points: dedupe(pg.Points)})
+	if err != nil {
+		return nil, err
+	}
+	return MultiPolygon{r}, nil
+}
+
+// regionsContain 用环绕数之和判断点是否严格位于区域内部：
+// 外边界逆时针 (+1)，洞顺时针 (-1)，所以点落在洞里时两者正好抵消。
+func regionsContain(m MultiPolygon, p Point) bool {
+	wn := 0
+	for _, r := range m {
+		for _, ring := range r.rings() {
+			wn += ring.WindingNumber(p)
+		}
+	}
+	return wn > 0
+}
+
+// dedge 是一条有向边，区域内部位于它的左侧
+type dedge struct {
+	a, b Point
+}
+
+func (e dedge) reversed() dedge { return dedge{e.b, e.a} }
+func (e dedge) mid() Point      { return e.a.Add(e.b).Mul(0.5) }
+
+func regionEdges(m MultiPolygon) []dedge {
+	var out []dedge
+	for _, r := range m {
+		for _, ring := range r.rings() {
+			n := len(ring.Points)
+			for i := range n {
+				out = append(out, dedge{ring.Points[i], ring.Points[(i+1)%n]})
+			}
+		}
+	}
+	return out
+}
+
+// splitEdges 在两组边的所有交点处切分边。
+// 同一个交点只计算一次并同时插入两条边，保证切分后重合的子边端点完全相等。
+func splitEdges(ea, eb []dedge) ([]dedge, []dedge) {
+	splitsA := make([][]Point, len(ea))
+	splitsB := make([][]Point, len(eb))
+	for i, a := range ea {
+		for j, b := range eb {
+			if !BoxOf(a.a, a.b).Intersects(BoxOf(b.a, b.b)) {
+				continue
+			}
+			for _, p := range edgeIntersections(a, b) {
+				splitsA[i] = append(splitsA[i], p)
+				splitsB[j] = append(splitsB[j], p)
+			}
+		}
+	}
+	return applySplits(ea, splitsA), applySplits(eb, splitsB)
+}
+
+// edgeIntersections 返回两条边的交点；共线重合时返回落在对方上的端点。
+// 离某个端点很近的交点会被吸附到该端点，避免产生极短的碎边。
+func edgeIntersections(e, f dedge) []Point {
+	d1, d2 := e.b.Sub(e.a), f.b.Sub(f.a)
+	denom := d1.Cross(d2)
+	if math.Abs(denom) > Epsilon*d1.Len()*d2.Len() {
+		w := f.a.Sub(e.a)
+		t, u := w.Cross(d2)/denom, w.Cross(d1)/denom
+		const tol = 1e-12
+		if t < -tol || t > 1+tol || u < -tol || u > 1+tol {
+			return nil
+		}
+		p := e.a.Add(d1.Mul(t))
+		for _, q := range []Point{e.a, e.b, f.a, f.b} {
+			if p.Eq(q) {
+				return []Point{q}
+			}
+		}
+		return []Point{p}
+	}
+	if Orient(e.a, e.b, f.a) != Collinear || Orient(e.a, e.b, f.b) != Collinear {
+		return nil // 平行但不共线
+	}
+	var out []Point
+	for _, p := range []Point{f.a, f.b} {
+		if onSegment(e.a, e.b, p) {
+			out = append(out, p)
+		}
+	}
+	for _, p := range []Point{e.a, e.b} {
+		if onSegment(f.a, f.b, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// applySplits 按切分点把每条边拆成若干子边
+func applySplits(edges []dedge, splits [][]Point) []dedge {
+	var out []dedge
+	for i, e := range edges {
+		d := e.b.Sub(e.a)
+		l2 := d.Dot(d)
+		param := func(p Point) float64 { return p.Sub(e.a).Dot(d) / l2 }
+		pts := []Point{e.a}
+		inner := slices.Clone(splits[i])
+		slices.SortFunc(inner, func(p, q Point) int { return cmpFloat(param(p), param(q)) })
+		for _, p := range inner {
+			if !p.Eq(pts[len(pts)-1]) && !p.Eq(e.b) {
+				pts = append(pts, p)
+			}
+		}
+		pts = append(pts, e.b)
+		for k := 1; k < len(pts); k++ {
+			out = append(out, dedge{pts[k-1], pts[k]})
+		}
+	}
+	return out
+}
+
+// linkRings 把有向边首尾相连成闭合的环。
+// 一个顶点有多条出边时，选择相对入边向左转得最多的那条，这样相互接触的环会被分开而不是缠在一起。
+func linkRings(edges []dedge) []Polygon {
+	outgoing := make(map[Point][]int)
+	for i, e := range edges {
+		outgoing[e.a] = append(outgoing[e.a], i)
+	}
+	used := make([]bool, len(edges))
+	var rings []Polygon
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		var pts []Point
+		cur := start
+		for {
+			used[cur] = true
+			e := edges[cur]
+			pts = append(pts, e.a)
+			if e.b == edges[start].a {
+				break
+			}
+			next := -1
+			best := math.Inf(-1)
+			din := e.b.Sub(e.a)
+			for _, j := range outgoing[e.b] {
+				if used[j] {
+					continue
+				}
+				dout := edges[j].b.Sub(edges[j].a)
+				turn := math.Atan2(din.Cross(dout), din.Dot(dout))
+				if turn >= Pi-Epsilon {
+					turn = -Pi // 原路折返的优先级最低
+				}
+				if turn > best {
+					next, best = j, turn
+				}
+			}
+			if next < 0 {
+				pts = nil // 数值误差导致环无法闭合，丢弃这段折线
+				break
+			}
+			cur = next
+		}
+		if pg := (Polygon{Points: dedupe(pts)}); len(pg.Points) >= 3 && pg.Area() > Epsilon {
+			rings = append(rings, pg)
+		}
+	}
+	return rings
+}
+
+// assembleRegions 把环组装成带洞多边形：逆时针的环是外边界，顺时针的环是洞，
+// 每个洞归属于包含它的面积最小的外边界。
+func assembleRegions(rings []Polygon) MultiPolygon {
+	var outers, holes []Polygon
+	for _, r := range rings {
+		if r.Orientation() == CounterClockwise {
+			outers = append(outers, r)
+		} else {
+			holes = append(holes, r)
+		}
+	}
+	slices.SortFunc(outers, func(a, b Polygon) int { return cmpFloat(a.Area(), b.Area()) })
+	result := make(MultiPolygon, len(outers))
+	for i, o := range outers {
+		result[i].Outer = o
+	}
+	for _, h := range holes {
+		p := interiorPointOf(h)
+		for i := range result {
+			if result[i].Outer.Contains(p) {
+				result[i].Holes = append(result[i].Holes, h)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// interiorPointOf 返回环上一个不在外边界上的测试点：取某条边中点并向环内侧偏移一点。
+func interiorPointOf(ring Polygon) Point {
+	a, b := ring.Points[0], ring.Points[1]
+	d := b.Sub(a)
+	l := d.Len()
+	n := Point{-d.Y / l, d.X / l} // 左法线
+	if ring.Orientation() == Clockwise {
+		n = n.Mul(-1)
+	}
+	return a.Add(b).Mul(0.5).Add(n.Mul(math.Min(l, 1) * 1e-6))
+}
@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestClipDegenerateInput(t *testing.T) {
+	flat, _ := NewRectangle(0, 5)
+	square, _ := NewRectangle(4, 4)
+	if _, err := Intersection(flat, square); !errors.Is(err, ErrDegeneratePolygon) {
+		t.Errorf("Intersection(零宽矩形) error = %v, want ErrDegeneratePolygon", err)
+	}
+	if _, err := NewRegion(Polygon{Points: []Point{{0, 0}, {1, 1}, {2, 2}}}); !errors.Is(err, ErrDegeneratePolygon) {
+		t.Errorf("NewRegion(共线顶点) error = %v, want ErrDegeneratePolygon", err)
+	}
+	bowtie := Polygon{Points: []Point{{0, 0}, {2, 2}, {2, 0}, {0, 3}}}
+	if _, err := NewRegion(bowtie); !errors.Is(err, ErrNotSimple) {
+		t.Errorf("NewRegion(自交) error = %v, want ErrNotSimple", err)
+	}
+}
+
+// 布尔运算的结果可以继续变换、绘制，不带洞的单块结果还可以转换为多边形
+func TestClipResultConsumers(t *testing.T) {
+	outer, _ := NewRectangle(10, 10)
+	inner, _ := NewRectangleAt(Pt(3, 3), 4, 4)
+	ring, err := Difference(outer, inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ring) != 1 || len(ring[0].Holes) != 1 {
+		t.Fatalf("Difference = %v, want 一个带洞的区域", ring)
+	}
+	if _, err := ToPolygon(ring); err == nil {
+		t.Error("带洞的区域不应该能转换为单个多边形")
+	}
+
+	mirrored, err := Scale(-1, 1).ApplyShape(ring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mirrored.(MultiPolygon)
+	if m[0].Outer.Orientation() != CounterClockwise || m[0].Holes[0].Orientation() != Clockwise {
+		t.Error("镜像之后应该保持外边界逆时针、洞顺时针")
+	}
+	if math.Abs(m.Area()-84) > 1e-9 {
+		t.Errorf("镜像之后面积 = %v, want 84", m.Area())
+	}
+
+	var sb strings.Builder
+	if err := WriteSVG(&sb, []SVGItem{{Shape: ring}}, SVGOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(sb.String(), " Z"); !strings.Contains(sb.String(), `fill-rule="evenodd"`) || got != 2 {
+		t.Errorf("SVG 中应该有一个包含 2 个环的 evenodd path:\n%s", sb.String())
+	}
+
+	both, err := Intersection(outer, inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pg, err := ToPolygon(both)
+	if err != nil {
+		t.Fatalf("ToPolygon(单块结果): %v", err)
+	}
+	if math.Abs(pg.Area()-16) > 1e-9 {
+		t.Errorf("ToPolygon(单块结果) 面积 = %v, want 16", pg.Area())
+	}
+}
@@ -0,0 +1,326 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotConvex 表示参与分离轴检测的多边形不是凸多边形。
+var ErrNotConvex = errors.New("多边形不是凸多边形")
+
+// Collision 描述两个形状 a、b 的碰撞检测结果。
+//
+// 约定：Normal 是从 a 指向 b 的单位向量，MTV (最小平移向量) = Normal × Depth。
+// 把 b 平移 MTV (或把 a 平移 -MTV) 就能让两个形状恰好分离。
+// 边界刚好接触时 Intersects 为 true，Depth 为 0。
+type Collision struct {
+	Intersects bool
+	Depth      float64 // 穿透深度
+	Normal     Point   // 分离方向 (单位向量)
+	MTV        Point   // 最小平移向量
+}
+
+func hit(normal Point, depth float64) Collision {
+	return Collision{Intersects: true, Depth: depth, Normal: normal, MTV: normal.Mul(depth)}
+}
+
+// flip 交换 a、b 的角色
+func (c Collision) flip() Collision {
+	c.Normal = c.Normal.Mul(-1)
+	c.MTV = c.MTV.Mul(-1)
+	return c
+}
+
+// Intersects 判断两个形状是否重叠 (边界接触也算重叠)。
+func Intersects(a, b Shape) (bool, error) {
+	c, err := Collide(a, b)
+	return c.Intersects, err
+}
+
+// Collide 检测两个形状的碰撞，并计算穿透深度和最小平移向量。
+// 支持圆、矩形、三角形、正多边形以及凸多边形之间的任意组合；
+// 非凸多边形返回 ErrNotConvex，椭圆等其它形状返回错误。
+func Collide(a, b Shape) (Collision, error) {
+	switch a := a.(type) {
+	case Circle:
+		switch b := b.(type) {
+		case Circle:
+			return CollideCircles(a, b), nil
+		case Rectangle:
+			return CollideCircleRect(a, b), nil
+		}
+		pb, err := convexVertices(b)
+		if err != nil {
+			return Collision{}, err
+		}
+		return CollideCircleConvex(a, pb)
+	case Rectangle:
+		switch b := b.(type) {
+		case Rectangle:
+			return CollideRects(a, b), nil
+		case Circle:
+			return CollideCircleRect(b, a).flip(), nil
+		}
+	}
+	if c, ok := b.(Circle); ok {
+		pa, err := convexVertices(a)
+		if err != nil {
+			return Collision{}, err
+		}
+		col, err := CollideCircleConvex(c, pa)
+		return col.flip(), err
+	}
+	pa, err := convexVertices(a)
+	if err != nil {
+		return Collision{}, err
+	}
+	pb, err := convexVertices(b)
+	if err != nil {
+		return Collision{}, err
+	}
+	return CollideConvex(pa, pb), nil
+}
+
+// convexVertices 把形状转换为凸多边形的顶点序列。
+// 顶点少于 3 个 (例如 RegularPolygon{Sides: 0}) 时返回包装了 ErrDegeneratePolygon 的错误。
+func convexVertices(s Shape) ([]Point, error) {
+	var pts []Point
+	switch v := s.(type) {
+	case Rectangle:
+		pts = v.Vertices()
+	case Triangle:
+		pts = v.Vertices()
+	case RegularPolygon:
+		pts = v.Vertices()
+	case Polygon:
+		if !v.IsConvex() {
+			return nil, ErrNotConvex
+		}
+		pts = v.Points
+	default:
+		return nil, fmt.Errorf("不支持对 %T 类型的形状做碰撞检测", s)
+	}
+	if err := checkConvexVertices(pts); err != nil {
+		return nil, err
+	}
+	return pts, nil
+}
+
+// checkConvexVertices 检查凸多边形至少有 3 个顶点
+func checkConvexVertices(pts []Point) error {
+	if len(pts) < 3 {
+		return fmt.Errorf("%w: 凸多边形至少需要 3 个顶点, 实际只有 %d 个", ErrDegeneratePolygon, len(pts))
+	}
+	return nil
+}
+
+// CollideCircles 检测两个圆的碰撞
+func CollideCircles(a, b Circle) Collision {
+	d := b.Center.Sub(a.Center)
+	dist := d.Len()
+	depth := a.Radius + b.Radius - dist
+	if depth < 0 {
+		return Collision{}
+	}
+	if dist <= Epsilon {
+		// 圆心重合时方向任意，约定沿 X 轴正方向分离
+		return hit(Point{1, 0}, depth)
+	}
+	return hit(d.Mul(1/dist), depth)
+}
+
+// CollideCircleRect 检测圆 c (作为 a) 与轴对齐矩形 r (作为 b) 的碰撞
+func CollideCircleRect(c Circle, r Rectangle) Collision {
+	b := r.Bounds()
+	closest := Point{
+		X: math.Max(b.Min.X, math.Min(c.Center.X, b.Max.X)),
+		Y: math.Max(b.Min.Y, math.Min(c.Center.Y, b.Max.Y)),
+	}
+	d := closest.Sub(c.Center)
+	if dist := d.Len(); dist > Epsilon {
+		// 圆心在矩形外部：沿圆心到最近点的方向分离
+		if dist > c.Radius {
+			return Collision{}
+		}
+		return hit(d.Mul(1/dist), c.Radius-dist)
+	}
+	// 圆心在矩形内部：选择离圆心最近的那条边，把矩形从该边的方向推开
+	candidates := []struct {
+		normal Point
+		dist   float64
+	}{
+		{Point{1, 0}, c.Center.X - b.Min.X},
+		{Point{-1, 0}, b.Max.X - c.Center.X},
+		{Point{0, 1}, c.Center.Y - b.Min.Y},
+		{Point{0, -1}, b.Max.Y - c.Center.Y},
+	}
+	best := candidates[0]
+	for _, cand := range candidates[1:] {
+		if cand.dist < best.dist {
+			best = cand
+		}
+	}
+	return hit(best.normal, best.dist+c.Radius)
+}
+
+// CollideRects 检测两个轴对齐矩形的碰撞
+func CollideRects(a, b Rectangle) Collision {
+	ba, bb := a.Bounds(), b.Bounds()
+	ox := math.Min(ba.Max.X, bb.Max.X) - math.Max(ba.Min.X, bb.Min.X)
+	oy := math.Min(ba.Max.Y, bb.Max.Y) - math.Max(ba.Min.Y, bb.Min.Y)
+	if ox < 0 || oy < 0 {
+		return Collision{}
+	}
+	d := bb.Center().Sub(ba.Center())
+	if ox < oy {
+		return hit(Point{signOf(d.X), 0}, ox)
+	}
+	return hit(Point{0, signOf(d.Y)}, oy)
+}
+
+func signOf(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
+
+// CollideConvex 使用分离轴定理 (SAT) 检测两个凸多边形的碰撞。
+// 两个凸多边形不相交，当且仅当存在一条以某条边法线为方向的轴，使两者在轴上的投影不重叠；
+// 所有轴上投影重叠量最小的那条轴就是最小平移方向。
+func CollideConvex(a, b []Point) Collision {
+	best := Collision{Depth: math.Inf(1)}
+	for _, axis := range append(edgeNormals(a), edgeNormals(b)...) {
+		minA, maxA := project(a, axis)
+		minB, maxB := project(b, axis)
+		overlap := math.Min(maxA, maxB) - math.Max(minA, minB)
+		if overlap < 0 {
+			return Collision{}
+		}
+		if overlap < best.Depth {
+			best = hit(axis, overlap)
+		}
+	}
+	return orientAway(best, centroidOf(a), centroidOf(b))
+}
+
+// CollideCircleConvex 使用分离轴定理检测圆 c (作为 a) 与凸多边形 pts (作为 b) 的碰撞。
+// 除了多边形各边的法线外，还需要检查"圆心到最近顶点"这条轴。
+// pts 少于 3 个顶点时返回包装了 ErrDegeneratePolygon 的错误。
+func CollideCircleConvex(c Circle, pts []Point) (Collision, error) {
+	if err := checkConvexVertices(pts); err != nil {
+		return Collision{}, err
+	}
+	axes := edgeNormals(pts)
+	nearest := pts[0]
+	for _, p := range pts[1:] {
+		if p.Dist(c.Center) < nearest.Dist(c.Center) {
+			nearest = p
+		}
+	}
+	if d := nearest.Sub(c.Center); d.Len() > Epsilon {
+		axes = append(axes, d.Mul(1/d.Len()))
+	}
+	best := Collision{Depth: math.Inf(1)}
+	for _, axis := range axes {
+		cp := c.Center.Dot(axis)
+		minA, maxA := cp-c.Radius, cp+c.Radius
+		minB, maxB := project(pts, axis)
+		overlap := math.Min(maxA, maxB) - math.Max(minA, minB)
+		if overlap < 0 {
+			return Collision{}, nil
+		}
+		if overlap < best.Depth {
+			best = hit(axis, overlap)
+		}
+	}
+	return orientAway(best, c.Center, centroidOf(pts)), nil
+}
+
+// orientAway 让法线方向从 a 的中心指向 b 的中心
+func orientAway(c Collision, ca, cb Point) Collision {
+	if c.Normal.Dot(cb.Sub(ca)) < 0 {
+		return c.flip()
+	}
+	return c
+}
+
+// edgeNormals 返回多边形每条边的单位法线 (跳过长度为零的边)
+func edgeNormals(pts []Point) []Point {
+	out := make([]Point, 0, len(pts))
+	for i := range pts {
+		e := pts[(i+1)%len(pts)].Sub(pts[i])
+		if l := e.Len(); l > Epsilon {
+			out = append(out, Point{-e.Y / l, e.X / l})
+		}
+	}
+	return out
+}
+
+// project 返回多边形在轴上投影的区间
+func project(pts []Point, axis Point) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, p := range pts {
+		v := p.Dot(axis)
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return lo, hi
+}
+
+func centroidOf(pts []Point) Point {
+	var c Point
+	for _, p := range pts {
+		c = c.Add(p)
+	}
+	return c.Mul(1 / float64(len(pts)))
+}
+
+// Distance 返回点 p 到形状 s 的最短距离，点在形状内部时为 0。
+// 圆、矩形、三角形、正多边形和多边形是精确值；其它形状 (如椭圆) 使用外接框近似。
+func Distance(s Shape, p Point) float64 {
+	switch v := s.(type) {
+	case Circle:
+		return math.Max(0, p.Dist(v.Center)-v.Radius)
+	case Rectangle:
+		return boxDistance(v.Bounds(), p)
+	case Triangle:
+		return polygonDistance(Polygon{Points: v.Vertices()}, p)
+	case RegularPolygon:
+		return polygonDistance(Polygon{Points: v.Vertices()}, p)
+	case Polygon:
+		return polygonDistance(v, p)
+	default:
+		return boxDistance(s.Bounds(), p)
+	}
+}
+
+// boxDistance 返回点到外接框的最短距离
+func boxDistance(b BBox, p Point) float64 {
+	dx := math.Max(0, math.Max(b.Min.X-p.X, p.X-b.Max.X))
+	dy := math.Max(0, math.Max(b.Min.Y-p.Y, p.Y-b.Max.Y))
+	return math.Hypot(dx, dy)
+}
+
+func polygonDistance(pg Polygon, p Point) float64 {
+	if pg.Contains(p) {
+		return 0
+	}
+	best := math.Inf(1)
+	n := len(pg.Points)
+	for i := range n {
+		best = math.Min(best, segmentDistance(p, pg.Points[i], pg.Points[(i+1)%n]))
+	}
+	return best
+}
+
+// segmentDistance 返回点 p 到线段 ab 的最短距离
+func segmentDistance(p, a, b Point) float64 {
+	ab := b.Sub(a)
+	l2 := ab.Dot(ab)
+	if l2 <= Epsilon*Epsilon {
+		return p.Dist(a)
+	}
+	t := math.Max(0, math.Min(1, p.Sub(a).Dot(ab)/l2))
+	return p.Dist(a.Add(ab.Mul(t)))
+}
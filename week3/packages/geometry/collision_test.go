@@ -0,0 +1,46 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// 顶点不足 3 个的凸多边形不能参与碰撞检测，应该返回错误而不是 panic
+func TestCollideTooFewVertices(t *testing.T) {
+	c := Circle{Center: Pt(0, 0), Radius: 1}
+	tests := []struct {
+		name string
+		a, b Shape
+	}{
+		{"圆与零边正多边形", c, RegularPolygon{Sides: 0, Radius: 1}},
+		{"零边正多边形与圆", RegularPolygon{Sides: 0, Radius: 1}, c},
+		{"圆与单边正多边形", c, RegularPolygon{Sides: 1, Radius: 1}},
+		{"正多边形之间", RegularPolygon{Sides: 2, Radius: 1}, Triangle{Pt(0, 0), Pt(1, 0), Pt(0, 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Collide(tt.a, tt.b); !errors.Is(err, ErrDegeneratePolygon) {
+				t.Errorf("Collide error = %v, want ErrDegeneratePolygon", err)
+			}
+		})
+	}
+	if _, err := CollideCircleConvex(c, nil); !errors.Is(err, ErrDegeneratePolygon) {
+		t.Errorf("CollideCircleConvex(nil) error = %v, want ErrDegeneratePolygon", err)
+	}
+}
+
+func TestCollideCircleConvex(t *testing.T) {
+	square := []Point{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	col, err := CollideCircleConvex(Circle{Center: Pt(-0.5, 1), Radius: 1}, square)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 圆心在正方形左边 0.5 处，穿透 0.5，法线从圆指向正方形 (+x)
+	if !col.Intersects || math.Abs(col.Depth-0.5) > 1e-9 || col.Normal != Pt(1, 0) {
+		t.Errorf("CollideCircleConvex = %+v, want 穿透 0.5, 法线 (1, 0)", col)
+	}
+	if col, _ := CollideCircleConvex(Circle{Center: Pt(-2, 1), Radius: 1}, square); col.Intersects {
+		t.Errorf("相离的圆与正方形被判为相交: %+v", col)
+	}
+}
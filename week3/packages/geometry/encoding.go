@@ -0,0 +1,123 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Geometry 是可以编码为 WKT (Well-Known Text) 的几何对象，
+// ParseWKT 和 ParseGeoJSON 的返回值也是这个类型 (具体类型为 Point、Polygon、Region 或 MultiPolygon)。
+type Geometry interface {
+	WKT() string
+}
+
+var (
+	_ Geometry = Point{}
+	_ Geometry = Polygon{}
+	_ Geometry = Rectangle{}
+	_ Geometry = Circle{}
+	_ Geometry = Region{}
+	_ Geometry = MultiPolygon{}
+)
+
+// ParseError 是解析 WKT 或 GeoJSON 时的错误，Offset 是出错位置在输入中的字节偏移量。
+type ParseError struct {
+	Format string // "WKT" 或 "GeoJSON"
+	Offset int
+	Msg    string
+	Err    error // 底层错误，可能为 nil
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s 解析错误 (偏移 %d): %s", e.Format, e.Offset, e.Msg)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// encodableRings 返回区域去掉重复顶点后的所有环。
+// 面积为零的环写出去之后 ParseWKT / ParseGeoJSON 无法读回，所以编码前就返回包装了 ErrDegeneratePolygon 的错误。
+func encodableRings(r Region, format string) ([]Polygon, error) {
+	rings := r.rings()
+	for i, ring := range rings {
+		pg := Polygon{Points: dedupe(ring.Points)}
+		if len(pg.Points) < 3 || pg.Area() <= Epsilon {
+			return nil, fmt.Errorf("%w: 第 %d 个环的面积为零, 无法编码为 %s", ErrDegeneratePolygon, i, format)
+		}
+		rings[i] = pg
+	}
+	return rings, nil
+}
+
+// polygonFromRings 用解析得到的环创建多边形，WKT 和 GeoJSON 共用。
+// 第一个环是外边界，其余的环是洞：只有一个环时返回 Polygon (保持原有的顶点顺序)，否则返回 Region。
+// offs 是每个环在输入中的偏移量，用于报告校验失败的位置。
+func polygonFromRings(format string, rings [][]Point, offs []int) (Geometry, error) {
+	polys := make([]Polygon, len(rings))
+	for i, pts := range rings {
+		pg, err := NewPolygon(pts)
+		if err != nil {
+			return nil, &ParseError{Format: format, Offset: offs[i], Msg: err.Error(), Err: err}
+		}
+		polys[i] = pg
+	}
+	if len(polys) == 1 {
+		return polys[0], nil
+	}
+	r, err := NewRegion(polys[0], polys[1:]...)
+	if err != nil {
+		return nil, &ParseError{Format: format, Offset: offs[0], Msg: err.Error(), Err: err}
+	}
+	return r, nil
+}
+
+// asRegion 把 polygonFromRings 的结果统一为 Region，用于组成 MultiPolygon
+func asRegion(g Geometry) Region {
+	if r, ok := g.(Region); ok {
+		return r
+	}
+	return Region{Outer: orient(g.(Polygon), CounterClockwise)}
+}
+
+// AsRectangle 判断多边形是否是一个轴对齐矩形，是则返回对应的 Rectangle。
+func (pg Polygon) AsRectangle() (Rectangle, bool) {
+	pts := dedupe(pg.Points)
+	if len(pts) != 4 {
+		return Rectangle{}, false
+	}
+	b := BoxOf(pts...)
+	for _, p := range pts {
+		onX := math.Abs(p.X-b.Min.X) <= Epsilon || math.Abs(p.X-b.Max.X) <= Epsilon
+		onY := math.Abs(p.Y-b.Min.Y) <= Epsilon || math.Abs(p.Y-b.Max.Y) <= Epsilon
+		if !onX || !onY {
+			return Rectangle{}, false
+		}
+	}
+	// 四个顶点都在外接框角上时，只有面积等于外接框面积才是真正的矩形 (排除"蝴蝶结"形状)
+	if b.Area() <= Epsilon || math.Abs(Polygon{Points: pts}.Area()-b.Area()) > Epsilon*math.Max(1, b.Area()) {
+		return Rectangle{}, false
+	}
+	return Rectangle{Origin: b.Min, Width: b.Width(), Height: b.Height()}, true
+}
+
+// AsCircle 判断多边形的顶点是否都落在同一个圆上 (例如由 ToPolygon 近似得到的圆)，
+// 是则返回该圆。顶点少于 8 个的多边形不会被当作圆。
+func (pg Polygon) AsCircle() (Circle, bool) {
+	pts := dedupe(pg.Points)
+	if len(pts) < 8 {
+		return Circle{}, false
+	}
+	c := centroidOf(pts)
+	var r float64
+	for _, p := range pts {
+		r += p.Dist(c)
+	}
+	r /= float64(len(pts))
+	for _, p := range pts {
+		if math.Abs(p.Dist(c)-r) > 1e-6*math.Max(1, r) {
+			return Circle{}, false
+		}
+	}
+	return Circle{Center: c, Radius: r}, true
+}
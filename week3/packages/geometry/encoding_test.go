@@ -0,0 +1,181 @@
+package geometry
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// 几何类型没有实现 json.Marshaler，包含它们的结构体保持普通的 JSON 格式
+func TestPlainJSONUnaffected(t *testing.T) {
+	data, err := json.Marshal(BBox{Min: Pt(0, 0), Max: Pt(1, 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Min":{"X":0,"Y":0},"Max":{"X":1,"Y":2}}`; string(data) != want {
+		t.Errorf("json.Marshal(BBox) = %s, want %s", data, want)
+	}
+}
+
+func TestGeoJSONRoundTrip(t *testing.T) {
+	outer, _ := NewRectangle(10, 10)
+	hole, _ := NewRectangleAt(Pt(2, 2), 3, 3)
+	outerPg, _ := ToPolygon(outer)
+	holePg, _ := ToPolygon(hole)
+	region, err := NewRegion(outerPg, holePg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	far, _ := NewRectangleAt(Pt(20, 0), 1, 1)
+	circle, _ := NewCircle(Pt(0, 0), 2)
+
+	for _, s := range []Shape{outer, circle, region, MultiPolygon{region, asRegion(Polygon{Points: far.Vertices()})}} {
+		want := s.Area()
+		if c, ok := s.(Circle); ok {
+			pg, _ := ToPolygon(c) // 圆按 CircleSegments 边形近似
+			want = pg.Area()
+		}
+		data, err := MarshalGeoJSON(s)
+		if err != nil {
+			t.Fatalf("MarshalGeoJSON(%T): %v", s, err)
+		}
+		g, err := ParseGeoJSON(data)
+		if err != nil {
+			t.Fatalf("ParseGeoJSON(%s): %v", data, err)
+		}
+		if got := g.(Shape).Area(); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%T: 面积 %v, want %v", s, got, want)
+		}
+
+		back, err := ParseWKT(g.WKT())
+		if err != nil {
+			t.Fatalf("ParseWKT(%s): %v", g.WKT(), err)
+		}
+		if got := back.(Shape).Area(); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%T 的 WKT: 面积 %v, want %v", s, got, want)
+		}
+	}
+
+	if _, ok := mustParseGeoJSON(t, region).(Region); !ok {
+		t.Error("带洞的 Polygon 应该解码为 Region")
+	}
+	if _, ok := mustParseGeoJSON(t, outer).(Polygon); !ok {
+		t.Error("不带洞的 Polygon 应该解码为 Polygon")
+	}
+}
+
+func mustParseGeoJSON(t *testing.T, s Shape) Geometry {
+	t.Helper()
+	data, err := MarshalGeoJSON(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := ParseGeoJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// 构造函数允许面积为零的形状，但它们无法编码为合法的 GeoJSON 多边形，编码时就应该报错
+func TestMarshalGeoJSONDegenerate(t *testing.T) {
+	flat, _ := NewRectangle(0, 5)
+	dot, _ := NewCircle(Pt(1, 1), 0)
+	for _, s := range []Shape{flat, dot} {
+		if _, err := MarshalGeoJSON(s); !errors.Is(err, ErrDegeneratePolygon) {
+			t.Errorf("MarshalGeoJSON(%v) error = %v, want ErrDegeneratePolygon", s, err)
+		}
+	}
+}
+
+// 与 ParseWKT 一样，ParseGeoJSON 拒绝几何对象之后的多余内容
+func TestParseGeoJSONTrailingData(t *testing.T) {
+	const point = `{"type":"Point","coordinates":[1,2]}`
+	for _, tc := range []struct {
+		src string
+		off int
+	}{
+		{point + " garbage", len(point) + 1},
+		{point + point, len(point)},
+		{point + " ,", len(point) + 1},
+	} {
+		_, err := ParseGeoJSON([]byte(tc.src))
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Offset != tc.off {
+			t.Errorf("ParseGeoJSON(%q) error = %v, want 偏移 %d 处的 ParseError", tc.src, err, tc.off)
+		}
+	}
+	if _, err := ParseGeoJSON([]byte(point + " \n")); err != nil {
+		t.Errorf("末尾的空白应该被接受: %v", err)
+	}
+	if _, err := ParseWKT("POINT (1 2) garbage"); err == nil {
+		t.Error("ParseWKT 应该拒绝多余的内容")
+	}
+}
+
+// GeoJSON 包装类型可以直接作为结构体字段参与 encoding/json 的编解码
+func TestGeoJSONWrapper(t *testing.T) {
+	type feature struct {
+		Name     string  `json:"name"`
+		Geometry GeoJSON `json:"geometry"`
+		Box      BBox    `json:"box"`
+	}
+	rect, _ := NewRectangle(2, 3)
+	for _, g := range []Geometry{Pt(1, 2), rect} {
+		in := feature{Name: "f", Geometry: GeoJSON{g}, Box: BBox{Max: Pt(1, 1)}}
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("json.Marshal(%T): %v", g, err)
+		}
+		if !strings.Contains(string(data), `"box":{"Min":{"X":0,"Y":0},"Max":{"X":1,"Y":1}}`) {
+			t.Errorf("BBox 的 JSON 格式不应该改变: %s", data)
+		}
+		var out feature
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("json.Unmarshal(%s): %v", data, err)
+		}
+		if out.Geometry.WKT() != g.WKT() {
+			t.Errorf("往返之后 = %s, want %s", out.Geometry.WKT(), g.WKT())
+		}
+	}
+
+	if _, err := json.Marshal(GeoJSON{}); err == nil {
+		t.Error("空的 GeoJSON 应该编码失败")
+	}
+	var g GeoJSON
+	if err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`), &g); err == nil {
+		t.Error("不支持的几何类型应该解码失败")
+	}
+}
+
+// 面积为零的形状在 WKT 和 GeoJSON 中都应该在编码时报错，能编码的形状都能原样读回
+func TestMarshalWKT(t *testing.T) {
+	flat, _ := NewRectangle(0, 5)
+	dot, _ := NewCircle(Pt(1, 1), 0)
+	for _, g := range []Geometry{flat, dot, MultiPolygon{{Outer: Polygon{Points: flat.Vertices()}}}} {
+		if _, err := MarshalWKT(g); !errors.Is(err, ErrDegeneratePolygon) {
+			t.Errorf("MarshalWKT(%v) error = %v, want ErrDegeneratePolygon", g, err)
+		}
+	}
+
+	rect, _ := NewRectangleAt(Pt(1, 2), 3, 4)
+	circle, _ := NewCircle(Pt(0, 0), 2)
+	for _, g := range []Geometry{Pt(1.5, -2), rect, circle, Polygon{Points: []Point{{0, 0}, {4, 0}, {0, 3}}}} {
+		s, err := MarshalWKT(g)
+		if err != nil {
+			t.Fatalf("MarshalWKT(%v): %v", g, err)
+		}
+		if s != g.WKT() {
+			t.Errorf("MarshalWKT = %q, want 与 WKT() 相同的 %q", s, g.WKT())
+		}
+		back, err := ParseWKT(s)
+		if err != nil {
+			t.Fatalf("ParseWKT(%q): %v", s, err)
+		}
+		if back.WKT() != s {
+			t.Errorf("往返之后 = %q, want %q", back.WKT(), s)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package geometry
+
+import (
+	"math"
+	"math/big"
+)
+
+// 精确谓词
+//
+// Orient、InCircle 等函数用 float64 计算行列式，再与 Epsilon 比较，
+// 在点几乎共线或几乎共圆时，舍入误差可能让结果出错，甚至让不同的判断互相矛盾。
+//
+// 每个有限的 float64 都可以被一个有理数精确表示，所以把坐标转换为 math/big.Rat 后再做运算，
+// 得到的就是输入坐标下的精确结果。big.Rat 运算比较慢，这里的函数先用浮点数计算，
+// 只有当结果落在误差界 (参考 Shewchuk 的自适应精度谓词) 之内、符号无法确定时才改用有理数重新计算，
+// 所以在一般情况下几乎没有额外开销。
+//
+// 注意：精确谓词不使用 Epsilon，坐标上的任何微小差别都会被当作真实的差别。
+
+// 浮点运算的单位舍入误差 2^-53，以及 Shewchuk 给出的误差界系数
+const (
+	roundoff       = 1.0 / (1 << 53)
+	orientErrBound = (3 + 16*roundoff) * roundoff
+	circleErrBound = (10 + 96*roundoff) * roundoff
+)
+
+// rat 把 float64 精确转换为有理数，调用方需保证 f 是有限值
+func rat(f float64) *big.Rat {
+	return new(big.Rat).SetFloat64(f)
+}
+
+func allFinite(pts ...Point) bool {
+	for _, p := range pts {
+		if !p.valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// OrientExact 与 Orient 相同，判断 a→b→c 的转向，但结果对输入坐标是精确的：
+// 只有三点真正共线时才返回 Collinear。
+func OrientExact(a, b, c Point) Orientation {
+	detLeft := (a.X - c.X) * (b.Y - c.Y)
+	detRight := (a.Y - c.Y) * (b.X - c.X)
+	det := detLeft - detRight
+	switch bound := orientErrBound * (math.Abs(detLeft) + math.Abs(detRight)); {
+	case det > bound:
+		return CounterClockwise
+	case -det > bound:
+		return Clockwise
+	}
+	if !allFinite(a, b, c) {
+		return sign(det)
+	}
+	bx, by := rat(b.X), rat(b.Y)
+	ax, ay := rat(a.X), rat(a.Y)
+	cx, cy := rat(c.X), rat(c.Y)
+	// (b - a) × (c - a)
+	ux, uy := new(big.Rat).Sub(bx, ax), new(big.Rat).Sub(by, ay)
+	vx, vy := new(big.Rat).Sub(cx, ax), new(big.Rat).Sub(cy, ay)
+	l := new(big.Rat).Mul(ux, vy)
+	r := new(big.Rat).Mul(uy, vx)
+	return Orientation(l.Cmp(r))
+}
+
+// InCircleExact 精确判断点 d 与三角形 abc (逆时针) 外接圆的关系：
+// 返回 1 表示在圆内，-1 表示在圆外，0 表示四点恰好共圆。
+// abc 为顺时针时结果的符号相反。
+func InCircleExact(a, b, c, d Point) int {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+	alift, blift, clift := adx*adx+ady*ady, bdx*bdx+bdy*bdy, cdx*cdx+cdy*cdy
+	det := alift*(bdx*cdy-cdx*bdy) + blift*(cdx*ady-adx*cdy) + clift*(adx*bdy-bdx*ady)
+	permanent := (math.Abs(bdx*cdy)+math.Abs(cdx*bdy))*alift +
+		(math.Abs(cdx*ady)+math.Abs(adx*cdy))*blift +
+		(math.Abs(adx*bdy)+math.Abs(bdx*ady))*clift
+	if bound := circleErrBound * permanent; det > bound || -det > bound {
+		return int(math.Copysign(1, det))
+	}
+	if !allFinite(a, b, c, d) {
+		return int(sign(det))
+	}
+	dx, dy := rat(d.X), rat(d.Y)
+	diff := func(p Point) (*big.Rat, *big.Rat, *big.Rat) {
+		x := new(big.Rat).Sub(rat(p.X), dx)
+		y := new(big.Rat).Sub(rat(p.Y), dy)
+		lift := new(big.Rat).Add(new(big.Rat).Mul(x, x), new(big.Rat).Mul(y, y))
+		return x, y, lift
+	}
+	// cross2 返回 p×q = px·qy - py·qx
+	cross2 := func(px, py, qx, qy *big.Rat) *big.Rat {
+		return new(big.Rat).Sub(new(big.Rat).Mul(px, qy), new(big.Rat).Mul(py, qx))
+	}
+	ax, ay, al := diff(a)
+	bx, by, bl := diff(b)
+	cx, cy, cl := diff(c)
+	sum := new(big.Rat).Mul(al, cross2(bx, by, cx, cy))
+	sum.Add(sum, new(big.Rat).Mul(bl, cross2(cx, cy, ax, ay)))
+	sum.Add(sum, new(big.Rat).Mul(cl, cross2(ax, ay, bx, by)))
+	return sum.Sign()
+}
+
+// SegmentsIntersectExact 与 SegmentsIntersect 相同，但使用精确谓词，
+// 端点恰好落在另一条线段上时才算接触。
+func SegmentsIntersectExact(p1, p2, q1, q2 Point) bool {
+	o1, o2 := OrientExact(p1, p2, q1), OrientExact(p1, p2, q2)
+	o3, o4 := OrientExact(q1, q2, p1), OrientExact(q1, q2, p2)
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	return (o1 == Collinear && inRange(p1, p2, q1)) ||
+		(o2 == Collinear && inRange(p1, p2, q2)) ||
+		(o3 == Collinear && inRange(q1, q2, p1)) ||
+		(o4 == Collinear && inRange(q1, q2, p2))
+}
+
+// inRange 判断 p 是否在以 ab 为对角线的矩形内。浮点数的比较本身是精确的，不需要容差。
+func inRange(a, b, p Point) bool {
+	return p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) &&
+		p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// WindingNumberExact 与 WindingNumber 相同，但使用 OrientExact 判断点在边的哪一侧
+func (pg Polygon) WindingNumberExact(p Point) int {
+	wn := 0
+	n := len(pg.Points)
+	for i := range n {
+		a, b := pg.Points[i], pg.Points[(i+1)%n]
+		if a.Y <= p.Y {
+			if b.Y > p.Y && OrientExact(a, b, p) == CounterClockwise {
+				wn++
+			}
+		} else if b.Y <= p.Y && OrientExact(a, b, p) == Clockwise {
+			wn--
+		}
+	}
+	return wn
+}
+
+// OnBoundaryExact 精确判断点 p 是否落在多边形的某条边上
+func (pg Polygon) OnBoundaryExact(p Point) bool {
+	n := len(pg.Points)
+	for i := range n {
+		a, b := pg.Points[i], pg.Points[(i+1)%n]
+		if OrientExact(a, b, p) == Collinear && inRange(a, b, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsExact 精确判断点 p 是否在多边形内部或边界上 (非零环绕规则)
+func (pg Polygon) ContainsExact(p Point) bool {
+	return pg.OnBoundaryExact(p) || pg.WindingNumberExact(p) != 0
+}
+
+// SignedAreaExact 用有理数计算鞋带公式，返回精确的有符号面积 (逆时针为正)
+func (pg Polygon) SignedAreaExact() *big.Rat {
+	sum := new(big.Rat)
+	n := len(pg.Points)
+	for i := range n {
+		a, b := pg.Points[i], pg.Points[(i+1)%n]
+		sum.Add(sum, new(big.Rat).Mul(rat(a.X), rat(b.Y)))
+		sum.Sub(sum, new(big.Rat).Mul(rat(b.X), rat(a.Y)))
+	}
+	return sum.Quo(sum, big.NewRat(2, 1))
+}
+
+// AreaExact 返回多边形的精确面积
+func (pg Polygon) AreaExact() *big.Rat {
+	return new(big.Rat).Abs(pg.SignedAreaExact())
+}
+
+// AreaExact 返回矩形的精确面积 Width × Height。
+// 与 Area 不同，乘积不会被舍入，可以和 Polygon.AreaExact 的结果直接用 Cmp 比较。
+func (r Rectangle) AreaExact() *big.Rat {
+	return new(big.Rat).Mul(rat(r.Width), rat(r.Height))
+}
@@ -0,0 +1,190 @@
+package geometry
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// up、down 返回 v 的下一个、上一个 float64
+func up(v float64) float64   { return math.Nextafter(v, math.Inf(1)) }
+func down(v float64) float64 { return math.Nextafter(v, math.Inf(-1)) }
+
+// orientUndecided 判断 OrientExact 的浮点过滤能否确定符号，不能时会改用 big.Rat 重新计算
+func orientUndecided(a, b, c Point) bool {
+	detLeft := (a.X - c.X) * (b.Y - c.Y)
+	detRight := (a.Y - c.Y) * (b.X - c.X)
+	return math.Abs(detLeft-detRight) <= orientErrBound*(math.Abs(detLeft)+math.Abs(detRight))
+}
+
+func TestOrientExact(t *testing.T) {
+	a, b := Pt(0.5, 0.5), Pt(12, 12)
+	tests := []struct {
+		name     string
+		c        Point
+		want     Orientation
+		fallback bool // 浮点过滤无法确定，必须用有理数计算
+	}{
+		{"明显在左侧", Pt(0, 1), CounterClockwise, false},
+		{"明显在右侧", Pt(1, 0), Clockwise, false},
+		{"恰好共线", Pt(24, 24), Collinear, true},
+		// c 只偏离直线 y = x 一个 ulp，浮点行列式的舍入误差比真实值还大
+		{"上方一个 ulp", Pt(24, up(24)), CounterClockwise, true},
+		{"下方一个 ulp", Pt(24, down(24)), Clockwise, true},
+		{"左侧一个 ulp", Pt(down(24), 24), CounterClockwise, true},
+		// 0.1、0.2、0.3 都不能被 float64 精确表示，但相同的舍入让三点仍然精确共线
+		{"不能精确表示的坐标", Pt(0.3, 0.3), Collinear, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orientUndecided(a, b, tt.c); got != tt.fallback {
+				t.Fatalf("浮点过滤无法确定 = %v, want %v", got, tt.fallback)
+			}
+			if got := OrientExact(a, b, tt.c); got != tt.want {
+				t.Errorf("OrientExact(%v, %v, %v) = %v, want %v", a, b, tt.c, got, tt.want)
+			}
+			// 交换 a、b 方向相反，轮换三个点方向不变；浮点计算常常违反这两条性质
+			if got := OrientExact(b, a, tt.c); got != -tt.want {
+				t.Errorf("交换 a、b 之后 = %v, want %v", got, -tt.want)
+			}
+			if got := OrientExact(tt.c, a, b); got != tt.want {
+				t.Errorf("轮换之后 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	// 带容差的 Orient 把只差一个 ulp 的点当作共线
+	if got := Orient(a, b, Pt(24, up(24))); got != Collinear {
+		t.Errorf("Orient = %v, want 共线 (在 Epsilon 之内)", got)
+	}
+}
+
+// 在 (0.5, 0.5) 附近的 ulp 网格上，OrientExact 必须与按定义用有理数直接算出的结果一致
+func TestOrientExactGrid(t *testing.T) {
+	b, c := Pt(12, 12), Pt(24, 24)
+	ulp := math.Nextafter(0.5, 1) - 0.5
+	undecided := 0
+	for i := range 64 {
+		for j := range 64 {
+			a := Pt(0.5+float64(i)*ulp, 0.5+float64(j)*ulp)
+			// (a - c) × (b - c)，与 OrientExact 中有理数分支的展开方式不同
+			ax, ay := new(big.Rat).Sub(rat(a.X), rat(c.X)), new(big.Rat).Sub(rat(a.Y), rat(c.Y))
+			bx, by := new(big.Rat).Sub(rat(b.X), rat(c.X)), new(big.Rat).Sub(rat(b.Y), rat(c.Y))
+			want := Orientation(new(big.Rat).Mul(ax, by).Cmp(new(big.Rat).Mul(ay, bx)))
+			if got := OrientExact(a, b, c); got != want {
+				t.Fatalf("OrientExact(%v, b, c) = %v, want %v", a, got, want)
+			}
+			if orientUndecided(a, b, c) {
+				undecided++
+			}
+		}
+	}
+	if undecided == 0 {
+		t.Error("网格中没有需要有理数计算的点，测试没有覆盖回退分支")
+	}
+}
+
+func TestInCircleExact(t *testing.T) {
+	// 勾股数 (3, 4, 5) 给出半径为 5 的圆上的整数点，平移到 1e6 附近后仍然可以被精确表示
+	const o = 1e6
+	a, b, c := Pt(o+5, o), Pt(o+3, o+4), Pt(o-4, o+3) // 逆时针
+	tests := []struct {
+		name string
+		d    Point
+		want int
+	}{
+		{"圆心", Pt(o, o), 1},
+		{"远处", Pt(o+10, o+10), -1},
+		{"恰好共圆", Pt(o, o-5), 0},
+		{"另一个共圆点", Pt(o-3, o-4), 0},
+		{"向内一个 ulp", Pt(o, up(o-5)), 1},
+		{"向外一个 ulp", Pt(o, down(o-5)), -1},
+		{"向外一个 ulp (x 方向)", Pt(up(o+4), o-3), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InCircleExact(a, b, c, tt.d); got != tt.want {
+				t.Errorf("InCircleExact(%v) = %d, want %d", tt.d, got, tt.want)
+			}
+			// 顺时针排列时符号相反，轮换三个点结果不变
+			if got := InCircleExact(b, a, c, tt.d); got != -tt.want {
+				t.Errorf("顺时针排列 = %d, want %d", got, -tt.want)
+			}
+			if got := InCircleExact(c, a, b, tt.d); got != tt.want {
+				t.Errorf("轮换之后 = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegmentsIntersectExact(t *testing.T) {
+	p1, p2 := Pt(0.5, 0.5), Pt(24, 24)
+	tests := []struct {
+		name   string
+		q1, q2 Point
+		want   bool
+	}{
+		{"交叉", Pt(0, 24), Pt(24, 0), true},
+		{"端点恰好落在线段上", Pt(12, 12), Pt(12, 13), true},
+		{"端点在线段上方一个 ulp", Pt(12, up(12)), Pt(12, 13), false},
+		{"端点在线段下方一个 ulp, 另一端在上方", Pt(12, down(12)), Pt(12, 13), true},
+		{"共线且重叠", Pt(1, 1), Pt(30, 30), true},
+		{"共线但不重叠", Pt(25, 25), Pt(30, 30), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SegmentsIntersectExact(p1, p2, tt.q1, tt.q2); got != tt.want {
+				t.Errorf("SegmentsIntersectExact = %v, want %v", got, tt.want)
+			}
+			if got := SegmentsIntersectExact(tt.q1, tt.q2, p1, p2); got != tt.want {
+				t.Errorf("交换两条线段之后 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsExact(t *testing.T) {
+	// 斜边在 y = x 上的三角形，斜边附近的点只能靠精确谓词区分
+	tri := Polygon{Points: []Point{{0.5, 0.5}, {24, 0.5}, {24, 24}}}
+	tests := []struct {
+		name                 string
+		p                    Point
+		contains, onBoundary bool
+	}{
+		{"内部", Pt(20, 10), true, false},
+		{"斜边上", Pt(12, 12), true, true},
+		{"斜边内侧一个 ulp", Pt(12, down(12)), true, false},
+		{"斜边外侧一个 ulp", Pt(12, up(12)), false, false},
+		{"顶点", Pt(24, 24), true, true},
+		{"底边延长线上", Pt(25, 0.5), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tri.ContainsExact(tt.p); got != tt.contains {
+				t.Errorf("ContainsExact(%v) = %v, want %v", tt.p, got, tt.contains)
+			}
+			if got := tri.OnBoundaryExact(tt.p); got != tt.onBoundary {
+				t.Errorf("OnBoundaryExact(%v) = %v, want %v", tt.p, got, tt.onBoundary)
+			}
+		})
+	}
+}
+
+func TestAreaExact(t *testing.T) {
+	// 0.1 不能被精确表示，精确面积是 rat(0.1)² / 2，而不是 0.005
+	tri := Polygon{Points: []Point{{0, 0}, {0.1, 0}, {0, 0.1}}}
+	want := new(big.Rat).Mul(rat(0.1), rat(0.1))
+	want.Quo(want, big.NewRat(2, 1))
+	if got := tri.SignedAreaExact(); got.Cmp(want) != 0 {
+		t.Errorf("SignedAreaExact = %v, want %v", got.FloatString(20), want.FloatString(20))
+	}
+	cw := Polygon{Points: []Point{{0, 0}, {0, 0.1}, {0.1, 0}}}
+	if got := cw.SignedAreaExact(); got.Sign() >= 0 || cw.AreaExact().Cmp(want) != 0 {
+		t.Errorf("顺时针的 SignedAreaExact = %v, AreaExact = %v", got, cw.AreaExact())
+	}
+
+	// Rectangle.AreaExact 不经过顶点坐标，0.5 这样的二进制小数得到精确的 3/2
+	sq, _ := NewRectangle(3, 0.5)
+	if got := sq.AreaExact(); got.Cmp(big.NewRat(3, 2)) != 0 {
+		t.Errorf("3×0.5 的 AreaExact = %v, want 3/2", got)
+	}
+}
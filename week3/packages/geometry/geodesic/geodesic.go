@@ -0,0 +1,202 @@
+// Package geodesic 提供地球表面 (经纬度坐标) 上的距离、方位角和面积计算。
+//
+// geometry 包和 week2/methods 中的 Point 都使用平面直角坐标，直接用勾股定理求距离；
+// 但经纬度是球面上的角度，一度经度对应的实际距离随纬度变化，必须使用球面或椭球面公式：
+//   - Haversine: 把地球看作球体，计算简单，误差约 0.5%
+//   - Vincenty:  基于 WGS-84 椭球的迭代公式，精度可达毫米级
+package geodesic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// 地球参数，单位为米
+const (
+	EarthRadius = 6371008.8 // 平均半径 (IUGG)，用于球面公式
+
+	wgs84A = 6378137.0             // WGS-84 长半轴
+	wgs84F = 1 / 298.257223563     // WGS-84 扁率
+	wgs84B = wgs84A * (1 - wgs84F) // WGS-84 短半轴
+)
+
+// ErrNoConvergence 表示 Vincenty 迭代没有收敛，通常发生在两点几乎位于地球两端 (对跖点) 时。
+// 这种情况可以退而使用 Haversine 的结果。
+var ErrNoConvergence = errors.New("vincenty 公式迭代未收敛")
+
+// LatLng 是一个经纬度坐标，单位为度。
+// 纬度范围 [-90, 90]，北纬为正；经度范围 [-180, 180]，东经为正。
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// NewLatLng 创建一个经纬度坐标，并检查取值范围
+func NewLatLng(lat, lng float64) (LatLng, error) {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return LatLng{}, fmt.Errorf("纬度必须在 [-90, 90] 之间, 得到 %v", lat)
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return LatLng{}, fmt.Errorf("经度必须在 [-180, 180] 之间, 得到 %v", lng)
+	}
+	return LatLng{Lat: lat, Lng: lng}, nil
+}
+
+// String 实现 fmt.Stringer，例如 "(39.904200°N, 116.407400°E)"
+func (p LatLng) String() string {
+	ns, ew := 'N', 'E'
+	if p.Lat < 0 {
+		ns = 'S'
+	}
+	if p.Lng < 0 {
+		ew = 'W'
+	}
+	return fmt.Sprintf("(%.6f°%c, %.6f°%c)", math.Abs(p.Lat), ns, math.Abs(p.Lng), ew)
+}
+
+// radians 返回以弧度表示的纬度和经度
+func (p LatLng) radians() (phi, lambda float64) {
+	return p.Lat * math.Pi / 180, p.Lng * math.Pi / 180
+}
+
+func fromRadians(phi, lambda float64) LatLng {
+	return LatLng{Lat: phi * 180 / math.Pi, Lng: normalizeLng(lambda * 180 / math.Pi)}
+}
+
+// normalizeLng 把经度规范到 [-180, 180)
+func normalizeLng(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// wrapAngle 把弧度差规范到 [-π, π]，处理跨越 180° 经线的情况
+func wrapAngle(d float64) float64 {
+	for d > math.Pi {
+		d -= 2 * math.Pi
+	}
+	for d < -math.Pi {
+		d += 2 * math.Pi
+	}
+	return d
+}
+
+// Haversine 按球面模型计算两点间的大圆距离 (米)。
+//
+// 半正矢公式: a = sin²(Δφ/2) + cos φ1 · cos φ2 · sin²(Δλ/2)，d = 2R · asin(√a)。
+// 与余弦定理相比，它在两点很近时也能保持数值稳定。
+func Haversine(a, b LatLng) float64 {
+	phi1, lambda1 := a.radians()
+	phi2, lambda2 := b.radians()
+	sinDPhi := math.Sin((phi2 - phi1) / 2)
+	sinDLambda := math.Sin((lambda2 - lambda1) / 2)
+	h := sinDPhi*sinDPhi + math.Cos(phi1)*math.Cos(phi2)*sinDLambda*sinDLambda
+	return 2 * EarthRadius * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// Vincenty 按 WGS-84 椭球模型计算两点间的测地线距离 (米)，精度约 0.5 毫米。
+// 两点接近对跖点时迭代可能不收敛，此时返回 ErrNoConvergence。
+func Vincenty(a, b LatLng) (float64, error) {
+	phi1, lambda1 := a.radians()
+	phi2, lambda2 := b.radians()
+	L := wrapAngle(lambda2 - lambda1)
+	// 归化纬度 (reduced latitude)
+	U1 := math.Atan((1 - wgs84F) * math.Tan(phi1))
+	U2 := math.Atan((1 - wgs84F) * math.Tan(phi2))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for range 200 {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		t1 := cosU2 * sinLambda
+		t2 := cosU1*sinU2 - sinU1*cosU2*cosLambda
+		sinSigma = math.Sqrt(t1*t1 + t2*t2)
+		if sinSigma == 0 {
+			return 0, nil // 两点重合
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0 // 两点都在赤道上时 cos²α = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*wgs84F*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda) > math.Pi {
+			break // λ 超出范围说明已经发散
+		}
+		if math.Abs(lambda-prev) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, fmt.Errorf("%w: %v -> %v", ErrNoConvergence, a, b)
+	}
+
+	uSq := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	return wgs84B * A * (sigma - deltaSigma), nil
+}
+
+// InitialBearing 返回从 a 沿大圆出发前往 b 时的初始方位角，单位为度，
+// 范围 [0, 360)：0 为正北，90 为正东。沿大圆行进时方位角会逐渐变化。
+func InitialBearing(a, b LatLng) float64 {
+	phi1, lambda1 := a.radians()
+	phi2, lambda2 := b.radians()
+	sinDL, cosDL := math.Sincos(lambda2 - lambda1)
+	y := sinDL * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*cosDL
+	theta := math.Atan2(y, x) * 180 / math.Pi
+	return math.Mod(theta+360, 360)
+}
+
+// Destination 返回从 start 出发，沿初始方位角 bearing (度) 走过 distance 米后到达的点 (球面模型)
+func Destination(start LatLng, bearing, distance float64) LatLng {
+	phi1, lambda1 := start.radians()
+	theta := bearing * math.Pi / 180
+	delta := distance / EarthRadius // 角距离
+	sinPhi1, cosPhi1 := math.Sincos(phi1)
+	sinDelta, cosDelta := math.Sincos(delta)
+	sinTheta, cosTheta := math.Sincos(theta)
+
+	sinPhi2 := sinPhi1*cosDelta + cosPhi1*sinDelta*cosTheta
+	phi2 := math.Asin(math.Max(-1, math.Min(1, sinPhi2)))
+	lambda2 := lambda1 + math.Atan2(sinTheta*sinDelta*cosPhi1, cosDelta-sinPhi1*sinPhi2)
+	return fromRadians(phi2, lambda2)
+}
+
+// Area 计算由 ring 围成的球面多边形的面积 (平方米)。
+// ring 的首尾可以重复也可以不重复，顶点顺序不影响结果；少于 3 个顶点时返回 0。
+//
+// 使用 Chamberlain & Duquette 的近似公式：
+// A = R²/2 · |Σ (λ[i+1] - λ[i]) · (2 + sin φ[i] + sin φ[i+1])|，
+// 它相当于把每条边投影到圆柱面上累加梯形面积，对于城市到国家尺度的多边形足够精确。
+func Area(ring []LatLng) float64 {
+	n := len(ring)
+	if n > 1 && ring[0] == ring[n-1] {
+		n--
+	}
+	if n < 3 {
+		return 0
+	}
+	var sum float64
+	for i := range n {
+		phi1, lambda1 := ring[i].radians()
+		phi2, lambda2 := ring[(i+1)%n].radians()
+		sum += wrapAngle(lambda2-lambda1) * (2 + math.Sin(phi1) + math.Sin(phi2))
+	}
+	return math.Abs(sum) * EarthRadius * EarthRadius / 2
+}
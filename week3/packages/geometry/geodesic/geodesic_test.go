@@ -0,0 +1,125 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// dms 把度分秒转换为度，符号由 deg 决定
+func dms(deg, min, sec float64) float64 {
+	return math.Copysign(math.Abs(deg)+min/60+sec/3600, deg)
+}
+
+func TestVincenty(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b LatLng
+		want float64 // 米
+		tol  float64
+	}{
+		// Vincenty (1975) 论文中的例子: Flinders Peak -> Buninyong
+		{"Flinders Peak -> Buninyong",
+			LatLng{dms(-37, 57, 3.72030), dms(144, 25, 29.52440)},
+			LatLng{dms(-37, 39, 10.15610), dms(143, 55, 35.38390)},
+			54972.271, 1e-3},
+		{"重合的点", LatLng{51.5, -0.1}, LatLng{51.5, -0.1}, 0, 0},
+		// 赤道上 cos²α = 0，走特殊分支；赤道一度经度约为 111.319 千米
+		{"赤道上", LatLng{0, 0}, LatLng{0, 1}, 111319.491, 1e-3},
+		// 子午线上从赤道到北极是四分之一子午线弧长
+		{"赤道到北极", LatLng{0, 0}, LatLng{90, 0}, 10001965.729, 1e-3},
+		{"跨越 180° 经线", LatLng{0, 179.5}, LatLng{0, -179.5}, 111319.491, 1e-3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Vincenty(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > tt.tol {
+				t.Errorf("Vincenty(%v, %v) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+			}
+			// 椭球距离与球面距离的差别在 0.5% 以内
+			if h := Haversine(tt.a, tt.b); math.Abs(h-got) > 0.005*got {
+				t.Errorf("Haversine = %.1f 与 Vincenty = %.1f 相差太多", h, got)
+			}
+		})
+	}
+}
+
+// 两点接近对跖点时 Vincenty 不收敛，应该返回 ErrNoConvergence 而不是错误的距离
+func TestVincentyNoConvergence(t *testing.T) {
+	for _, b := range []LatLng{{0.5, 179.7}, {-0.4, -179.6}} {
+		d, err := Vincenty(LatLng{0, 0}, b)
+		if !errors.Is(err, ErrNoConvergence) {
+			t.Errorf("Vincenty((0, 0), %v) = %v, %v; want ErrNoConvergence", b, d, err)
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	quarter := EarthRadius * math.Pi / 2 // 四分之一大圆
+	tests := []struct {
+		name     string
+		start    LatLng
+		bearing  float64
+		distance float64
+		want     LatLng
+	}{
+		{"沿赤道向东", LatLng{0, 0}, 90, quarter, LatLng{0, 90}},
+		{"向北到北极", LatLng{0, 30}, 0, quarter, LatLng{90, 30}},
+		{"向南", LatLng{10, 20}, 180, EarthRadius * math.Pi / 18, LatLng{0, 20}},
+		{"向东跨越 180° 经线", LatLng{0, 179}, 90, EarthRadius * math.Pi / 90, LatLng{0, -179}},
+		{"向西跨越 180° 经线", LatLng{0, -179.5}, 270, EarthRadius * math.Pi / 180, LatLng{0, 179.5}},
+		{"距离为零", LatLng{-33.9, 151.2}, 45, 0, LatLng{-33.9, 151.2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Destination(tt.start, tt.bearing, tt.distance)
+			if math.Abs(got.Lat-tt.want.Lat) > 1e-9 || (math.Abs(got.Lat) < 90-1e-9 && math.Abs(got.Lng-tt.want.Lng) > 1e-9) {
+				t.Errorf("Destination = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 任意方向走出去之后，Haversine 距离和初始方位角都应该与输入一致
+	start := LatLng{48.8566, 2.3522}
+	for _, bearing := range []float64{0, 37, 90, 200, 315} {
+		end := Destination(start, bearing, 500e3)
+		if d := Haversine(start, end); math.Abs(d-500e3) > 1e-6 {
+			t.Errorf("方位角 %v: 距离 = %v, want 500000", bearing, d)
+		}
+		if b := InitialBearing(start, end); math.Abs(b-bearing) > 1e-9 {
+			t.Errorf("方位角 %v: InitialBearing = %v", bearing, b)
+		}
+	}
+}
+
+func TestArea(t *testing.T) {
+	// 经纬线围成的区域在球面上的面积是 R² · Δλ · (sin φ2 - sin φ1)，近似公式对它是精确的
+	box := func(lat1, lng1, lat2, lng2 float64) float64 {
+		rad := math.Pi / 180
+		return EarthRadius * EarthRadius * (lng2 - lng1) * rad * (math.Sin(lat2*rad) - math.Sin(lat1*rad))
+	}
+	tests := []struct {
+		name string
+		ring []LatLng
+		want float64
+	}{
+		{"赤道上 1°×1°", []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}}, box(0, 0, 1, 1)},
+		{"首尾重复", []LatLng{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}, box(0, 0, 1, 1)},
+		{"顺时针", []LatLng{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, box(0, 0, 1, 1)},
+		{"高纬度", []LatLng{{60, 10}, {60, 12}, {61, 12}, {61, 10}}, box(60, 10, 61, 12)},
+		{"跨越 180° 经线", []LatLng{{-1, 179}, {-1, -179}, {1, -179}, {1, 179}}, box(-1, 179, 1, 181)},
+		{"北半球", []LatLng{{0, 0}, {0, 90}, {0, 180}, {0, -90}}, 2 * math.Pi * EarthRadius * EarthRadius},
+		{"少于 3 个顶点", []LatLng{{0, 0}, {1, 1}}, 0},
+		{"空", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Area(tt.ring); math.Abs(got-tt.want) > 1e-9*tt.want {
+				t.Errorf("Area = %.6g, want %.6g", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package geometry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GeoJSON (RFC 7946) 编码
+//
+// 几何类型本身没有实现 json.Marshaler：那样会改变所有包含 Point 的结构体 (BBox、碰撞结果中的法向量等)
+// 的 JSON 格式。需要 GeoJSON 时显式调用下面的函数，或者使用 GeoJSON 包装类型:
+//   - MarshalGeoJSONPoint 把点编码为 {"type":"Point","coordinates":[x,y]}
+//   - MarshalGeoJSON 把形状编码为 {"type":"Polygon","coordinates":[[[x,y],...],...]}，
+//     Region 的洞编码为后面的环，MultiPolygon 编码为 {"type":"MultiPolygon",...}。
+//     外环按逆时针、洞按顺时针排列，每个环重复首点闭合；圆和椭圆用 CircleSegments 个顶点近似。
+//   - ParseGeoJSON 解码 Point、Polygon 和 MultiPolygon
+//   - GeoJSON 包装类型实现了 json.Marshaler 和 json.Unmarshaler，可以直接作为结构体字段使用:
+//
+//	type Feature struct {
+//		Name     string          `json:"name"`
+//		Geometry geometry.GeoJSON `json:"geometry"`
+//	}
+//
+// 解析失败时返回 *ParseError，其中包含出错位置的字节偏移量。
+
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// GeoJSON 把任意 Geometry 包装为按 GeoJSON 编码的值。
+// 只有显式使用这个类型时才会得到 GeoJSON，Point、BBox 等类型本身的 JSON 格式保持不变。
+type GeoJSON struct {
+	Geometry
+}
+
+var (
+	_ json.Marshaler   = GeoJSON{}
+	_ json.Unmarshaler = (*GeoJSON)(nil)
+)
+
+// MarshalJSON 实现 json.Marshaler：点编码为 Point，其它形状编码为 Polygon 或 MultiPolygon
+func (g GeoJSON) MarshalJSON() ([]byte, error) {
+	switch v := g.Geometry.(type) {
+	case nil:
+		return nil, errors.New("GeoJSON 中没有几何对象")
+	case Point:
+		return MarshalGeoJSONPoint(v)
+	case Shape:
+		return MarshalGeoJSON(v)
+	default:
+		return nil, fmt.Errorf("不支持把 %T 编码为 GeoJSON", v)
+	}
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，解码规则与 ParseGeoJSON 相同
+func (g *GeoJSON) UnmarshalJSON(data []byte) error {
+	geom, err := ParseGeoJSON(data)
+	if err != nil {
+		return err
+	}
+	g.Geometry = geom
+	return nil
+}
+
+// MarshalGeoJSONPoint 把点编码为 GeoJSON Point
+func MarshalGeoJSONPoint(p Point) ([]byte, error) {
+	if !p.valid() {
+		return nil, fmt.Errorf("坐标不是有限数值: %v", p)
+	}
+	return json.Marshal(geoJSONGeometry{Type: "Point", Coordinates: [2]float64{p.X, p.Y}})
+}
+
+// MarshalGeoJSON 把形状编码为 GeoJSON Polygon 或 MultiPolygon。
+// 面积为零的形状 (如宽度为 0 的矩形、半径为 0 的圆) 无法编码为合法的多边形，
+// 会返回包装了 ErrDegeneratePolygon 的错误，而不是生成一个 ParseGeoJSON 读不回来的结果。
+func MarshalGeoJSON(s Shape) ([]byte, error) {
+	switch v := s.(type) {
+	case Region:
+		rings, err := regionCoords(v)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(geoJSONGeometry{Type: "Polygon", Coordinates: rings})
+	case MultiPolygon:
+		polys := make([][][][2]float64, len(v))
+		for i, r := range v {
+			rings, err := regionCoords(r)
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 个区域: %w", i, err)
+			}
+			polys[i] = rings
+		}
+		return json.Marshal(geoJSONGeometry{Type: "MultiPolygon", Coordinates: polys})
+	}
+	pg, err := ToPolygon(s)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalGeoJSON(Region{Outer: pg})
+}
+
+// regionCoords 把区域转换为 GeoJSON 的环：外环逆时针、洞顺时针，首点重复一次闭合
+func regionCoords(r Region) ([][][2]float64, error) {
+	rings, err := encodableRings(r, "GeoJSON")
+	if err != nil {
+		return nil, err
+	}
+	out := make([][][2]float64, 0, len(rings))
+	for i, pg := range rings {
+		want := CounterClockwise
+		if i > 0 {
+			want = Clockwise
+		}
+		pg = orient(pg, want)
+		coords := make([][2]float64, 0, len(pg.Points)+1)
+		for _, p := range pg.Points {
+			coords = append(coords, [2]float64{p.X, p.Y})
+		}
+		out = append(out, append(coords, coords[0]))
+	}
+	return out, nil
+}
+
+// ParseGeoJSON 解码 GeoJSON 几何对象，返回值的具体类型为:
+//   - Point: type 为 "Point"
+//   - Polygon: type 为 "Polygon" 且只有外环
+//   - Region: type 为 "Polygon" 且带洞
+//   - MultiPolygon: type 为 "MultiPolygon"
+//
+// 每个环都要经过 NewPolygon 校验，带洞的多边形还要经过 NewRegion 校验。
+func ParseGeoJSON(data []byte) (Geometry, error) {
+	doc, err := decodeGeoJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	switch doc.typ {
+	case "Point":
+		return doc.coords.position()
+	case "Polygon":
+		return doc.coords.polygon()
+	case "MultiPolygon":
+		if doc.coords.isNum || len(doc.coords.kids) == 0 {
+			return nil, doc.coords.errorf("MultiPolygon 至少需要一个多边形")
+		}
+		m := make(MultiPolygon, 0, len(doc.coords.kids))
+		for _, k := range doc.coords.kids {
+			g, err := k.polygon()
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, asRegion(g))
+		}
+		return m, nil
+	default:
+		return nil, doc.errorf(doc.typeOff, "不支持的几何类型 %q, 只支持 Point、Polygon 和 MultiPolygon", doc.typ)
+	}
+}
+
+// --- 解码辅助 ---
+
+// coordNode 是 coordinates 字段中的一个值：数字或嵌套数组，off 记录它在输入中的偏移。
+type coordNode struct {
+	off   int
+	isNum bool
+	num   float64
+	kids  []coordNode
+}
+
+type geoJSONDoc struct {
+	typ     string
+	typeOff int
+	coords  coordNode
+}
+
+func (g geoJSONDoc) errorf(off int, format string, args ...any) error {
+	return &ParseError{Format: "GeoJSON", Offset: off, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (n coordNode) errorf(format string, args ...any) error {
+	return &ParseError{Format: "GeoJSON", Offset: n.off, Msg: fmt.Sprintf(format, args...)}
+}
+
+// position 把 [x, y] 转换为点
+func (n coordNode) position() (Point, error) {
+	if n.isNum {
+		return Point{}, n.errorf("期望坐标数组 [x, y]，得到数字")
+	}
+	if len(n.kids) < 2 {
+		return Point{}, n.errorf("坐标至少需要 2 个数值, 得到 %d", len(n.kids))
+	}
+	for _, k := range n.kids {
+		if !k.isNum {
+			return Point{}, k.errorf("坐标分量必须是数字")
+		}
+	}
+	return Point{n.kids[0].num, n.kids[1].num}, nil
+}
+
+// ring 把 [[x,y], ...] 转换为点序列，并检查环是否闭合
+func (n coordNode) ring() ([]Point, error) {
+	if n.isNum {
+		return nil, n.errorf("期望坐标环，得到数字")
+	}
+	if len(n.kids) < 4 {
+		return nil, n.errorf("多边形的环至少需要 4 个坐标 (含闭合点), 得到 %d", len(n.kids))
+	}
+	pts := make([]Point, len(n.kids))
+	for i, k := range n.kids {
+		p, err := k.position()
+		if err != nil {
+			return nil, err
+		}
+		pts[i] = p
+	}
+	if !pts[0].Eq(pts[len(pts)-1]) {
+		return nil, n.errorf("多边形的环没有闭合: 首点 %v, 末点 %v", pts[0], pts[len(pts)-1])
+	}
+	return pts, nil
+}
+
+// polygon 把 [[[x,y], ...], ...] 转换为多边形，第一个环是外边界，其余的环是洞
+func (n coordNode) polygon() (Geometry, error) {
+	if n.isNum || len(n.kids) == 0 {
+		return nil, n.errorf("Polygon 至少需要一个环")
+	}
+	rings := make([][]Point, len(n.kids))
+	offs := make([]int, len(n.kids))
+	for i, k := range n.kids {
+		pts, err := k.ring()
+		if err != nil {
+			return nil, err
+		}
+		rings[i], offs[i] = pts, k.off
+	}
+	return polygonFromRings("GeoJSON", rings, offs)
+}
+
+// decodeGeoJSON 逐个记号读取 GeoJSON 几何对象，
+// 并把 coordinates 解析为带偏移量的树，方便后续给出精确的错误位置。
+func decodeGeoJSON(data []byte) (geoJSONDoc, error) {
+	var doc geoJSONDoc
+	dec := json.NewDecoder(bytes.NewReader(data))
+	offset := func() int { return skipSpace(data, int(dec.InputOffset())) }
+
+	if tok, err := dec.Token(); err != nil {
+		return doc, jsonError(err, offset())
+	} else if tok != json.Delim('{') {
+		return doc, doc.errorf(0, "期望 JSON 对象")
+	}
+	doc.typeOff = -1
+	sawCoords := false
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return doc, jsonError(err, offset())
+		}
+		key, _ := keyTok.(string)
+		off := offset()
+		switch key {
+		case "type":
+			doc.typeOff = off
+			if err := dec.Decode(&doc.typ); err != nil {
+				return doc, jsonError(err, off)
+			}
+		case "coordinates":
+			sawCoords = true
+			if doc.coords, err = readCoords(dec, data); err != nil {
+				return doc, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return doc, jsonError(err, off)
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return doc, jsonError(err, offset())
+	}
+	// 与 ParseWKT 一样，几何对象之后不允许再有其它内容 (包括第二个对象)
+	if rest := bytes.TrimLeft(data[dec.InputOffset():], " \t\r\n"); len(rest) > 0 {
+		return doc, doc.errorf(len(data)-len(rest), "几何对象之后有多余的内容 %q", tail(rest))
+	}
+	switch {
+	case doc.typeOff < 0:
+		return doc, doc.errorf(0, "缺少 type 字段")
+	case !sawCoords:
+		return doc, doc.errorf(0, "缺少 coordinates 字段")
+	}
+	return doc, nil
+}
+
+// tail 截取错误信息中展示的多余内容，避免把很长的输入整段放进错误里
+func tail(b []byte) string {
+	const limit = 20
+	if len(b) > limit {
+		return string(b[:limit]) + "..."
+	}
+	return string(b)
+}
+
+// readCoords 递归读取嵌套的数字数组
+func readCoords(dec *json.Decoder, data []byte) (coordNode, error) {
+	n := coordNode{off: skipSpace(data, int(dec.InputOffset()))}
+	tok, err := dec.Token()
+	if err != nil {
+		return n, jsonError(err, n.off)
+	}
+	switch v := tok.(type) {
+	case float64:
+		n.isNum, n.num = true, v
+		return n, nil
+	case json.Delim:
+		if v != '[' {
+			return n, n.errorf("coordinates 中出现了意外的 %q", v)
+		}
+	default:
+		return n, n.errorf("coordinates 只能包含数字和数组, 得到 %v", tok)
+	}
+	for dec.More() {
+		kid, err := readCoords(dec, data)
+		if err != nil {
+			return n, err
+		}
+		n.kids = append(n.kids, kid)
+	}
+	if _, err := dec.Token(); err != nil { // 读取 ']'
+		return n, jsonError(err, n.off)
+	}
+	return n, nil
+}
+
+// skipSpace 跳过空白、逗号和冒号，让偏移量指向下一个值的第一个字符
+func skipSpace(data []byte, off int) int {
+	for off < len(data) && bytes.IndexByte([]byte(" \t\r\n,:"), data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+// jsonError 把 encoding/json 的错误包装为 ParseError，尽量使用其自带的偏移量
+func jsonError(err error, off int) error {
+	var syn *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syn):
+		off = int(syn.Offset)
+	case errors.As(err, &typ):
+		off = int(typ.Offset)
+	}
+	return &ParseError{Format: "GeoJSON", Offset: off, Msg: err.Error(), Err: err}
+}
@@ -5,6 +5,7 @@ package geometry
 import (
 	"fmt"
 	"math"
+	"os"
 )
 
 // Pi 是一个导出的常量 (首字母大写)
@@ -19,6 +20,7 @@ type rect struct {
 // Circle struct (导出，首字母大写)
 // 这个结构体可以在包外部使用。
 type Circle struct {
+	Center Point // 圆心，零值表示位于原点
 	Radius float64
 }
 
@@ -53,13 +55,15 @@ func internalHelperFunction() {
 // 按照它们在包中声明的顺序自动执行。
 // 如果一个包导入了其他包，则会先执行被导入包的 init 函数。
 // init 函数通常用于执行包级别的初始化任务。
+//
+// 这里的提示信息写到标准错误，避免混进使用本包的命令行工具的标准输出 (例如 JSON 结果)。
 func init() {
-	fmt.Println("geometry 包的 init 函数被调用了。")
+	fmt.Fprintln(os.Stderr, "geometry 包的 init 函数被调用了。")
 	// internalHelperFunction() // 可以在这里调用包内函数
 }
 
 func init() {
-	fmt.Println("geometry 包的第二个 init 函数被调用了 (按声明顺序)。")
+	fmt.Fprintln(os.Stderr, "geometry 包的第二个 init 函数被调用了 (按声明顺序)。")
 }
 
 // NewRect 是一个导出的构造函数，用于创建未导出的 rect 结构体的实例。
@@ -82,17 +86,27 @@ func NewRect(w, h float64) *rect {
 */
 
 // Rectangle 结构体 (导出)
+// 矩形与坐标轴对齐，Origin 是左下角坐标 (零值表示位于原点)。
 type Rectangle struct {
+	Origin Point
 	Width  float64
 	Height float64
 }
 
 // NewRectangle 是一个构造函数，用于创建 Rectangle 实例
 func NewRectangle(width, height float64) (Rectangle, error) {
+	return NewRectangleAt(Point{}, width, height)
+}
+
+// NewRectangleAt 创建一个左下角位于 origin 的 Rectangle 实例
+func NewRectangleAt(origin Point, width, height float64) (Rectangle, error) {
 	if width < 0 || height < 0 {
 		return Rectangle{}, fmt.Errorf("宽度和高度不能为负数: width=%.2f, height=%.2f", width, height)
 	}
-	return Rectangle{Width: width, Height: height}, nil
+	if !origin.valid() || !finite(width) || !finite(height) {
+		return Rectangle{}, fmt.Errorf("矩形参数必须是有限数值: origin=%v, width=%v, height=%v", origin, width, height)
+	}
+	return Rectangle{Origin: origin, Width: width, Height: height}, nil
 }
 
 // Methods for exported Rectangle
@@ -0,0 +1,188 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Path 是由若干首尾相连的 Segment 组成的复合路径，可以用来描述圆角矩形、
+// 胶囊形这类 Circle 和 Rectangle 表达不了的形状。
+//
+// 用 NewPath 指定起点，再用 LineTo、QuadTo、CubicTo、ArcTo 依次追加曲线，
+// 这些方法返回 *Path 本身，可以链式调用：
+//
+//	p := geometry.NewPath(geometry.Pt(0, 0)).LineTo(geometry.Pt(4, 0)).QuadTo(geometry.Pt(5, 0), geometry.Pt(5, 1)).Close()
+type Path struct {
+	start    Point
+	segments []Segment
+	closed   bool
+}
+
+// NewPath 创建一条从 start 开始的空路径
+func NewPath(start Point) *Path {
+	return &Path{start: start}
+}
+
+// Current 返回路径当前的终点，下一段曲线从这里开始
+func (p *Path) Current() Point {
+	if len(p.segments) == 0 {
+		return p.start
+	}
+	return p.segments[len(p.segments)-1].End()
+}
+
+// Segments 返回路径中的全部曲线段
+func (p *Path) Segments() []Segment { return p.segments }
+
+// Closed 报告路径是否已经闭合
+func (p *Path) Closed() bool { return p.closed }
+
+// Append 追加一段曲线，它的起点应当与路径当前终点重合
+func (p *Path) Append(s Segment) *Path {
+	p.segments = append(p.segments, s)
+	return p
+}
+
+// LineTo 追加一条到 q 的直线段
+func (p *Path) LineTo(q Point) *Path {
+	return p.Append(LineSegment{p.Current(), q})
+}
+
+// QuadTo 追加一条以 c 为控制点、终点为 q 的二次贝塞尔曲线
+func (p *Path) QuadTo(c, q Point) *Path {
+	return p.Append(QuadBezier{p.Current(), c, q})
+}
+
+// CubicTo 追加一条以 c1、c2 为控制点、终点为 q 的三次贝塞尔曲线
+func (p *Path) CubicTo(c1, c2, q Point) *Path {
+	return p.Append(CubicBezier{p.Current(), c1, c2, q})
+}
+
+// ArcTo 以 center 为圆心，从当前点出发转过 sweep 弧度 (正数为逆时针) 追加一段圆弧
+func (p *Path) ArcTo(center Point, sweep float64) *Path {
+	d := p.Current().Sub(center)
+	return p.Append(Arc{Center: center, Radius: d.Len(), StartAngle: math.Atan2(d.Y, d.X), Sweep: sweep})
+}
+
+// Close 闭合路径：当前点与起点不重合时追加一条回到起点的直线段
+func (p *Path) Close() *Path {
+	if !p.Current().Eq(p.start) {
+		p.LineTo(p.start)
+	}
+	p.closed = true
+	return p
+}
+
+// Length 返回路径的总长度
+func (p *Path) Length() float64 {
+	var sum float64
+	for _, s := range p.segments {
+		sum += s.Length()
+	}
+	return sum
+}
+
+// Bounds 返回路径的外接框
+func (p *Path) Bounds() BBox {
+	b := BoxOf(p.start)
+	for _, s := range p.segments {
+		b = b.Union(s.Bounds())
+	}
+	return b
+}
+
+// PointAt 返回沿路径从起点走过距离 d 后所在的点，d 超出范围时取端点
+func (p *Path) PointAt(d float64) Point {
+	for _, s := range p.segments {
+		l := s.Length()
+		if d <= l {
+			return s.At(arcParam(s, math.Max(0, d), l))
+		}
+		d -= l
+	}
+	return p.Current()
+}
+
+// arcParam 求使 s 从起点到 t 的弧长等于 d 的参数 t (l 为整段弧长)。
+// 直线和圆弧的速度恒定，可以直接按比例换算；贝塞尔曲线用二分法。
+func arcParam(s Segment, d, l float64) float64 {
+	if l <= Epsilon {
+		return 0
+	}
+	switch s.(type) {
+	case LineSegment, Arc:
+		return d / l
+	}
+	lo, hi := 0.0, 1.0
+	for range 50 {
+		mid := (lo + hi) / 2
+		first, _ := s.Split(mid)
+		if first.Length() < d {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// Flatten 把整条路径近似为折线，相邻曲线段共享的端点只保留一次
+func (p *Path) Flatten(tol float64) []Point {
+	pts := []Point{p.start}
+	for _, s := range p.segments {
+		flat := s.Flatten(tol)
+		pts = append(pts, flat[1:]...)
+	}
+	if p.closed && len(pts) > 1 && pts[len(pts)-1].Eq(pts[0]) {
+		pts = pts[:len(pts)-1]
+	}
+	return pts
+}
+
+// Polygon 把闭合路径近似为多边形，路径未闭合时返回错误
+func (p *Path) Polygon(tol float64) (Polygon, error) {
+	if !p.closed {
+		return Polygon{}, fmt.Errorf("路径没有闭合，无法转换为多边形")
+	}
+	return NewPolygon(p.Flatten(tol))
+}
+
+// Nearest 返回路径上离 q 最近的点，以及该点所在曲线段的下标和参数
+func (p *Path) Nearest(q Point) (pt Point, segment int, t float64) {
+	if len(p.segments) == 0 {
+		return p.start, -1, 0
+	}
+	best := math.Inf(1)
+	for i, s := range p.segments {
+		st, sp := s.Nearest(q)
+		if d := sp.Dist(q); d < best {
+			best, pt, segment, t = d, sp, i, st
+		}
+	}
+	return pt, segment, t
+}
+
+// RoundedRect 创建四个角都是半径为 radius 的圆弧的圆角矩形路径 (逆时针)。
+// radius 不能超过短边的一半。
+func RoundedRect(r Rectangle, radius float64) (*Path, error) {
+	if radius < 0 || radius > math.Min(r.Width, r.Height)/2 {
+		return nil, fmt.Errorf("圆角半径必须在 [0, %v] 之间, 得到 %v", math.Min(r.Width, r.Height)/2, radius)
+	}
+	x0, y0 := r.Origin.X, r.Origin.Y
+	x1, y1 := x0+r.Width, y0+r.Height
+	p := NewPath(Pt(x0+radius, y0))
+	corner := func(cx, cy float64) {
+		if radius > 0 {
+			p.ArcTo(Pt(cx, cy), Pi/2)
+		}
+	}
+	p.LineTo(Pt(x1-radius, y0))
+	corner(x1-radius, y0+radius)
+	p.LineTo(Pt(x1, y1-radius))
+	corner(x1-radius, y1-radius)
+	p.LineTo(Pt(x0+radius, y1))
+	corner(x0+radius, y1-radius)
+	p.LineTo(Pt(x0, y0+radius))
+	corner(x0+radius, y0+radius)
+	return p.Close(), nil
+}
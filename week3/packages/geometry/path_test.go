@@ -0,0 +1,118 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundedRect(t *testing.T) {
+	r, _ := NewRectangleAt(Pt(1, 2), 10, 6)
+	tests := []struct {
+		radius   float64
+		length   float64
+		area     float64
+		segments int
+	}{
+		{0, 32, 60, 4},
+		{1, 24 + 2*Pi, 60 - (4 - Pi), 8},
+		{3, 8 + 6*Pi, 60 - 9*(4-Pi), 8}, // 短边的一半，左右两条边退化为零长度
+	}
+	for _, tt := range tests {
+		p, err := RoundedRect(r, tt.radius)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Closed() || len(p.Segments()) != tt.segments {
+			t.Errorf("radius=%v: Closed = %v, %d 段; want 闭合, %d 段", tt.radius, p.Closed(), len(p.Segments()), tt.segments)
+		}
+		if got := p.Length(); math.Abs(got-tt.length) > 1e-9 {
+			t.Errorf("radius=%v: Length = %v, want %v", tt.radius, got, tt.length)
+		}
+		if got := p.Bounds(); !boxNear(got, r.Bounds(), 1e-12) {
+			t.Errorf("radius=%v: Bounds = %v, want %v", tt.radius, got, r.Bounds())
+		}
+		// 折线的每一段都在圆弧内侧，面积略小于真实值，差距不超过 周长 × 容差
+		const tol = 1e-3
+		pg, err := p.Polygon(tol)
+		if err != nil {
+			t.Fatalf("radius=%v: Polygon: %v", tt.radius, err)
+		}
+		if got := pg.Area(); got > tt.area+1e-9 || tt.area-got > tt.length*tol {
+			t.Errorf("radius=%v: 多边形面积 = %v, want 略小于 %v", tt.radius, got, tt.area)
+		}
+	}
+	for _, radius := range []float64{-1, 3.5} {
+		if _, err := RoundedRect(r, radius); err == nil {
+			t.Errorf("RoundedRect(radius=%v) 应该报错", radius)
+		}
+	}
+}
+
+func TestPathFlatten(t *testing.T) {
+	open := NewPath(Pt(0, 0)).LineTo(Pt(2, 0)).QuadTo(Pt(3, 0), Pt(3, 1)).ArcTo(Pt(3, 2), Pi)
+	pts := open.Flatten(0.01)
+	if !pts[0].Eq(Pt(0, 0)) || !pts[len(pts)-1].Eq(open.Current()) {
+		t.Errorf("Flatten 的首尾 = %v, %v; want (0, 0), %v", pts[0], pts[len(pts)-1], open.Current())
+	}
+	// 相邻曲线段共享的端点只出现一次
+	for i := 1; i < len(pts); i++ {
+		if pts[i].Eq(pts[i-1]) {
+			t.Fatalf("第 %d 个点与前一个点重复: %v", i, pts[i])
+		}
+	}
+	if !near(open.Current(), Pt(3, 3), 1e-12) {
+		t.Errorf("Current = %v, want (3, 3)", open.Current())
+	}
+	if _, err := open.Polygon(0.01); err == nil {
+		t.Error("未闭合的路径转换为多边形应该报错")
+	}
+
+	// 闭合之后不重复起点
+	tri := NewPath(Pt(0, 0)).LineTo(Pt(4, 0)).LineTo(Pt(0, 3)).Close()
+	if pts := tri.Flatten(0); len(pts) != 3 {
+		t.Errorf("三角形路径 Flatten = %v, want 3 个点", pts)
+	}
+	// 终点已经回到起点时，Close 不追加线段
+	loop := NewPath(Pt(1, 0)).ArcTo(Pt(0, 0), 2*Pi).Close()
+	if n := len(loop.Segments()); n != 1 {
+		t.Errorf("整圆路径 Close 之后有 %d 段, want 1", n)
+	}
+	if pg, err := loop.Polygon(1e-4); err != nil || math.Abs(pg.Area()-Pi) > 2*Pi*1e-4 {
+		t.Errorf("整圆路径的多边形面积 = %v, %v; want 约 π", pg.Area(), err)
+	}
+}
+
+func TestPathPointAt(t *testing.T) {
+	p := NewPath(Pt(0, 0)).LineTo(Pt(4, 0)).QuadTo(Pt(5, 2), Pt(6, 0))
+	quadLen := p.Segments()[1].Length()
+	tests := []struct {
+		name string
+		d    float64
+		want Point
+	}{
+		{"起点", 0, Pt(0, 0)},
+		{"负数取起点", -1, Pt(0, 0)},
+		{"直线段中间", 3, Pt(3, 0)},
+		{"两段的交界", 4, Pt(4, 0)},
+		// 对称的二次曲线，弧长的一半就在顶点
+		{"曲线弧长的一半", 4 + quadLen/2, Pt(5, 1)},
+		{"终点", 4 + quadLen, Pt(6, 0)},
+		{"超出取终点", 100, Pt(6, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.PointAt(tt.d); !near(got, tt.want, 1e-9) {
+				t.Errorf("PointAt(%v) = %v, want %v", tt.d, got, tt.want)
+			}
+		})
+	}
+
+	// 最近点由数值搜索得到，在极小值附近距离对参数不敏感，只能精确到约 1e-8
+	pt, seg, param := p.Nearest(Pt(5, 3))
+	if seg != 1 || math.Abs(param-0.5) > 1e-6 || !near(pt, Pt(5, 1), 1e-6) {
+		t.Errorf("Nearest((5, 3)) = %v, 第 %d 段, t=%v; want (5, 1), 第 1 段, t=0.5", pt, seg, param)
+	}
+	if _, seg, _ := NewPath(Pt(1, 1)).Nearest(Pt(0, 0)); seg != -1 {
+		t.Errorf("空路径的 Nearest 段下标 = %d, want -1", seg)
+	}
+}
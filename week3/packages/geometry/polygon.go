@@ -0,0 +1,327 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Polygon 是由顶点序列构成的任意多边形，最后一个顶点与第一个顶点隐式相连。
+// 顶点可以按顺时针或逆时针排列；多边形可能自交，可用 IsSimple 检查。
+type Polygon struct {
+	Points []Point
+}
+
+// ErrDegeneratePolygon 表示多边形退化：有效顶点不足 3 个，或所有顶点共线导致面积为零。
+var ErrDegeneratePolygon = errors.New("多边形退化")
+
+// NewPolygon 创建多边形。
+// 连续重复的顶点 (包括与首顶点重复的闭合点) 会被去掉；去重后至少需要 3 个顶点，且面积不能为零，
+// 否则返回包装了 ErrDegeneratePolygon 的错误。
+// 传入的切片会被复制，之后修改原切片不会影响多边形。
+func NewPolygon(points []Point) (Polygon, error) {
+	for i, p := range points {
+		if !p.valid() {
+			return Polygon{}, fmt.Errorf("多边形第 %d 个顶点不是有限数值: %v", i, p)
+		}
+	}
+	pts := dedupe(points)
+	if len(pts) < 3 {
+		return Polygon{}, fmt.Errorf("%w: 至少需要 3 个不同的顶点, got %d", ErrDegeneratePolygon, len(pts))
+	}
+	pg := Polygon{Points: pts}
+	if pg.Area() <= Epsilon {
+		return Polygon{}, fmt.Errorf("%w: 面积为零 (所有顶点共线)", ErrDegeneratePolygon)
+	}
+	return pg, nil
+}
+
+// dedupe 返回去掉连续重复顶点后的新切片，首尾相同的闭合点也会被去掉。
+func dedupe(points []Point) []Point {
+	out := make([]Point, 0, len(points))
+	for _, p := range points {
+		if len(out) > 0 && out[len(out)-1].Eq(p) {
+			continue
+		}
+		out = append(out, p)
+	}
+	for len(out) > 1 && out[len(out)-1].Eq(out[0]) {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// SignedArea 使用鞋带公式 (shoelace formula) 计算有符号面积。
+// 顶点按逆时针排列时为正，按顺时针排列时为负。
+func (pg Polygon) SignedArea() float64 {
+	var sum float64
+	n := len(pg.Points)
+	for i := range n {
+		sum += pg.Points[i].Cross(pg.Points[(i+1)%n])
+	}
+	return sum / 2
+}
+
+// Area 返回多边形面积 (总是非负)
+func (pg Polygon) Area() float64 {
+	return math.Abs(pg.SignedArea())
+}
+
+// Perimeter 返回所有边长之和
+func (pg Polygon) Perimeter() float64 {
+	var sum float64
+	n := len(pg.Points)
+	for i := range n {
+		sum += pg.Points[i].Dist(pg.Points[(i+1)%n])
+	}
+	return sum
+}
+
+// Bounds 返回所有顶点的外接框
+func (pg Polygon) Bounds() BBox {
+	return BoxOf(pg.Points...)
+}
+
+// Centroid 返回多边形区域的质心 (按面积加权，而不是顶点的简单平均)。
+func (pg Polygon) Centroid() Point {
+	a := pg.SignedArea()
+	if math.Abs(a) <= Epsilon {
+		return BoxOf(pg.Points...).Center()
+	}
+	var cx, cy float64
+	n := len(pg.Points)
+	for i := range n {
+		p, q := pg.Points[i], pg.Points[(i+1)%n]
+		f := p.Cross(q)
+		cx += (p.X + q.X) * f
+		cy += (p.Y + q.Y) * f
+	}
+	return Point{cx / (6 * a), cy / (6 * a)}
+}
+
+// Vertices 返回顶点的副本
+func (pg Polygon) Vertices() []Point {
+	return append([]Point(nil), pg.Points...)
+}
+
+// Orientation 表示三个点 (或一个多边形顶点序列) 的旋转方向。
+type Orientation int
+
+const (
+	Collinear        Orientation = 0  // 共线 / 面积为零
+	CounterClockwise Orientation = 1  // 逆时针
+	Clockwise        Orientation = -1 // 顺时针
+)
+
+// String 实现 fmt.Stringer
+func (o Orientation) String() string {
+	switch o {
+	case CounterClockwise:
+		return "逆时针"
+	case Clockwise:
+		return "顺时针"
+	default:
+		return "共线"
+	}
+}
+
+// Orient 判断 a→b→c 的转向：c 在有向线段 ab 左侧为逆时针，右侧为顺时针。
+func Orient(a, b, c Point) Orientation {
+	return sign(b.Sub(a).Cross(c.Sub(a)))
+}
+
+func sign(v float64) Orientation {
+	switch {
+	case v > Epsilon:
+		return CounterClockwise
+	case v < -Epsilon:
+		return Clockwise
+	default:
+		return Collinear
+	}
+}
+
+// Orientation 返回多边形顶点的排列方向 (由有符号面积的符号决定)
+func (pg Polygon) Orientation() Orientation {
+	return sign(pg.SignedArea())
+}
+
+// Reversed 返回顶点顺序相反的多边形，可用于在顺时针和逆时针之间切换。
+func (pg Polygon) Reversed() Polygon {
+	n := len(pg.Points)
+	pts := make([]Point, n)
+	for i, p := range pg.Points {
+		pts[n-1-i] = p
+	}
+	return Polygon{Points: pts}
+}
+
+// IsConvex 判断多边形是否为凸多边形。
+// 要求所有非共线的相邻边转向一致，并且顶点绕中心只转一圈 (排除星形等自交的情况)。
+func (pg Polygon) IsConvex() bool {
+	n := len(pg.Points)
+	if n < 3 {
+		return false
+	}
+	var dir Orientation
+	var turn float64
+	for i := range n {
+		a, b, c := pg.Points[i], pg.Points[(i+1)%n], pg.Points[(i+2)%n]
+		o := Orient(a, b, c)
+		if o != Collinear {
+			if dir == Collinear {
+				dir = o
+			} else if o != dir {
+				return false
+			}
+		}
+		u, v := b.Sub(a), c.Sub(b)
+		turn += math.Atan2(u.Cross(v), u.Dot(v))
+	}
+	return dir != Collinear && math.Abs(math.Abs(turn)-2*Pi) < 1e-6
+}
+
+// SelfIntersections 返回所有相交的不相邻边对 (边 i 从 Points[i] 到 Points[i+1])。
+// 相邻边只有在重叠 (折返) 时才会被报告。结果为空表示多边形是简单多边形。
+func (pg Polygon) SelfIntersections() [][2]int {
+	var out [][2]int
+	n := len(pg.Points)
+	for i := range n {
+		a1, a2 := pg.Points[i], pg.Points[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			b1, b2 := pg.Points[j], pg.Points[(j+1)%n]
+			adjacent := j == i+1 || (i == 0 && j == n-1)
+			if adjacent {
+				// 相邻边共享一个端点，只有共线且方向相反时才算重叠
+				shared, other1, other2 := a2, a1, b2
+				if i == 0 && j == n-1 {
+					shared, other1, other2 = a1, a2, b1
+				}
+				if Orient(other1, shared, other2) == Collinear && other1.Sub(shared).Dot(other2.Sub(shared)) > 0 {
+					out = append(out, [2]int{i, j})
+				}
+				continue
+			}
+			if SegmentsIntersect(a1, a2, b1, b2) {
+				out = append(out, [2]int{i, j})
+			}
+		}
+	}
+	return out
+}
+
+// IsSimple 判断多边形的边是否互不相交 (没有自交)
+func (pg Polygon) IsSimple() bool {
+	return len(pg.SelfIntersections()) == 0
+}
+
+// SegmentsIntersect 判断线段 p1p2 与 q1q2 是否相交 (包括端点接触和共线重叠)。
+func SegmentsIntersect(p1, p2, q1, q2 Point) bool {
+	o1, o2 := Orient(p1, p2, q1), Orient(p1, p2, q2)
+	o3, o4 := Orient(q1, q2, p1), Orient(q1, q2, p2)
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	// 剩下的情况只可能是某个端点恰好落在另一条线段所在直线上
+	return (o1 == Collinear && onSegment(p1, p2, q1)) ||
+		(o2 == Collinear && onSegment(p1, p2, q2)) ||
+		(o3 == Collinear && onSegment(q1, q2, p1)) ||
+		(o4 == Collinear && onSegment(q1, q2, p2))
+}
+
+// onSegment 判断与线段 ab 共线的点 p 是否落在线段范围内
+func onSegment(a, b, p Point) bool {
+	return p.X >= math.Min(a.X, b.X)-Epsilon && p.X <= math.Max(a.X, b.X)+Epsilon &&
+		p.Y >= math.Min(a.Y, b.Y)-Epsilon && p.Y <= math.Max(a.Y, b.Y)+Epsilon
+}
+
+// WindingNumber 计算多边形绕点 p 的环绕数 (winding number)。
+// 逆时针每绕一圈 +1，顺时针每绕一圈 -1，为 0 表示点在多边形外部。
+// 与射线法相比，环绕数对自交多边形也能给出一致的结果。
+func (pg Polygon) WindingNumber(p Point) int {
+	wn := 0
+	n := len(pg.Points)
+	for i := range n {
+		a, b := pg.Points[i], pg.Points[(i+1)%n]
+		if a.Y <= p.Y {
+			if b.Y > p.Y && Orient(a, b, p) == CounterClockwise {
+				wn++ // 向上穿过且 p 在边的左侧
+			}
+		} else if b.Y <= p.Y && Orient(a, b, p) == Clockwise {
+			wn-- // 向下穿过且 p 在边的右侧
+		}
+	}
+	return wn
+}
+
+// OnBoundary 判断点 p 是否恰好落在多边形的某条边上
+func (pg Polygon) OnBoundary(p Point) bool {
+	n := len(pg.Points)
+	for i := range n {
+		a, b := pg.Points[i], pg.Points[(i+1)%n]
+		if Orient(a, b, p) == Collinear && onSegment(a, b, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains 判断点 p 是否在多边形内部或边界上 (非零环绕规则)。
+func (pg Polygon) Contains(p Point) bool {
+	return pg.OnBoundary(p) || pg.WindingNumber(p) != 0
+}
+
+// CircleSegments 是把圆和椭圆近似为多边形时使用的顶点数。
+const CircleSegments = 64
+
+// ToPolygon 把形状转换为多边形：多边形类形状直接取顶点，圆和椭圆用 CircleSegments 个顶点近似。
+// 结果的顶点按逆时针排列。
+//
+// 布尔运算的结果只有在是不带洞的单个区域时才能转换；带洞或由多块组成的结果无法用一个简单多边形表示，
+// 需要直接使用 Region / MultiPolygon (WriteSVG、MarshalGeoJSON 和 WKT 都支持这两种类型)。
+func ToPolygon(s Shape) (Polygon, error) {
+	var pts []Point
+	switch v := s.(type) {
+	case Region:
+		if len(v.Holes) > 0 {
+			return Polygon{}, fmt.Errorf("带洞的区域无法转换为单个多边形")
+		}
+		pts = v.Outer.Points
+	case MultiPolygon:
+		if len(v) != 1 {
+			return Polygon{}, fmt.Errorf("由 %d 块组成的区域无法转换为单个多边形", len(v))
+		}
+		return ToPolygon(v[0])
+	case Polygon:
+		pts = v.Vertices()
+	case Rectangle:
+		pts = v.Vertices()
+	case Triangle:
+		pts = v.Vertices()
+	case RegularPolygon:
+		pts = v.Vertices()
+	case Circle:
+		pts = ellipsePoints(Ellipse{Center: v.Center, RX: v.Radius, RY: v.Radius}, CircleSegments)
+	case Ellipse:
+		pts = ellipsePoints(v, CircleSegments)
+	default:
+		return Polygon{}, fmt.Errorf("无法把 %T 类型的形状转换为多边形", s)
+	}
+	pg := Polygon{Points: pts}
+	if pg.Orientation() == Clockwise {
+		pg = pg.Reversed()
+	}
+	return pg, nil
+}
+
+// ellipsePoints 在椭圆上均匀取 n 个参数角对应的点 (逆时针)
+func ellipsePoints(e Ellipse, n int) []Point {
+	sin, cos := math.Sincos(e.Angle)
+	pts := make([]Point, n)
+	for i := range pts {
+		s, c := math.Sincos(2 * Pi * float64(i) / float64(n))
+		x, y := e.RX*c, e.RY*s
+		pts[i] = Point{e.Center.X + x*cos - y*sin, e.Center.Y + x*sin + y*cos}
+	}
+	return pts
+}
@@ -0,0 +1,382 @@
+package geometry
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+)
+
+// R 树的节点容量。每个节点最多 rtMaxEntries 个条目，非根节点至少 rtMinEntries 个。
+const (
+	rtMaxEntries = 16
+	rtMinEntries = 6
+)
+
+// Item 是存放在空间索引中的元素，ID 由调用方分配，用于删除和识别结果。
+type Item struct {
+	ID    int
+	Shape Shape
+}
+
+// RTree 是基于外接框的 R 树空间索引，支持插入、删除、范围查询、k 近邻查询和批量加载。
+// 相比线性扫描，查询时可以整棵跳过与查询区域不相交的子树。
+// RTree 不是并发安全的，多个 goroutine 同时读写时需要调用方自行加锁。
+type RTree struct {
+	root   *rtNode
+	height int          // 树高，只有一个叶子根节点时为 1
+	boxes  map[int]BBox // ID -> 外接框，用于删除时快速定位
+}
+
+// rtEntry 是节点中的一个条目：叶子节点的条目保存 item，内部节点的条目指向 child。
+type rtEntry struct {
+	box   BBox
+	child *rtNode
+	item  Item
+}
+
+type rtNode struct {
+	leaf    bool
+	entries []rtEntry
+}
+
+func (n *rtNode) bounds() BBox {
+	b := n.entries[0].box
+	for _, e := range n.entries[1:] {
+		b = b.Union(e.box)
+	}
+	return b
+}
+
+// NewRTree 创建一棵空的 R 树
+func NewRTree() *RTree {
+	return &RTree{root: &rtNode{leaf: true}, height: 1, boxes: make(map[int]BBox)}
+}
+
+// Len 返回索引中的元素个数
+func (t *RTree) Len() int {
+	return len(t.boxes)
+}
+
+// Bounds 返回所有元素的外接框，索引为空时返回零值
+func (t *RTree) Bounds() BBox {
+	if len(t.root.entries) == 0 {
+		return BBox{}
+	}
+	return t.root.bounds()
+}
+
+// Insert 插入一个元素。如果 ID 已存在，旧元素会被替换。
+func (t *RTree) Insert(it Item) {
+	if _, ok := t.boxes[it.ID]; ok {
+		t.Delete(it.ID)
+	}
+	box := it.Shape.Bounds()
+	t.boxes[it.ID] = box
+	t.insertEntry(rtEntry{box: box, item: it})
+}
+
+func (t *RTree) insertEntry(e rtEntry) {
+	if sibling := t.insertAt(t.root, e, t.height-1); sibling != nil {
+		// 根节点分裂，树长高一层
+		old := t.root
+		t.root = &rtNode{entries: []rtEntry{
+			{box: old.bounds(), child: old},
+			{box: sibling.bounds(), child: sibling},
+		}}
+		t.height++
+	}
+}
+
+// insertAt 把叶子条目 e 插入以 n 为根、层级为 level 的子树 (叶子层级为 0)。
+// 如果 n 因此溢出而分裂，返回分裂出来的新兄弟节点。
+func (t *RTree) insertAt(n *rtNode, e rtEntry, level int) *rtNode {
+	if level == 0 {
+		n.entries = append(n.entries, e)
+	} else {
+		i := chooseSubtree(n, e.box)
+		child := n.entries[i].child
+		sibling := t.insertAt(child, e, level-1)
+		n.entries[i].box = child.bounds()
+		if sibling != nil {
+			n.entries = append(n.entries, rtEntry{box: sibling.bounds(), child: sibling})
+		}
+	}
+	if len(n.entries) > rtMaxEntries {
+		return split(n)
+	}
+	return nil
+}
+
+// chooseSubtree 选择插入后面积增量最小的子节点，增量相同时选面积较小的。
+func chooseSubtree(n *rtNode, box BBox) int {
+	best, bestGrow, bestArea := 0, math.Inf(1), math.Inf(1)
+	for i, e := range n.entries {
+		area := e.box.Area()
+		grow := e.box.Union(box).Area() - area
+		if grow < bestGrow || (grow == bestGrow && area < bestArea) {
+			best, bestGrow, bestArea = i, grow, area
+		}
+	}
+	return best
+}
+
+// split 把溢出的节点一分为二 (简化版的 R* 分裂)：
+// 先选出分组后总周长最小的坐标轴，再在该轴上选择两组外接框重叠面积最小的分割位置。
+// n 保留前一半条目，返回装有后一半条目的新节点。
+func split(n *rtNode) *rtNode {
+	byX := func(a, b rtEntry) int { return cmpFloat(a.box.Min.X+a.box.Max.X, b.box.Min.X+b.box.Max.X) }
+	byY := func(a, b rtEntry) int { return cmpFloat(a.box.Min.Y+a.box.Max.Y, b.box.Min.Y+b.box.Max.Y) }
+
+	margin := func(cmp func(a, b rtEntry) int) float64 {
+		slices.SortFunc(n.entries, cmp)
+		var sum float64
+		for k := rtMinEntries; k <= len(n.entries)-rtMinEntries; k++ {
+			l, r := boundsOf(n.entries[:k]), boundsOf(n.entries[k:])
+			sum += l.Width() + l.Height() + r.Width() + r.Height()
+		}
+		return sum
+	}
+	if margin(byX) < margin(byY) {
+		slices.SortFunc(n.entries, byX)
+	} // 否则 margin(byY) 最后执行，条目已经按 Y 排好序
+
+	bestK, bestOverlap, bestArea := rtMinEntries, math.Inf(1), math.Inf(1)
+	for k := rtMinEntries; k <= len(n.entries)-rtMinEntries; k++ {
+		l, r := boundsOf(n.entries[:k]), boundsOf(n.entries[k:])
+		overlap := overlapArea(l, r)
+		area := l.Area() + r.Area()
+		if overlap < bestOverlap || (overlap == bestOverlap && area < bestArea) {
+			bestK, bestOverlap, bestArea = k, overlap, area
+		}
+	}
+	sibling := &rtNode{leaf: n.leaf, entries: append([]rtEntry(nil), n.entries[bestK:]...)}
+	n.entries = append([]rtEntry(nil), n.entries[:bestK]...)
+	return sibling
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boundsOf(entries []rtEntry) BBox {
+	b := entries[0].box
+	for _, e := range entries[1:] {
+		b = b.Union(e.box)
+	}
+	return b
+}
+
+func overlapArea(a, b BBox) float64 {
+	w := math.Min(a.Max.X, b.Max.X) - math.Max(a.Min.X, b.Min.X)
+	h := math.Min(a.Max.Y, b.Max.Y) - math.Max(a.Min.Y, b.Min.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// Delete 删除指定 ID 的元素，返回该元素是否存在。
+// 删除后条目过少的节点会被拆掉，其中的元素重新插入，以保持树的平衡。
+func (t *RTree) Delete(id int) bool {
+	box, ok := t.boxes[id]
+	if !ok {
+		return false
+	}
+	var orphans []Item
+	t.remove(t.root, id, box, &orphans)
+	delete(t.boxes, id)
+
+	// 根节点只剩一个子节点时降低树高
+	for !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+		t.height--
+	}
+	if !t.root.leaf && len(t.root.entries) == 0 {
+		t.root, t.height = &rtNode{leaf: true}, 1
+	}
+	for _, it := range orphans {
+		t.insertEntry(rtEntry{box: t.boxes[it.ID], item: it})
+	}
+	return true
+}
+
+// remove 在子树 n 中删除元素，下溢的子节点会被摘除，其中的元素收集到 orphans。
+func (t *RTree) remove(n *rtNode, id int, box BBox, orphans *[]Item) bool {
+	if n.leaf {
+		i := slices.IndexFunc(n.entries, func(e rtEntry) bool { return e.item.ID == id })
+		if i < 0 {
+			return false
+		}
+		n.entries = slices.Delete(n.entries, i, i+1)
+		return true
+	}
+	for i, e := range n.entries {
+		if !e.box.Intersects(box) || !t.remove(e.child, id, box, orphans) {
+			continue
+		}
+		if len(e.child.entries) < rtMinEntries {
+			collectItems(e.child, orphans)
+			n.entries = slices.Delete(n.entries, i, i+1)
+		} else {
+			n.entries[i].box = e.child.bounds()
+		}
+		return true
+	}
+	return false
+}
+
+func collectItems(n *rtNode, out *[]Item) {
+	for _, e := range n.entries {
+		if n.leaf {
+			*out = append(*out, e.item)
+		} else {
+			collectItems(e.child, out)
+		}
+	}
+}
+
+// Search 返回外接框与 box 相交的所有元素。
+// 注意这是基于外接框的粗筛，需要精确结果时可以再用 Collide 等函数检查。
+func (t *RTree) Search(box BBox) []Item {
+	var out []Item
+	t.SearchFunc(box, func(it Item) bool {
+		out = append(out, it)
+		return true
+	})
+	return out
+}
+
+// SearchFunc 对外接框与 box 相交的每个元素调用 fn，fn 返回 false 时停止遍历。
+func (t *RTree) SearchFunc(box BBox, fn func(Item) bool) {
+	if len(t.root.entries) > 0 {
+		searchNode(t.root, box, fn)
+	}
+}
+
+func searchNode(n *rtNode, box BBox, fn func(Item) bool) bool {
+	for _, e := range n.entries {
+		if !e.box.Intersects(box) {
+			continue
+		}
+		if n.leaf {
+			if !fn(e.item) {
+				return false
+			}
+		} else if !searchNode(e.child, box, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Nearest 返回距离点 p 最近的 k 个元素，按距离从近到远排序 (距离由 Distance 计算)。
+// 使用"最佳优先"搜索：用优先队列按外接框到 p 的最小距离展开节点，
+// 因为外接框距离不会大于形状的实际距离，所以先出队的元素一定是当前最近的。
+func (t *RTree) Nearest(p Point, k int) []Item {
+	if k <= 0 || len(t.root.entries) == 0 {
+		return nil
+	}
+	pq := &nnQueue{{dist: 0, node: t.root}}
+	var out []Item
+	for pq.Len() > 0 && len(out) < k {
+		c := heap.Pop(pq).(nnCandidate)
+		if c.node == nil {
+			out = append(out, c.item)
+			continue
+		}
+		for _, e := range c.node.entries {
+			if c.node.leaf {
+				heap.Push(pq, nnCandidate{dist: Distance(e.item.Shape, p), item: e.item})
+			} else {
+				heap.Push(pq, nnCandidate{dist: boxDistance(e.box, p), node: e.child})
+			}
+		}
+	}
+	return out
+}
+
+// nnCandidate 是 k 近邻搜索中的候选者：node 非空时表示一个待展开的节点，否则是一个元素。
+type nnCandidate struct {
+	dist float64
+	node *rtNode
+	item Item
+}
+
+// nnQueue 实现 container/heap 接口，是按距离排序的小顶堆。
+type nnQueue []nnCandidate
+
+func (q nnQueue) Len() int           { return len(q) }
+func (q nnQueue) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q nnQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *nnQueue) Push(x any)        { *q = append(*q, x.(nnCandidate)) }
+func (q *nnQueue) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// BulkLoad 使用 STR (Sort-Tile-Recursive) 算法一次性构建 R 树。
+// 对大量静态数据来说，它比逐个插入快得多，而且生成的节点重叠更少、查询更快。
+// ID 重复时保留最后一个。
+func BulkLoad(items []Item) *RTree {
+	t := NewRTree()
+	entries := make([]rtEntry, 0, len(items))
+	for _, it := range items {
+		if _, dup := t.boxes[it.ID]; dup {
+			i := slices.IndexFunc(entries, func(e rtEntry) bool { return e.item.ID == it.ID })
+			entries = slices.Delete(entries, i, i+1)
+		}
+		box := it.Shape.Bounds()
+		t.boxes[it.ID] = box
+		entries = append(entries, rtEntry{box: box, item: it})
+	}
+	if len(entries) == 0 {
+		return t
+	}
+	leaf := true
+	for {
+		nodes := strPack(entries, leaf)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			return t
+		}
+		entries = make([]rtEntry, len(nodes))
+		for i, n := range nodes {
+			entries[i] = rtEntry{box: n.bounds(), child: n}
+		}
+		leaf = false
+		t.height++
+	}
+}
+
+// strPack 把条目按 STR 规则打包成一层节点：
+// 先按 X 排序切成若干竖条，每个竖条内再按 Y 排序，依次每 rtMaxEntries 个装进一个节点。
+func strPack(entries []rtEntry, leaf bool) []*rtNode {
+	leaves := (len(entries) + rtMaxEntries - 1) / rtMaxEntries
+	slabs := int(math.Ceil(math.Sqrt(float64(leaves))))
+	slabSize := slabs * rtMaxEntries
+
+	slices.SortFunc(entries, func(a, b rtEntry) int {
+		return cmpFloat(a.box.Min.X+a.box.Max.X, b.box.Min.X+b.box.Max.X)
+	})
+	var nodes []*rtNode
+	for s := 0; s < len(entries); s += slabSize {
+		slab := entries[s:min(s+slabSize, len(entries))]
+		slices.SortFunc(slab, func(a, b rtEntry) int {
+			return cmpFloat(a.box.Min.Y+a.box.Max.Y, b.box.Min.Y+b.box.Max.Y)
+		})
+		for i := 0; i < len(slab); i += rtMaxEntries {
+			chunk := slab[i:min(i+rtMaxEntries, len(slab))]
+			nodes = append(nodes, &rtNode{leaf: leaf, entries: append([]rtEntry(nil), chunk...)})
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,176 @@
+package geometry
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// randomItems 生成 n 个随机分布在 1000x1000 区域内的小矩形和小圆。
+// 使用固定种子，保证每次运行的数据相同。
+func randomItems(n int) []Item {
+	r := rand.New(rand.NewPCG(1, 2))
+	items := make([]Item, n)
+	for i := range items {
+		x, y := r.Float64()*1000, r.Float64()*1000
+		if i%2 == 0 {
+			items[i] = Item{ID: i, Shape: Rectangle{Origin: Pt(x, y), Width: r.Float64() * 5, Height: r.Float64() * 5}}
+		} else {
+			items[i] = Item{ID: i, Shape: Circle{Center: Pt(x, y), Radius: r.Float64() * 3}}
+		}
+	}
+	return items
+}
+
+// linearSearch 是作为对照组的线性扫描
+func linearSearch(items []Item, box BBox) []Item {
+	var out []Item
+	for _, it := range items {
+		if it.Shape.Bounds().Intersects(box) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func linearNearest(items []Item, p Point, k int) []Item {
+	sorted := slices.Clone(items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Distance(sorted[i].Shape, p) < Distance(sorted[j].Shape, p)
+	})
+	return sorted[:min(k, len(sorted))]
+}
+
+func ids(items []Item) []int {
+	out := make([]int, len(items))
+	for i, it := range items {
+		out[i] = it.ID
+	}
+	slices.Sort(out)
+	return out
+}
+
+// TestRTreeMatchesLinearScan 检查逐个插入和批量加载得到的树，查询结果都与线性扫描一致。
+func TestRTreeMatchesLinearScan(t *testing.T) {
+	items := randomItems(2000)
+	inserted := NewRTree()
+	for _, it := range items {
+		inserted.Insert(it)
+	}
+	trees := map[string]*RTree{
+		"insert":    inserted,
+		"bulk load": BulkLoad(items),
+	}
+	queries := []BBox{
+		{Min: Pt(0, 0), Max: Pt(100, 100)},
+		{Min: Pt(450, 450), Max: Pt(550, 700)},
+		{Min: Pt(-10, -10), Max: Pt(-1, -1)},
+		{Min: Pt(0, 0), Max: Pt(1000, 1000)},
+	}
+
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if tree.Len() != len(items) {
+				t.Fatalf("Len() = %d; want %d", tree.Len(), len(items))
+			}
+			for _, q := range queries {
+				got, want := ids(tree.Search(q)), ids(linearSearch(items, q))
+				if !slices.Equal(got, want) {
+					t.Errorf("Search(%v) returned %d items; want %d", q, len(got), len(want))
+				}
+			}
+			p := Pt(500, 500)
+			got, want := tree.Nearest(p, 10), linearNearest(items, p, 10)
+			for i := range want {
+				if Distance(got[i].Shape, p) != Distance(want[i].Shape, p) {
+					t.Errorf("Nearest #%d distance = %v; want %v", i, Distance(got[i].Shape, p), Distance(want[i].Shape, p))
+				}
+			}
+		})
+	}
+}
+
+// TestRTreeDelete 删除一半元素后，剩下的元素仍然都能被查到。
+func TestRTreeDelete(t *testing.T) {
+	items := randomItems(1000)
+	tree := BulkLoad(items)
+	var kept []Item
+	for _, it := range items {
+		if it.ID%2 == 0 {
+			if !tree.Delete(it.ID) {
+				t.Fatalf("Delete(%d) = false; want true", it.ID)
+			}
+		} else {
+			kept = append(kept, it)
+		}
+	}
+	if tree.Delete(0) {
+		t.Errorf("Delete of a missing ID returned true")
+	}
+	if tree.Len() != len(kept) {
+		t.Fatalf("Len() = %d; want %d", tree.Len(), len(kept))
+	}
+	all := BBox{Min: Pt(-100, -100), Max: Pt(1100, 1100)}
+	if got, want := ids(tree.Search(all)), ids(kept); !slices.Equal(got, want) {
+		t.Errorf("after delete Search returned %d items; want %d", len(got), len(want))
+	}
+}
+
+// --- 基准测试：R 树 vs 线性扫描 ---
+// 运行：go test -bench . ./week3/packages/geometry/
+
+const benchSize = 20000
+
+var benchQuery = BBox{Min: Pt(400, 400), Max: Pt(450, 450)}
+
+func BenchmarkRTreeSearch(b *testing.B) {
+	tree := BulkLoad(randomItems(benchSize))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Search(benchQuery)
+	}
+}
+
+func BenchmarkLinearSearch(b *testing.B) {
+	items := randomItems(benchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linearSearch(items, benchQuery)
+	}
+}
+
+func BenchmarkRTreeNearest(b *testing.B) {
+	tree := BulkLoad(randomItems(benchSize))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Nearest(Pt(500, 500), 10)
+	}
+}
+
+func BenchmarkLinearNearest(b *testing.B) {
+	items := randomItems(benchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linearNearest(items, Pt(500, 500), 10)
+	}
+}
+
+func BenchmarkRTreeInsert(b *testing.B) {
+	items := randomItems(benchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree := NewRTree()
+		for _, it := range items {
+			tree.Insert(it)
+		}
+	}
+}
+
+func BenchmarkBulkLoad(b *testing.B) {
+	items := randomItems(benchSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BulkLoad(items)
+	}
+}
@@ -0,0 +1,147 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// Epsilon 是几何计算中判断"近似为零"时使用的容差。
+const Epsilon = 1e-9
+
+// Shape 是所有二维形状的公共接口。
+// 只要实现了下面四个方法，就可以被统一地计算面积、周长、外接框和质心。
+type Shape interface {
+	Area() float64      // 面积
+	Perimeter() float64 // 周长
+	Bounds() BBox       // 轴对齐外接框
+	Centroid() Point    // 质心 (几何中心)
+}
+
+// 编译期检查：确保各个形状都实现了 Shape 接口。
+var (
+	_ Shape = Circle{}
+	_ Shape = Rectangle{}
+	_ Shape = Triangle{}
+	_ Shape = Ellipse{}
+	_ Shape = RegularPolygon{}
+	_ Shape = Polygon{}
+)
+
+// Point 表示平面上的一个点，也可以当作二维向量使用。
+type Point struct {
+	X, Y float64
+}
+
+// Pt 是 Point{X: x, Y: y} 的简写。
+func Pt(x, y float64) Point {
+	return Point{X: x, Y: y}
+}
+
+// Add 返回 p + q
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Sub 返回 p - q
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Mul 返回 p 按 k 缩放后的结果
+func (p Point) Mul(k float64) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Dot 返回点积 p·q
+func (p Point) Dot(q Point) float64 {
+	return p.X*q.X + p.Y*q.Y
+}
+
+// Cross 返回二维叉积 p×q (即 z 分量)，正值表示 q 在 p 的逆时针方向。
+func (p Point) Cross(q Point) float64 {
+	return p.X*q.Y - p.Y*q.X
+}
+
+// Len 返回向量长度
+func (p Point) Len() float64 {
+	return math.Hypot(p.X, p.Y)
+}
+
+// Dist 返回两点之间的欧氏距离
+func (p Point) Dist(q Point) float64 {
+	return p.Sub(q).Len()
+}
+
+// Eq 判断两点在 Epsilon 容差内是否相等
+func (p Point) Eq(q Point) bool {
+	return math.Abs(p.X-q.X) <= Epsilon && math.Abs(p.Y-q.Y) <= Epsilon
+}
+
+// String 实现 fmt.Stringer
+func (p Point) String() string {
+	return fmt.Sprintf("(%g, %g)", p.X, p.Y)
+}
+
+// valid 判断坐标是否为有限数值 (非 NaN、非 Inf)
+func (p Point) valid() bool {
+	return finite(p.X) && finite(p.Y)
+}
+
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// BBox 是轴对齐的外接框 (Axis-Aligned Bounding Box)。
+type BBox struct {
+	Min, Max Point
+}
+
+// BoxOf 返回包含所有给定点的最小外接框。没有点时返回零值。
+func BoxOf(pts ...Point) BBox {
+	if len(pts) == 0 {
+		return BBox{}
+	}
+	b := BBox{Min: pts[0], Max: pts[0]}
+	for _, p := range pts[1:] {
+		b = b.ExtendPoint(p)
+	}
+	return b
+}
+
+// Width 返回外接框宽度
+func (b BBox) Width() float64 { return b.Max.X - b.Min.X }
+
+// Height 返回外接框高度
+func (b BBox) Height() float64 { return b.Max.Y - b.Min.Y }
+
+// Area 返回外接框面积
+func (b BBox) Area() float64 { return b.Width() * b.Height() }
+
+// Center 返回外接框中心
+func (b BBox) Center() Point {
+	return Point{(b.Min.X + b.Max.X) / 2, (b.Min.Y + b.Max.Y) / 2}
+}
+
+// ExtendPoint 返回扩展到包含 p 的外接框
+func (b BBox) ExtendPoint(p Point) BBox {
+	return BBox{
+		Min: Point{math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y)},
+		Max: Point{math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y)},
+	}
+}
+
+// Union 返回同时包含 b 和 o 的最小外接框
+func (b BBox) Union(o BBox) BBox {
+	return b.ExtendPoint(o.Min).ExtendPoint(o.Max)
+}
+
+// Intersects 判断两个外接框是否相交 (边界接触也算相交)
+func (b BBox) Intersects(o BBox) bool {
+	return b.Min.X <= o.Max.X && o.Min.X <= b.Max.X &&
+		b.Min.Y <= o.Max.Y && o.Min.Y <= b.Max.Y
+}
+
+// Contains 判断点 p 是否在外接框内 (含边界)
+func (b BBox) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
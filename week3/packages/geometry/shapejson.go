@@ -0,0 +1,193 @@
+package geometry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 带类型标签的形状 JSON 编码
+//
+// GeoJSON 只有点、线、多边形，圆和椭圆编码后会变成近似的多边形。
+// MarshalShape / UnmarshalShape 使用 "type" 字段区分形状，保留每种形状的原始参数，
+// 坐标统一写作 [x, y] 数组：
+//
+//	{"type": "circle", "center": [0, 0], "radius": 7}
+//	{"type": "rectangle", "origin": [0, 0], "width": 10, "height": 5}
+//	{"type": "triangle", "points": [[0, 0], [4, 0], [0, 3]]}
+//	{"type": "ellipse", "center": [0, 0], "rx": 3, "ry": 2, "angle": 0.5}
+//	{"type": "regular_polygon", "center": [0, 0], "sides": 6, "radius": 2, "rotation": 0}
+//	{"type": "polygon", "points": [[0, 0], [4, 0], [4, 3]]}
+//
+// 解码时也接受 GeoJSON Polygon 和 MultiPolygon ({"type": "Polygon", "coordinates": ...})，
+// 带洞的多边形和布尔运算的结果 (Region、MultiPolygon) 也按这种格式编码。
+// 省略的可选字段 (center、origin、angle、rotation) 取零值，其余字段缺失时报错。
+
+// ErrInvalidShape 表示 JSON 格式正确，但形状参数没有通过构造函数的校验。
+var ErrInvalidShape = errors.New("无效的形状")
+
+// shapeJSON 是所有形状共用的线上格式，指针字段用来区分"缺失"和"零值"。
+type shapeJSON struct {
+	Type        string          `json:"type"`
+	Center      *[2]float64     `json:"center,omitempty"`
+	Origin      *[2]float64     `json:"origin,omitempty"`
+	Radius      *float64        `json:"radius,omitempty"`
+	Width       *float64        `json:"width,omitempty"`
+	Height      *float64        `json:"height,omitempty"`
+	RX          *float64        `json:"rx,omitempty"`
+	RY          *float64        `json:"ry,omitempty"`
+	Angle       float64         `json:"angle,omitempty"`
+	Sides       *int            `json:"sides,omitempty"`
+	Rotation    float64         `json:"rotation,omitempty"`
+	Points      [][2]float64    `json:"points,omitempty"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+}
+
+// MarshalShape 把形状编码为带类型标签的 JSON。
+// Region 和 MultiPolygon 没有对应的标签，编码为 GeoJSON Polygon / MultiPolygon (UnmarshalShape 同样接受)。
+func MarshalShape(s Shape) ([]byte, error) {
+	var w shapeJSON
+	switch v := s.(type) {
+	case Region, MultiPolygon:
+		return MarshalGeoJSON(v)
+	case Circle:
+		w = shapeJSON{Type: "circle", Center: xy(v.Center), Radius: &v.Radius}
+	case Rectangle:
+		w = shapeJSON{Type: "rectangle", Origin: xy(v.Origin), Width: &v.Width, Height: &v.Height}
+	case Triangle:
+		w = shapeJSON{Type: "triangle", Points: xys(v.A, v.B, v.C)}
+	case Ellipse:
+		w = shapeJSON{Type: "ellipse", Center: xy(v.Center), RX: &v.RX, RY: &v.RY, Angle: v.Angle}
+	case RegularPolygon:
+		w = shapeJSON{Type: "regular_polygon", Center: xy(v.Center), Sides: &v.Sides, Radius: &v.Radius, Rotation: v.Rotation}
+	case Polygon:
+		w = shapeJSON{Type: "polygon", Points: xys(v.Points...)}
+	default:
+		return nil, fmt.Errorf("不支持编码 %T 类型的形状", s)
+	}
+	return json.Marshal(w)
+}
+
+// UnmarshalShape 解码 MarshalShape 的输出 (或 GeoJSON Polygon / MultiPolygon)，并通过对应的构造函数校验参数。
+// JSON 语法或字段类型错误返回 *ParseError，参数校验失败返回包装了 ErrInvalidShape 的错误。
+func UnmarshalShape(data []byte) (Shape, error) {
+	var w shapeJSON
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, jsonFormatError(err)
+	}
+	if w.Coordinates != nil && (w.Type == "Polygon" || w.Type == "MultiPolygon") {
+		g, err := ParseGeoJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return g.(Shape), nil
+	}
+	s, err := w.shape()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidShape, err)
+	}
+	return s, nil
+}
+
+func (w shapeJSON) shape() (Shape, error) {
+	center, origin := pointOf(w.Center), pointOf(w.Origin)
+	switch strings.ToLower(w.Type) {
+	case "circle":
+		r, err := required("radius", w.Radius)
+		if err != nil {
+			return nil, err
+		}
+		return NewCircle(center, r)
+	case "rectangle":
+		width, err := required("width", w.Width)
+		if err != nil {
+			return nil, err
+		}
+		height, err := required("height", w.Height)
+		if err != nil {
+			return nil, err
+		}
+		return NewRectangleAt(origin, width, height)
+	case "triangle":
+		if len(w.Points) != 3 {
+			return nil, fmt.Errorf("三角形需要 3 个顶点, 得到 %d", len(w.Points))
+		}
+		pts := pointsOf(w.Points)
+		return NewTriangle(pts[0], pts[1], pts[2])
+	case "ellipse":
+		rx, err := required("rx", w.RX)
+		if err != nil {
+			return nil, err
+		}
+		ry, err := required("ry", w.RY)
+		if err != nil {
+			return nil, err
+		}
+		e, err := NewEllipse(center, rx, ry)
+		if err != nil {
+			return nil, err
+		}
+		if !finite(w.Angle) {
+			return nil, fmt.Errorf("椭圆旋转角必须是有限数值: angle=%v", w.Angle)
+		}
+		e.Angle = w.Angle
+		return e, nil
+	case "regular_polygon":
+		r, err := required("radius", w.Radius)
+		if err != nil {
+			return nil, err
+		}
+		if w.Sides == nil {
+			return nil, fmt.Errorf("缺少字段 %q", "sides")
+		}
+		return NewRegularPolygon(center, *w.Sides, r, w.Rotation)
+	case "polygon":
+		return NewPolygon(pointsOf(w.Points))
+	case "":
+		return nil, fmt.Errorf("缺少字段 %q", "type")
+	default:
+		return nil, fmt.Errorf("未知的形状类型 %q", w.Type)
+	}
+}
+
+func required(name string, v *float64) (float64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("缺少字段 %q", name)
+	}
+	return *v, nil
+}
+
+func xy(p Point) *[2]float64 {
+	return &[2]float64{p.X, p.Y}
+}
+
+func xys(pts ...Point) [][2]float64 {
+	out := make([][2]float64, len(pts))
+	for i, p := range pts {
+		out[i] = [2]float64{p.X, p.Y}
+	}
+	return out
+}
+
+func pointOf(v *[2]float64) Point {
+	if v == nil {
+		return Point{}
+	}
+	return Point{v[0], v[1]}
+}
+
+func pointsOf(vs [][2]float64) []Point {
+	pts := make([]Point, len(vs))
+	for i, v := range vs {
+		pts[i] = Point{v[0], v[1]}
+	}
+	return pts
+}
+
+// jsonFormatError 把 encoding/json 的错误转换为 *ParseError
+func jsonFormatError(err error) error {
+	pe := jsonError(err, 0).(*ParseError)
+	pe.Format = "JSON"
+	return pe
+}
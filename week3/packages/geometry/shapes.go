@@ -0,0 +1,216 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+)
+
+// --- Circle ---
+
+// NewCircle 是 Circle 的构造函数，半径不能为负数。
+func NewCircle(center Point, radius float64) (Circle, error) {
+	if radius < 0 {
+		return Circle{}, fmt.Errorf("半径不能为负数: radius=%.2f", radius)
+	}
+	if !center.valid() || !finite(radius) {
+		return Circle{}, fmt.Errorf("圆参数必须是有限数值: center=%v, radius=%v", center, radius)
+	}
+	return Circle{Center: center, Radius: radius}, nil
+}
+
+// Area 计算圆面积，与 CircleArea 结果相同，用于实现 Shape 接口。
+func (c Circle) Area() float64 {
+	return c.CircleArea()
+}
+
+// Perimeter 计算圆周长
+func (c Circle) Perimeter() float64 {
+	return 2 * Pi * c.Radius
+}
+
+// Bounds 返回圆的外接正方形
+func (c Circle) Bounds() BBox {
+	r := Point{c.Radius, c.Radius}
+	return BBox{Min: c.Center.Sub(r), Max: c.Center.Add(r)}
+}
+
+// Centroid 返回圆心
+func (c Circle) Centroid() Point {
+	return c.Center
+}
+
+// --- Rectangle ---
+
+// Bounds 返回矩形本身所占的区域
+func (r Rectangle) Bounds() BBox {
+	return BBox{Min: r.Origin, Max: r.Origin.Add(Point{r.Width, r.Height})}
+}
+
+// Centroid 返回矩形中心
+func (r Rectangle) Centroid() Point {
+	return r.Origin.Add(Point{r.Width / 2, r.Height / 2})
+}
+
+// Vertices 按逆时针顺序返回矩形的四个顶点 (从左下角开始)
+func (r Rectangle) Vertices() []Point {
+	o := r.Origin
+	return []Point{
+		o,
+		{o.X + r.Width, o.Y},
+		{o.X + r.Width, o.Y + r.Height},
+		{o.X, o.Y + r.Height},
+	}
+}
+
+// --- Triangle ---
+
+// Triangle 由三个顶点构成
+type Triangle struct {
+	A, B, C Point
+}
+
+// NewTriangle 创建三角形，三点共线 (面积为零) 时返回错误。
+func NewTriangle(a, b, c Point) (Triangle, error) {
+	if !a.valid() || !b.valid() || !c.valid() {
+		return Triangle{}, fmt.Errorf("三角形顶点必须是有限数值: %v, %v, %v", a, b, c)
+	}
+	t := Triangle{A: a, B: b, C: c}
+	if t.Area() <= Epsilon {
+		return Triangle{}, fmt.Errorf("三角形顶点共线: %v, %v, %v", a, b, c)
+	}
+	return t, nil
+}
+
+// Area 用叉积计算三角形面积
+func (t Triangle) Area() float64 {
+	return math.Abs(t.B.Sub(t.A).Cross(t.C.Sub(t.A))) / 2
+}
+
+// Perimeter 返回三边长度之和
+func (t Triangle) Perimeter() float64 {
+	return t.A.Dist(t.B) + t.B.Dist(t.C) + t.C.Dist(t.A)
+}
+
+// Bounds 返回三角形的外接框
+func (t Triangle) Bounds() BBox {
+	return BoxOf(t.A, t.B, t.C)
+}
+
+// Centroid 返回三个顶点的平均值
+func (t Triangle) Centroid() Point {
+	return t.A.Add(t.B).Add(t.C).Mul(1.0 / 3)
+}
+
+// Vertices 返回三个顶点
+func (t Triangle) Vertices() []Point {
+	return []Point{t.A, t.B, t.C}
+}
+
+// --- Ellipse ---
+
+// Ellipse 是椭圆，RX、RY 分别是椭圆自身坐标系下 X、Y 方向的半轴长度。
+// Angle 是椭圆 X 半轴相对坐标系 X 轴正方向的旋转角度 (弧度)，零值表示轴对齐。
+type Ellipse struct {
+	Center Point
+	RX, RY float64
+	Angle  float64
+}
+
+// NewEllipse 创建轴对齐的椭圆，半轴长度不能为负数。
+func NewEllipse(center Point, rx, ry float64) (Ellipse, error) {
+	if rx < 0 || ry < 0 {
+		return Ellipse{}, fmt.Errorf("椭圆半轴不能为负数: rx=%.2f, ry=%.2f", rx, ry)
+	}
+	if !center.valid() || !finite(rx) || !finite(ry) {
+		return Ellipse{}, fmt.Errorf("椭圆参数必须是有限数值: center=%v, rx=%v, ry=%v", center, rx, ry)
+	}
+	return Ellipse{Center: center, RX: rx, RY: ry}, nil
+}
+
+// Area 计算椭圆面积 πab
+func (e Ellipse) Area() float64 {
+	return Pi * e.RX * e.RY
+}
+
+// Perimeter 使用 Ramanujan 第二近似公式计算椭圆周长。
+// 椭圆周长没有初等闭式解，这个公式在常见的长短轴比例下误差极小。
+func (e Ellipse) Perimeter() float64 {
+	a, b := e.RX, e.RY
+	if a+b == 0 {
+		return 0
+	}
+	h := (a - b) * (a - b) / ((a + b) * (a + b))
+	return Pi * (a + b) * (1 + 3*h/(10+math.Sqrt(4-3*h)))
+}
+
+// Bounds 返回椭圆的外接框 (考虑了旋转角度)
+func (e Ellipse) Bounds() BBox {
+	sin, cos := math.Sincos(e.Angle)
+	hx := math.Hypot(e.RX*cos, e.RY*sin)
+	hy := math.Hypot(e.RX*sin, e.RY*cos)
+	r := Point{hx, hy}
+	return BBox{Min: e.Center.Sub(r), Max: e.Center.Add(r)}
+}
+
+// Centroid 返回椭圆中心
+func (e Ellipse) Centroid() Point {
+	return e.Center
+}
+
+// --- RegularPolygon ---
+
+// RegularPolygon 是正多边形。
+// Radius 是外接圆半径，Rotation 是第一个顶点相对 X 轴正方向的角度 (弧度)。
+type RegularPolygon struct {
+	Center   Point
+	Sides    int
+	Radius   float64
+	Rotation float64
+}
+
+// NewRegularPolygon 创建正多边形，边数至少为 3，半径必须为正数。
+func NewRegularPolygon(center Point, sides int, radius, rotation float64) (RegularPolygon, error) {
+	if sides < 3 {
+		return RegularPolygon{}, fmt.Errorf("正多边形至少需要 3 条边: sides=%d", sides)
+	}
+	if radius <= 0 {
+		return RegularPolygon{}, fmt.Errorf("正多边形半径必须为正数: radius=%.2f", radius)
+	}
+	if !center.valid() || !finite(radius) || !finite(rotation) {
+		return RegularPolygon{}, fmt.Errorf("正多边形参数必须是有限数值: center=%v, radius=%v, rotation=%v", center, radius, rotation)
+	}
+	return RegularPolygon{Center: center, Sides: sides, Radius: radius, Rotation: rotation}, nil
+}
+
+// Area 计算正多边形面积 n/2 · R² · sin(2π/n)
+func (p RegularPolygon) Area() float64 {
+	n := float64(p.Sides)
+	return n / 2 * p.Radius * p.Radius * math.Sin(2*Pi/n)
+}
+
+// Perimeter 计算正多边形周长 2nR · sin(π/n)
+func (p RegularPolygon) Perimeter() float64 {
+	n := float64(p.Sides)
+	return 2 * n * p.Radius * math.Sin(Pi/n)
+}
+
+// Bounds 返回所有顶点的外接框
+func (p RegularPolygon) Bounds() BBox {
+	return BoxOf(p.Vertices()...)
+}
+
+// Centroid 返回正多边形中心
+func (p RegularPolygon) Centroid() Point {
+	return p.Center
+}
+
+// Vertices 按逆时针顺序返回所有顶点
+func (p RegularPolygon) Vertices() []Point {
+	pts := make([]Point, p.Sides)
+	step := 2 * Pi / float64(p.Sides)
+	for i := range pts {
+		a := p.Rotation + step*float64(i)
+		pts[i] = Point{p.Center.X + p.Radius*math.Cos(a), p.Center.Y + p.Radius*math.Sin(a)}
+	}
+	return pts
+}
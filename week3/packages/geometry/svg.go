@@ -0,0 +1,192 @@
+package geometry
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVGStyle 描述单个形状的绘制样式，空字段使用 SVGOptions.Style 中的默认值。
+type SVGStyle struct {
+	Stroke      string  // 描边颜色，如 "black"、"#336699"
+	StrokeWidth float64 // 描边宽度 (屏幕像素，不随缩放变化)
+	Fill        string  // 填充颜色，"none" 表示不填充
+	FillOpacity float64 // 填充不透明度 (0~1)，0 表示使用默认值
+	Label       string  // 标注文字，绘制在形状质心处
+}
+
+// SVGItem 是一个待绘制的形状及其样式
+type SVGItem struct {
+	Shape Shape
+	Style SVGStyle
+}
+
+// SVGOptions 是整张图的选项
+type SVGOptions struct {
+	Width      int      // 输出图片的像素宽度，默认 800，高度按内容比例计算
+	Padding    float64  // 内容四周留白 (坐标单位)，默认为内容尺寸的 5%
+	Background string   // 背景颜色，为空表示透明
+	Style      SVGStyle // 默认样式
+	FontSize   float64  // 标注字号 (坐标单位)，默认为内容尺寸的 3%
+}
+
+var defaultSVGStyle = SVGStyle{Stroke: "#1f77b4", StrokeWidth: 1.5, Fill: "#1f77b4", FillOpacity: 0.25}
+
+// WriteSVG 把一组形状绘制为完整的 SVG 文档并写入 w。
+//
+// viewBox 由所有形状外接框的并集自动计算。几何坐标系的 Y 轴向上，
+// 而 SVG 的 Y 轴向下，所以绘制前会先做一次上下翻转，保证图像方向与数学坐标一致。
+func WriteSVG(w io.Writer, items []SVGItem, opts SVGOptions) error {
+	if len(items) == 0 {
+		return fmt.Errorf("没有需要绘制的形状")
+	}
+	bounds := items[0].Shape.Bounds()
+	for _, it := range items[1:] {
+		bounds = bounds.Union(it.Shape.Bounds())
+	}
+	size := math.Max(bounds.Width(), bounds.Height())
+	if size <= Epsilon {
+		size = 1
+	}
+	pad := opts.Padding
+	if pad <= 0 {
+		pad = size * 0.05
+	}
+	fontSize := opts.FontSize
+	if fontSize <= 0 {
+		fontSize = size * 0.03
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = 800
+	}
+	vb := BBox{Min: bounds.Min.Sub(Point{pad, pad}), Max: bounds.Max.Add(Point{pad, pad})}
+	height := int(math.Round(float64(width) * vb.Height() / vb.Width()))
+
+	// 上下翻转：y' = (minY + maxY) - y
+	flip := Transform{A: 1, D: -1, F: vb.Min.Y + vb.Max.Y}
+	defaults := mergeStyle(opts.Style, defaultSVGStyle)
+
+	sw := &svgWriter{w: w}
+	sw.printf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sw.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%s %s %s %s">`+"\n",
+		width, height, num(vb.Min.X), num(vb.Min.Y), num(vb.Width()), num(vb.Height()))
+	if opts.Background != "" {
+		sw.printf(`  <rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+			num(vb.Min.X), num(vb.Min.Y), num(vb.Width()), num(vb.Height()), esc(opts.Background))
+	}
+	for _, it := range items {
+		shape, err := flip.ApplyShape(it.Shape)
+		if err != nil {
+			return err
+		}
+		st := mergeStyle(it.Style, defaults)
+		if err := sw.shape(shape, st); err != nil {
+			return err
+		}
+		if st.Label != "" {
+			c := shape.Centroid()
+			sw.printf(`  <text x="%s" y="%s" font-size="%s" font-family="sans-serif" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n",
+				num(c.X), num(c.Y), num(fontSize), esc(st.Label))
+		}
+	}
+	sw.printf("</svg>\n")
+	return sw.err
+}
+
+// mergeStyle 用 def 中的值填充 s 的空字段
+func mergeStyle(s, def SVGStyle) SVGStyle {
+	if s.Stroke == "" {
+		s.Stroke = def.Stroke
+	}
+	if s.StrokeWidth <= 0 {
+		s.StrokeWidth = def.StrokeWidth
+	}
+	if s.Fill == "" {
+		s.Fill = def.Fill
+	}
+	if s.FillOpacity <= 0 {
+		s.FillOpacity = def.FillOpacity
+	}
+	return s
+}
+
+// svgWriter 记录第一次写入错误，之后的写入全部忽略，避免每一行都检查错误。
+type svgWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (sw *svgWriter) printf(format string, args ...any) {
+	if sw.err == nil {
+		_, sw.err = fmt.Fprintf(sw.w, format, args...)
+	}
+}
+
+func (sw *svgWriter) shape(s Shape, st SVGStyle) error {
+	attrs := fmt.Sprintf(`stroke="%s" stroke-width="%s" fill="%s" fill-opacity="%s" vector-effect="non-scaling-stroke"`,
+		esc(st.Stroke), num(st.StrokeWidth), esc(st.Fill), num(st.FillOpacity))
+	switch v := s.(type) {
+	case Circle:
+		sw.printf(`  <circle cx="%s" cy="%s" r="%s" %s/>`+"\n", num(v.Center.X), num(v.Center.Y), num(v.Radius), attrs)
+	case Ellipse:
+		sw.printf(`  <ellipse cx="%s" cy="%s" rx="%s" ry="%s" transform="rotate(%s %s %s)" %s/>`+"\n",
+			num(v.Center.X), num(v.Center.Y), num(v.RX), num(v.RY),
+			num(v.Angle*180/Pi), num(v.Center.X), num(v.Center.Y), attrs)
+	case Rectangle:
+		sw.printf(`  <rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n",
+			num(v.Origin.X), num(v.Origin.Y), num(v.Width), num(v.Height), attrs)
+	case Region:
+		sw.printf(`  <path d="%s" fill-rule="evenodd" %s/>`+"\n", svgRings(v), attrs)
+	case MultiPolygon:
+		// 所有区域放在同一个 path 中，重叠的描边和填充只绘制一次
+		d := make([]string, len(v))
+		for i, r := range v {
+			d[i] = svgRings(r)
+		}
+		sw.printf(`  <path d="%s" fill-rule="evenodd" %s/>`+"\n", strings.Join(d, " "), attrs)
+	default:
+		pg, err := ToPolygon(s)
+		if err != nil {
+			return err
+		}
+		pts := make([]string, len(pg.Points))
+		for i, p := range pg.Points {
+			pts[i] = num(p.X) + "," + num(p.Y)
+		}
+		sw.printf(`  <polygon points="%s" %s/>`+"\n", strings.Join(pts, " "), attrs)
+	}
+	return sw.err
+}
+
+// svgRings 把区域的每个环编码为一段 "M x,y L x,y ... Z" 路径，洞依靠 evenodd 填充规则挖空
+func svgRings(r Region) string {
+	var sb strings.Builder
+	for _, ring := range r.rings() {
+		for i, p := range ring.Points {
+			if i == 0 {
+				sb.WriteString("M")
+			} else {
+				sb.WriteString(" L")
+			}
+			sb.WriteString(num(p.X) + "," + num(p.Y))
+		}
+		sb.WriteString(" Z ")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// num 格式化坐标，保留 6 位小数并去掉多余的 0
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e6)/1e6, 'f', -1, 64)
+}
+
+// esc 转义 XML 特殊字符
+func esc(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
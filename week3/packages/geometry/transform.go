@@ -0,0 +1,255 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Transform 是二维仿射变换，对应矩阵
+//
+//	| A  C  E |
+//	| B  D  F |
+//	| 0  0  1 |
+//
+// 即 x' = A·x + C·y + E，y' = B·x + D·y + F。
+// 字段顺序与 SVG / Canvas 的 matrix(a, b, c, d, e, f) 一致。
+// 零值不是恒等变换，请使用 Identity()。
+type Transform struct {
+	A, B, C, D, E, F float64
+}
+
+// ErrSingularTransform 表示变换矩阵不可逆 (行列式为零)，会把形状压扁成线段或点。
+var ErrSingularTransform = errors.New("变换矩阵不可逆")
+
+// Identity 返回恒等变换
+func Identity() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// Translate 返回平移变换
+func Translate(dx, dy float64) Transform {
+	return Transform{A: 1, D: 1, E: dx, F: dy}
+}
+
+// Rotate 返回绕原点逆时针旋转 theta 弧度的变换
+func Rotate(theta float64) Transform {
+	sin, cos := math.Sincos(theta)
+	return Transform{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// RotateAbout 返回绕点 c 逆时针旋转 theta 弧度的变换
+func RotateAbout(c Point, theta float64) Transform {
+	return Translate(-c.X, -c.Y).Then(Rotate(theta)).Then(Translate(c.X, c.Y))
+}
+
+// Scale 返回以原点为中心的缩放变换，负数表示镜像
+func Scale(sx, sy float64) Transform {
+	return Transform{A: sx, D: sy}
+}
+
+// ScaleAbout 返回以点 c 为中心的缩放变换
+func ScaleAbout(c Point, sx, sy float64) Transform {
+	return Translate(-c.X, -c.Y).Then(Scale(sx, sy)).Then(Translate(c.X, c.Y))
+}
+
+// Shear 返回错切变换：x' = x + kx·y，y' = y + ky·x
+func Shear(kx, ky float64) Transform {
+	return Transform{A: 1, B: ky, C: kx, D: 1}
+}
+
+// Compose 按参数顺序依次组合多个变换，即先应用 ts[0]，再应用 ts[1]，以此类推。
+func Compose(ts ...Transform) Transform {
+	r := Identity()
+	for _, t := range ts {
+		r = r.Then(t)
+	}
+	return r
+}
+
+// Then 返回"先应用 t，再应用 u"的组合变换 (矩阵乘法 u·t)。
+func (t Transform) Then(u Transform) Transform {
+	return Transform{
+		A: u.A*t.A + u.C*t.B,
+		B: u.B*t.A + u.D*t.B,
+		C: u.A*t.C + u.C*t.D,
+		D: u.B*t.C + u.D*t.D,
+		E: u.A*t.E + u.C*t.F + u.E,
+		F: u.B*t.E + u.D*t.F + u.F,
+	}
+}
+
+// Det 返回线性部分的行列式，其绝对值是面积的缩放倍数，负数表示包含镜像。
+func (t Transform) Det() float64 {
+	return t.A*t.D - t.B*t.C
+}
+
+// Invert 返回逆变换。矩阵不可逆时返回 ErrSingularTransform。
+func (t Transform) Invert() (Transform, error) {
+	det := t.Det()
+	if math.Abs(det) <= Epsilon {
+		return Transform{}, fmt.Errorf("%w: det=%g", ErrSingularTransform, det)
+	}
+	inv := Transform{A: t.D / det, B: -t.B / det, C: -t.C / det, D: t.A / det}
+	inv.E = -(inv.A*t.E + inv.C*t.F)
+	inv.F = -(inv.B*t.E + inv.D*t.F)
+	return inv, nil
+}
+
+// IsIdentity 判断是否 (在容差内) 为恒等变换
+func (t Transform) IsIdentity() bool {
+	return t.approx(Identity())
+}
+
+func (t Transform) approx(u Transform) bool {
+	return math.Abs(t.A-u.A) <= Epsilon && math.Abs(t.B-u.B) <= Epsilon &&
+		math.Abs(t.C-u.C) <= Epsilon && math.Abs(t.D-u.D) <= Epsilon &&
+		math.Abs(t.E-u.E) <= Epsilon && math.Abs(t.F-u.F) <= Epsilon
+}
+
+// Apply 对点 p 应用变换
+func (t Transform) Apply(p Point) Point {
+	return Point{t.A*p.X + t.C*p.Y + t.E, t.B*p.X + t.D*p.Y + t.F}
+}
+
+// ApplyVector 只应用线性部分 (不平移)，适用于方向向量
+func (t Transform) ApplyVector(v Point) Point {
+	return Point{t.A*v.X + t.C*v.Y, t.B*v.X + t.D*v.Y}
+}
+
+// ApplyAll 对一组点应用变换，返回新的切片
+func (t Transform) ApplyAll(pts []Point) []Point {
+	out := make([]Point, len(pts))
+	for i, p := range pts {
+		out[i] = t.Apply(p)
+	}
+	return out
+}
+
+// isSimilarity 判断线性部分是否为"旋转 (可带镜像) + 均匀缩放"，
+// 这类变换把圆映射为圆、把正多边形映射为正多边形。
+func (t Transform) isSimilarity() bool {
+	rot := math.Abs(t.A-t.D) <= Epsilon && math.Abs(t.B+t.C) <= Epsilon
+	mirror := math.Abs(t.A+t.D) <= Epsilon && math.Abs(t.B-t.C) <= Epsilon
+	return rot || mirror
+}
+
+// preservesAxes 判断坐标轴方向是否被保持 (只有缩放、镜像或 90° 倍数的旋转)，
+// 此时轴对齐矩形变换后仍然是轴对齐矩形。
+func (t Transform) preservesAxes() bool {
+	diagonal := math.Abs(t.B) <= Epsilon && math.Abs(t.C) <= Epsilon
+	swapped := math.Abs(t.A) <= Epsilon && math.Abs(t.D) <= Epsilon
+	return diagonal || swapped
+}
+
+// Transformable 由能够自行处理仿射变换的形状实现。
+// 自定义形状实现这个接口后，也可以通过 Transform.ApplyShape 进行变换。
+type Transformable interface {
+	Shape
+	Transformed(t Transform) Shape
+}
+
+// ApplyShape 对任意形状应用变换，返回变换后的形状：
+//   - 圆在相似变换下仍是圆，否则变为椭圆；
+//   - 椭圆总是变为 (可能旋转的) 椭圆；
+//   - 轴对齐矩形在保持坐标轴方向时仍是矩形，旋转或错切后变为一般多边形；
+//   - 正多边形在相似变换下仍是正多边形，否则变为一般多边形；
+//   - 三角形和多边形逐顶点变换；
+//   - Region 和 MultiPolygon 逐个环变换，并保持外边界逆时针、洞顺时针。
+//
+// 变换矩阵不可逆时返回 ErrSingularTransform。
+func (t Transform) ApplyShape(s Shape) (Shape, error) {
+	if math.Abs(t.Det()) <= Epsilon {
+		return nil, fmt.Errorf("%w: det=%g", ErrSingularTransform, t.Det())
+	}
+	switch v := s.(type) {
+	case Transformable:
+		return v.Transformed(t), nil
+	case Circle:
+		return t.circle(v), nil
+	case Ellipse:
+		return t.ellipse(v), nil
+	case Rectangle:
+		return t.rectangle(v), nil
+	case Triangle:
+		return Triangle{A: t.Apply(v.A), B: t.Apply(v.B), C: t.Apply(v.C)}, nil
+	case RegularPolygon:
+		return t.regularPolygon(v), nil
+	case Polygon:
+		return Polygon{Points: t.ApplyAll(v.Points)}, nil
+	case Region:
+		return t.region(v), nil
+	case MultiPolygon:
+		m := make(MultiPolygon, len(v))
+		for i, r := range v {
+			m[i] = t.region(r)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("不支持对 %T 类型的形状进行变换", s)
+	}
+}
+
+// region 变换区域的每个环。镜像变换 (行列式为负) 会反转顶点方向，所以需要重新统一为外边界逆时针、洞顺时针。
+func (t Transform) region(r Region) Region {
+	out := Region{Outer: orient(Polygon{Points: t.ApplyAll(r.Outer.Points)}, CounterClockwise)}
+	for _, h := range r.Holes {
+		out.Holes = append(out.Holes, orient(Polygon{Points: t.ApplyAll(h.Points)}, Clockwise))
+	}
+	return out
+}
+
+func (t Transform) circle(c Circle) Shape {
+	if t.isSimilarity() {
+		return Circle{Center: t.Apply(c.Center), Radius: c.Radius * math.Sqrt(math.Abs(t.Det()))}
+	}
+	return t.ellipse(Ellipse{Center: c.Center, RX: c.Radius, RY: c.Radius})
+}
+
+// ellipse 计算椭圆在仿射变换下的像。
+// 椭圆可以看作单位圆经过线性映射 M = L·R(angle)·diag(RX, RY) 的结果，
+// 变换后的半轴长度是 M 的奇异值，即 M·Mᵀ 特征值的平方根，主轴方向由对应特征向量给出。
+func (t Transform) ellipse(e Ellipse) Ellipse {
+	sin, cos := math.Sincos(e.Angle)
+	// M 的两列分别是椭圆两个半轴向量经过线性变换后的结果
+	u := t.ApplyVector(Point{e.RX * cos, e.RX * sin})
+	v := t.ApplyVector(Point{-e.RY * sin, e.RY * cos})
+	p := u.X*u.X + v.X*v.X
+	q := u.X*u.Y + v.X*v.Y
+	r := u.Y*u.Y + v.Y*v.Y
+	mid := (p + r) / 2
+	d := math.Hypot((p-r)/2, q)
+	return Ellipse{
+		Center: t.Apply(e.Center),
+		RX:     math.Sqrt(mid + d),
+		RY:     math.Sqrt(math.Max(mid-d, 0)),
+		Angle:  math.Atan2(2*q, p-r) / 2,
+	}
+}
+
+func (t Transform) rectangle(r Rectangle) Shape {
+	pts := t.ApplyAll(r.Vertices())
+	if t.preservesAxes() {
+		b := BoxOf(pts...)
+		return Rectangle{Origin: b.Min, Width: b.Width(), Height: b.Height()}
+	}
+	if t.Det() < 0 {
+		// 镜像会把逆时针的顶点顺序变成顺时针，这里统一调整回逆时针
+		return Polygon{Points: pts}.Reversed()
+	}
+	return Polygon{Points: pts}
+}
+
+func (t Transform) regularPolygon(p RegularPolygon) Shape {
+	if !t.isSimilarity() {
+		return Polygon{Points: t.ApplyAll(p.Vertices())}
+	}
+	center := t.Apply(p.Center)
+	first := t.Apply(p.Vertices()[0]).Sub(center)
+	return RegularPolygon{
+		Center:   center,
+		Sides:    p.Sides,
+		Radius:   first.Len(),
+		Rotation: math.Atan2(first.Y, first.X),
+	}
+}
@@ -0,0 +1,322 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrCollinearPoints 表示点集全部共线 (或有效点不足 3 个)，无法构成任何三角形。
+var ErrCollinearPoints = errors.New("点集共线，无法三角剖分")
+
+// 三角剖分的结果用索引表示：每个 [3]int 是一个逆时针排列的三角形，
+// 三个元素是输入点切片中的下标。可以用 TrianglesOf 把它转换为 Triangle。
+
+// TrianglesOf 根据索引列表取出对应的三角形
+func TrianglesOf(pts []Point, tris [][3]int) []Triangle {
+	out := make([]Triangle, len(tris))
+	for i, t := range tris {
+		out[i] = Triangle{A: pts[t[0]], B: pts[t[1]], C: pts[t[2]]}
+	}
+	return out
+}
+
+// InCircle 判断点 d 是否在三角形 abc (逆时针) 的外接圆内：
+// 返回值大于 0 表示在圆内，小于 0 表示在圆外，等于 0 表示四点共圆。
+func InCircle(a, b, c, d Point) float64 {
+	ax, ay := a.X-d.X, a.Y-d.Y
+	bx, by := b.X-d.X, b.Y-d.Y
+	cx, cy := c.X-d.X, c.Y-d.Y
+	return (ax*ax+ay*ay)*(bx*cy-cx*by) -
+		(bx*bx+by*by)*(ax*cy-cx*ay) +
+		(cx*cx+cy*cy)*(ax*by-bx*ay)
+}
+
+// --- 耳切法 ---
+
+// Triangulate 使用耳切法 (ear clipping) 对简单多边形做三角剖分，时间复杂度 O(n²)。
+// 返回的下标对应 pg.Points。多边形存在自交时返回 ErrNotSimple。
+//
+// "耳朵"是指相邻三个顶点构成的凸角三角形，且内部不包含其它顶点；
+// 任何简单多边形都至少有两只耳朵，反复切掉耳朵就能得到 n-2 个三角形。
+func Triangulate(pg Polygon) ([][3]int, error) {
+	n := len(pg.Points)
+	if n < 3 {
+		return nil, fmt.Errorf("%w: 至少需要 3 个顶点", ErrDegeneratePolygon)
+	}
+	if !pg.IsSimple() {
+		return nil, ErrNotSimple
+	}
+	pts := pg.Points
+	// idx 是剩余顶点的下标，统一按逆时针顺序处理
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	if pg.Orientation() == Clockwise {
+		slices.Reverse(idx)
+	}
+
+	tris := make([][3]int, 0, n-2)
+	for len(idx) > 3 {
+		m := len(idx)
+		ear := -1
+		for i := range m {
+			if isEar(pts, idx, i) {
+				ear = i
+				break
+			}
+		}
+		if ear < 0 {
+			// 找不到耳朵只可能是剩下的顶点有共线的情况，去掉一个共线顶点后继续
+			for i := range m {
+				if Orient(pts[idx[(i+m-1)%m]], pts[idx[i]], pts[idx[(i+1)%m]]) == Collinear {
+					ear = i
+					break
+				}
+			}
+			if ear < 0 {
+				return nil, fmt.Errorf("耳切法失败: 剩余 %d 个顶点中找不到耳朵", m)
+			}
+			idx = slices.Delete(idx, ear, ear+1)
+			continue
+		}
+		tris = append(tris, [3]int{idx[(ear+m-1)%m], idx[ear], idx[(ear+1)%m]})
+		idx = slices.Delete(idx, ear, ear+1)
+	}
+	if Orient(pts[idx[0]], pts[idx[1]], pts[idx[2]]) == CounterClockwise {
+		tris = append(tris, [3]int{idx[0], idx[1], idx[2]})
+	}
+	return tris, nil
+}
+
+// isEar 判断 idx[i] 处的顶点是否是耳朵
+func isEar(pts []Point, idx []int, i int) bool {
+	m := len(idx)
+	a, b, c := pts[idx[(i+m-1)%m]], pts[idx[i]], pts[idx[(i+1)%m]]
+	if Orient(a, b, c) != CounterClockwise {
+		return false
+	}
+	for k := range m {
+		if k == i || k == (i+m-1)%m || k == (i+1)%m {
+			continue
+		}
+		p := pts[idx[k]]
+		if p.Eq(a) || p.Eq(b) || p.Eq(c) {
+			continue
+		}
+		if Orient(a, b, p) != Clockwise && Orient(b, c, p) != Clockwise && Orient(c, a, p) != Clockwise {
+			return false
+		}
+	}
+	return true
+}
+
+// --- Delaunay 三角剖分 ---
+
+// Delaunay 使用 Bowyer–Watson 算法计算点集的 Delaunay 三角剖分：
+// 任何三角形的外接圆内都不包含其它点，从而尽量避免狭长的三角形。
+// 重复的点只使用第一次出现的那个。点集全部共线时返回 ErrCollinearPoints。
+//
+// 常见的写法是先用一个很大的"超级三角形"包住所有点，最后删掉与它相连的三角形。
+// 但无论超级三角形多大，凸包边上狭长三角形的外接圆都可能比它还大、把它的顶点包进去，
+// 这些三角形就会被错误地删掉，凸包上出现缺口。这里改用一个"无穷远点"：
+// 凸包的每条边与无穷远点组成一个"幽灵三角形"，它的"外接圆"就是这条边外侧的开半平面
+// (加上边本身的内部)，这样就不需要任何有限的坐标，判断也可以完全用精确谓词完成。
+func Delaunay(pts []Point) ([][3]int, error) {
+	var use []int
+	seen := make(map[Point]bool, len(pts))
+	for i, p := range pts {
+		if !p.valid() {
+			return nil, fmt.Errorf("第 %d 个点不是有限数值: %v", i, p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			use = append(use, i)
+		}
+	}
+
+	// 找出第一个不共线的三点作为初始三角形
+	if len(use) < 3 {
+		return nil, ErrCollinearPoints
+	}
+	a, b := use[0], use[1]
+	k := slices.IndexFunc(use[2:], func(i int) bool { return OrientExact(pts[a], pts[b], pts[i]) != Collinear })
+	if k < 0 {
+		return nil, ErrCollinearPoints
+	}
+	c := use[2+k]
+	use = slices.Delete(use, 2+k, 3+k)[2:]
+	if OrientExact(pts[a], pts[b], pts[c]) == Clockwise {
+		a, b = b, a
+	}
+
+	// 下标 inf 表示无穷远点。幽灵三角形 {x, y, inf} 表示凸包边 y→x，外侧在 x→y 的左边
+	inf := len(pts)
+	tris := [][3]int{{a, b, c}, {b, a, inf}, {c, b, inf}, {a, c, inf}}
+
+	for _, pi := range use {
+		p := pts[pi]
+		// 找出外接圆包含 p 的"坏"三角形，它们合起来是一个星形空洞
+		var bad []int
+		for ti, t := range tris {
+			if inCircumcircle(pts, t, inf, p) {
+				bad = append(bad, ti)
+			}
+		}
+		// 空洞的边界 = 只属于一个坏三角形的边
+		count := make(map[[2]int]int)
+		for _, ti := range bad {
+			t := tris[ti]
+			for k := range 3 {
+				count[edgeKey(t[k], t[(k+1)%3])]++
+			}
+		}
+		var boundary [][2]int
+		for _, ti := range bad {
+			t := tris[ti]
+			for k := range 3 {
+				if count[edgeKey(t[k], t[(k+1)%3])] == 1 {
+					boundary = append(boundary, [2]int{t[k], t[(k+1)%3]})
+				}
+			}
+		}
+		// 删除坏三角形，用空洞边界与 p 连成新的三角形。
+		// 边界边含有 inf 时新三角形是幽灵三角形，旋转顶点使 inf 排在最后，循环顺序不变
+		for i := len(bad) - 1; i >= 0; i-- {
+			tris = slices.Delete(tris, bad[i], bad[i]+1)
+		}
+		for _, e := range boundary {
+			t := [3]int{e[0], e[1], pi}
+			for t[0] == inf || t[1] == inf {
+				t = [3]int{t[1], t[2], t[0]}
+			}
+			tris = append(tris, t)
+		}
+	}
+
+	out := tris[:0]
+	for _, t := range tris {
+		if t[2] != inf {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// inCircumcircle 判断 p 是否严格位于三角形 t 的外接圆内。
+// 对幽灵三角形 {x, y, inf}，"外接圆内"是指 p 在 x→y 的左侧 (凸包外)，或者恰好落在边 xy 的内部。
+func inCircumcircle(pts []Point, t [3]int, inf int, p Point) bool {
+	if t[2] != inf {
+		return InCircleExact(pts[t[0]], pts[t[1]], pts[t[2]], p) > 0
+	}
+	x, y := pts[t[0]], pts[t[1]]
+	switch OrientExact(x, y, p) {
+	case CounterClockwise:
+		return true
+	case Collinear:
+		if x.X != y.X {
+			return min(x.X, y.X) < p.X && p.X < max(x.X, y.X)
+		}
+		return min(x.Y, y.Y) < p.Y && p.Y < max(x.Y, y.Y)
+	}
+	return false
+}
+
+func edgeKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// --- 约束 Delaunay 三角剖分 ---
+
+// ConstrainedDelaunay 对简单多边形做约束 Delaunay 三角剖分：
+// 多边形的每条边都保留在结果中，在此前提下三角形尽量满足 Delaunay 性质。
+//
+// 做法是先用耳切法得到一个合法的剖分，再反复翻转不满足 Delaunay 条件的内部边 (Lawson 翻转)，
+// 多边形的边作为约束永远不翻转。对于没有内部点的多边形，这样收敛的结果就是约束 Delaunay 剖分。
+func ConstrainedDelaunay(pg Polygon) ([][3]int, error) {
+	tris, err := Triangulate(pg)
+	if err != nil {
+		return nil, err
+	}
+	pts := pg.Points
+	n := len(pts)
+	constrained := make(map[[2]int]bool, n)
+	for i := range n {
+		constrained[edgeKey(i, (i+1)%n)] = true
+	}
+	edges := make(map[[2]int][]int)
+	for ti, t := range tris {
+		for k := range 3 {
+			key := edgeKey(t[k], t[(k+1)%3])
+			edges[key] = append(edges[key], ti)
+		}
+	}
+
+	stack := make([][2]int, 0, len(edges))
+	for key := range edges {
+		stack = append(stack, key)
+	}
+	slices.SortFunc(stack, func(a, b [2]int) int { // map 遍历顺序随机，排序让结果可重现
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		owners := edges[key]
+		if constrained[key] || len(owners) != 2 {
+			continue
+		}
+		t1, t2 := owners[0], owners[1]
+		a, b, c := rotateTo(tris[t1], key)
+		d := opposite(tris[t2], a, b)
+		// d 在三角形 abc 的外接圆内，且四边形 adbc 是凸的，才翻转 ab 为 cd
+		if InCircleExact(pts[a], pts[b], pts[c], pts[d]) <= 0 ||
+			Orient(pts[a], pts[d], pts[c]) != CounterClockwise ||
+			Orient(pts[d], pts[b], pts[c]) != CounterClockwise {
+			continue
+		}
+		tris[t1] = [3]int{a, d, c}
+		tris[t2] = [3]int{d, b, c}
+		delete(edges, key)
+		edges[edgeKey(c, d)] = []int{t1, t2}
+		replaceOwner(edges, edgeKey(a, d), t2, t1)
+		replaceOwner(edges, edgeKey(b, c), t1, t2)
+		stack = append(stack, edgeKey(a, d), edgeKey(d, b), edgeKey(b, c), edgeKey(c, a))
+	}
+	return tris, nil
+}
+
+// rotateTo 旋转三角形 t 的顶点顺序，使边 key 成为前两个顶点，返回 (a, b, c)
+func rotateTo(t [3]int, key [2]int) (a, b, c int) {
+	for k := range 3 {
+		if edgeKey(t[k], t[(k+1)%3]) == key {
+			return t[k], t[(k+1)%3], t[(k+2)%3]
+		}
+	}
+	panic("geometry: 三角形不包含指定的边")
+}
+
+// opposite 返回三角形 t 中既不是 a 也不是 b 的顶点
+func opposite(t [3]int, a, b int) int {
+	for _, v := range t {
+		if v != a && v != b {
+			return v
+		}
+	}
+	panic("geometry: 三角形退化")
+}
+
+func replaceOwner(edges map[[2]int][]int, key [2]int, from, to int) {
+	for i, ti := range edges[key] {
+		if ti == from {
+			edges[key][i] = to
+		}
+	}
+}
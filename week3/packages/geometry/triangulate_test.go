@@ -0,0 +1,92 @@
+package geometry
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// hullArea 用单调链算法求凸包面积，作为三角剖分覆盖面积的对照
+func hullArea(pts []Point) float64 {
+	ps := slices.Clone(pts)
+	slices.SortFunc(ps, func(a, b Point) int {
+		return cmp.Or(cmp.Compare(a.X, b.X), cmp.Compare(a.Y, b.Y))
+	})
+	var hull []Point
+	for pass := range 2 {
+		start := len(hull)
+		for _, p := range ps {
+			for len(hull) >= start+2 && OrientExact(hull[len(hull)-2], hull[len(hull)-1], p) != CounterClockwise {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+		if pass == 0 {
+			slices.Reverse(ps)
+		}
+	}
+	return Polygon{Points: hull}.Area()
+}
+
+// checkDelaunay 检查三角形都是逆时针的、覆盖面积等于凸包面积，并且没有点严格落在任何外接圆内
+func checkDelaunay(t *testing.T, name string, pts []Point) {
+	t.Helper()
+	tris, err := Delaunay(pts)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	var area float64
+	for _, tr := range tris {
+		a, b, c := pts[tr[0]], pts[tr[1]], pts[tr[2]]
+		if OrientExact(a, b, c) != CounterClockwise {
+			t.Fatalf("%s: 三角形 %v 不是逆时针的", name, tr)
+		}
+		area += Triangle{A: a, B: b, C: c}.Area()
+		for i, p := range pts {
+			if InCircleExact(a, b, c, p) > 0 {
+				t.Fatalf("%s: 第 %d 个点 %v 落在三角形 %v 的外接圆内", name, i, p, tr)
+			}
+		}
+	}
+	if want := hullArea(pts); math.Abs(area-want) > 1e-9*want {
+		t.Errorf("%s: 覆盖面积 %.6f, 凸包面积 %.6f (%d 个三角形)", name, area, want, len(tris))
+	}
+}
+
+func TestDelaunay(t *testing.T) {
+	for seed := range uint64(5) {
+		r := rand.New(rand.NewPCG(seed, 2))
+		pts := make([]Point, 200)
+		for i := range pts {
+			pts[i] = Pt(r.Float64()*100, r.Float64()*100)
+		}
+		checkDelaunay(t, "随机点", pts)
+	}
+
+	// 网格: 大量共线和共圆的点，凸包边上也有共线的点
+	var grid []Point
+	for x := range 8 {
+		for y := range 5 {
+			grid = append(grid, Pt(float64(x), float64(y)))
+		}
+	}
+	checkDelaunay(t, "网格", grid)
+
+	// 凸包上很扁的三角形: 外接圆极大，有限大小的超级三角形会把它删掉
+	checkDelaunay(t, "扁平凸包", []Point{Pt(0, 0), Pt(50, 1e-6), Pt(100, 0), Pt(50, -30), Pt(30, -10)})
+
+	// 圆周上的点全部共圆
+	var circle []Point
+	for i := range 12 {
+		a := float64(i) * math.Pi / 6
+		circle = append(circle, Pt(10*math.Cos(a), 10*math.Sin(a)))
+	}
+	checkDelaunay(t, "圆周", circle)
+
+	if _, err := Delaunay([]Point{Pt(0, 0), Pt(1, 1), Pt(2, 2), Pt(1, 1)}); err != ErrCollinearPoints {
+		t.Errorf("共线点集的错误 = %v; want ErrCollinearPoints", err)
+	}
+}
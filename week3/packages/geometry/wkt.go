@@ -0,0 +1,369 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- 编码 ---
+
+// WKT 返回点的 WKT 表示，例如 "POINT (1 2)"
+func (p Point) WKT() string {
+	return "POINT (" + wktCoord(p) + ")"
+}
+
+// WKT 返回多边形的 WKT 表示，例如 "POLYGON ((0 0, 1 0, 1 1, 0 0))"。
+// 按规范，环的最后一个点会重复第一个点以闭合。
+func (pg Polygon) WKT() string {
+	return "POLYGON (" + wktRing(pg.Points) + ")"
+}
+
+// WKT 把矩形编码为 WKT 多边形。宽或高为 0 的矩形得到的 WKT 无法被 ParseWKT 读回，需要检查时使用 MarshalWKT
+func (r Rectangle) WKT() string {
+	return Polygon{Points: r.Vertices()}.WKT()
+}
+
+// WKT 把圆编码为由 CircleSegments 个顶点近似的 WKT 多边形，半径为 0 时同样需要 MarshalWKT 检查
+func (c Circle) WKT() string {
+	pg, _ := ToPolygon(c)
+	return pg.WKT()
+}
+
+// WKT 把带洞的多边形编码为 WKT，例如 "POLYGON ((0 0, 4 0, 4 4, 0 0), (1 1, 2 1, 1 2, 1 1))"
+func (r Region) WKT() string {
+	return "POLYGON " + wktRings(r)
+}
+
+// WKT 把多个区域编码为 WKT，例如 "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((2 2, 3 2, 3 3, 2 2)))"
+func (m MultiPolygon) WKT() string {
+	parts := make([]string, len(m))
+	for i, r := range m {
+		parts[i] = wktRings(r)
+	}
+	return "MULTIPOLYGON (" + strings.Join(parts, ", ") + ")"
+}
+
+// MarshalWKT 与 g.WKT() 的输出相同，但会先检查结果能否被 ParseWKT 读回：
+// 坐标必须是有限数值，多边形的每个环面积都不能为零 (否则返回包装了 ErrDegeneratePolygon 的错误)。
+func MarshalWKT(g Geometry) (string, error) {
+	var regions MultiPolygon
+	switch v := g.(type) {
+	case nil:
+		return "", errors.New("没有需要编码的几何对象")
+	case Point:
+		if !v.valid() {
+			return "", fmt.Errorf("坐标不是有限数值: %v", v)
+		}
+		return v.WKT(), nil
+	case Region:
+		regions = MultiPolygon{v}
+	case MultiPolygon:
+		regions = v
+	case Shape:
+		pg, err := ToPolygon(v)
+		if err != nil {
+			return "", err
+		}
+		regions = MultiPolygon{{Outer: pg}}
+	default:
+		return g.WKT(), nil
+	}
+	for i, r := range regions {
+		rings, err := encodableRings(r, "WKT")
+		if err != nil {
+			if len(regions) > 1 {
+				err = fmt.Errorf("第 %d 个区域: %w", i, err)
+			}
+			return "", err
+		}
+		for _, ring := range rings {
+			for _, p := range ring.Points {
+				if !p.valid() {
+					return "", fmt.Errorf("坐标不是有限数值: %v", p)
+				}
+			}
+		}
+	}
+	return g.WKT(), nil
+}
+
+func wktRings(r Region) string {
+	rings := r.rings()
+	parts := make([]string, len(rings))
+	for i, ring := range rings {
+		parts[i] = wktRing(ring.Points)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func wktCoord(p Point) string {
+	return strconv.FormatFloat(p.X, 'g', -1, 64) + " " + strconv.FormatFloat(p.Y, 'g', -1, 64)
+}
+
+func wktRing(pts []Point) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for i, p := range pts {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(wktCoord(p))
+	}
+	if len(pts) > 0 {
+		sb.WriteString(", " + wktCoord(pts[0]))
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// --- 解析 ---
+
+// ParseWKT 解析 WKT 文本，返回 Point、Polygon、Region (带洞的 POLYGON) 或 MultiPolygon。
+// 支持 POINT、POLYGON 和 MULTIPOLYGON (关键字不区分大小写)，暂不支持 Z/M 坐标。
+// 语法错误返回 *ParseError，其中包含出错位置的字节偏移量。
+func ParseWKT(s string) (Geometry, error) {
+	p := &wktParser{src: s}
+	p.next()
+	tag := p.tok
+	if tag.kind != wktWord {
+		return nil, p.errorf(tag.off, "期望几何类型关键字, 得到 %s", tag)
+	}
+	p.next()
+	var g Geometry
+	var err error
+	switch strings.ToUpper(tag.text) {
+	case "POINT":
+		g, err = p.point()
+	case "POLYGON":
+		g, err = p.polygon()
+	case "MULTIPOLYGON":
+		g, err = p.multiPolygon()
+	default:
+		return nil, p.errorf(tag.off, "不支持的几何类型 %q", tag.text)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != wktEOF {
+		return nil, p.errorf(p.tok.off, "多余的内容 %s", p.tok)
+	}
+	return g, nil
+}
+
+// ParseWKTPolygon 解析 WKT 多边形，输入是其它类型时返回错误。
+func ParseWKTPolygon(s string) (Polygon, error) {
+	g, err := ParseWKT(s)
+	if err != nil {
+		return Polygon{}, err
+	}
+	pg, ok := g.(Polygon)
+	if !ok {
+		return Polygon{}, &ParseError{Format: "WKT", Msg: fmt.Sprintf("期望不带洞的 POLYGON, 得到 %T", g)}
+	}
+	return pg, nil
+}
+
+type wktKind int
+
+const (
+	wktEOF wktKind = iota
+	wktWord
+	wktNumber
+	wktLParen
+	wktRParen
+	wktComma
+	wktInvalid
+)
+
+type wktToken struct {
+	kind wktKind
+	text string
+	off  int
+}
+
+func (t wktToken) String() string {
+	if t.kind == wktEOF {
+		return "输入结尾"
+	}
+	return strconv.Quote(t.text)
+}
+
+// wktParser 是一个简单的递归下降解析器，tok 是当前 (尚未消费的) 记号。
+type wktParser struct {
+	src string
+	pos int
+	tok wktToken
+}
+
+func (p *wktParser) errorf(off int, format string, args ...any) error {
+	return &ParseError{Format: "WKT", Offset: off, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next 读取下一个记号到 p.tok
+func (p *wktParser) next() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = wktToken{kind: wktEOF, off: start}
+		return
+	}
+	c := p.src[p.pos]
+	switch {
+	case c == '(' || c == ')' || c == ',':
+		p.pos++
+		kind := map[byte]wktKind{'(': wktLParen, ')': wktRParen, ',': wktComma}[c]
+		p.tok = wktToken{kind: kind, text: string(c), off: start}
+	case isLetter(c):
+		for p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok = wktToken{kind: wktWord, text: p.src[start:p.pos], off: start}
+	case c == '-' || c == '+' || c == '.' || isDigit(c):
+		for p.pos < len(p.src) && strings.IndexByte("+-.eE0123456789", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+		p.tok = wktToken{kind: wktNumber, text: p.src[start:p.pos], off: start}
+	default:
+		p.pos++
+		p.tok = wktToken{kind: wktInvalid, text: string(c), off: start}
+	}
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+func (p *wktParser) expect(kind wktKind, what string) error {
+	if p.tok.kind != kind {
+		return p.errorf(p.tok.off, "期望 %s, 得到 %s", what, p.tok)
+	}
+	p.next()
+	return nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	t := p.tok
+	if t.kind != wktNumber {
+		return 0, p.errorf(t.off, "期望数字, 得到 %s", t)
+	}
+	v, err := strconv.ParseFloat(t.text, 64)
+	if err != nil || !finite(v) {
+		return 0, p.errorf(t.off, "无效的数字 %q", t.text)
+	}
+	p.next()
+	return v, nil
+}
+
+// coord 解析 "x y"
+func (p *wktParser) coord() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	if p.tok.kind == wktNumber {
+		return Point{}, p.errorf(p.tok.off, "暂不支持三维或带 M 值的坐标")
+	}
+	return Point{x, y}, nil
+}
+
+// point 解析 "( x y )"
+func (p *wktParser) point() (Point, error) {
+	if p.tok.kind == wktWord && strings.EqualFold(p.tok.text, "EMPTY") {
+		return Point{}, p.errorf(p.tok.off, "不支持 EMPTY 几何")
+	}
+	if err := p.expect(wktLParen, `"("`); err != nil {
+		return Point{}, err
+	}
+	pt, err := p.coord()
+	if err != nil {
+		return Point{}, err
+	}
+	return pt, p.expect(wktRParen, `")"`)
+}
+
+// ring 解析 "( x y, x y, ... )"，返回环的全部坐标 (含闭合点) 以及环的起始偏移
+func (p *wktParser) ring() ([]Point, int, error) {
+	off := p.tok.off
+	if err := p.expect(wktLParen, `"("`); err != nil {
+		return nil, off, err
+	}
+	var pts []Point
+	for {
+		pt, err := p.coord()
+		if err != nil {
+			return nil, off, err
+		}
+		pts = append(pts, pt)
+		if p.tok.kind != wktComma {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(wktRParen, `"," 或 ")"`); err != nil {
+		return nil, off, err
+	}
+	if len(pts) < 4 {
+		return nil, off, p.errorf(off, "多边形的环至少需要 4 个坐标 (含闭合点), 得到 %d", len(pts))
+	}
+	if !pts[0].Eq(pts[len(pts)-1]) {
+		return nil, off, p.errorf(off, "多边形的环没有闭合: 首点 %v, 末点 %v", pts[0], pts[len(pts)-1])
+	}
+	return pts, off, nil
+}
+
+// polygon 解析 "( ring [, ring]... )"，第一个环是外边界，其余的环是洞
+func (p *wktParser) polygon() (Geometry, error) {
+	if p.tok.kind == wktWord && strings.EqualFold(p.tok.text, "EMPTY") {
+		return nil, p.errorf(p.tok.off, "不支持 EMPTY 几何")
+	}
+	if err := p.expect(wktLParen, `"("`); err != nil {
+		return nil, err
+	}
+	var rings [][]Point
+	var offs []int
+	for {
+		pts, off, err := p.ring()
+		if err != nil {
+			return nil, err
+		}
+		rings, offs = append(rings, pts), append(offs, off)
+		if p.tok.kind != wktComma {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(wktRParen, `"," 或 ")"`); err != nil {
+		return nil, err
+	}
+	return polygonFromRings("WKT", rings, offs)
+}
+
+// multiPolygon 解析 "( polygon [, polygon]... )"
+func (p *wktParser) multiPolygon() (MultiPolygon, error) {
+	if p.tok.kind == wktWord && strings.EqualFold(p.tok.text, "EMPTY") {
+		return nil, p.errorf(p.tok.off, "不支持 EMPTY 几何")
+	}
+	if err := p.expect(wktLParen, `"("`); err != nil {
+		return nil, err
+	}
+	var m MultiPolygon
+	for {
+		g, err := p.polygon()
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, asRegion(g))
+		if p.tok.kind != wktComma {
+			break
+		}
+		p.next()
+	}
+	return m, p.expect(wktRParen, `"," 或 ")"`)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Mag1cFall/go-get-started/week3/packages/geometry"
+)
+
+// 输入格式
+//
+// JSON: 一个形状对象、形状数组，或 {"shapes": [...]}。形状对象使用 geometry.MarshalShape 的格式，
+// 可以额外带一个 "name" 字段：
+//
+//	[{"name": "a", "type": "circle", "center": [0, 0], "radius": 7},
+//	 {"name": "b", "type": "rectangle", "origin": [0, 0], "width": 10, "height": 5}]
+//
+// CSV: 每行 "名称,类型,数值..."，只有以 # 开头的整行才是注释 (行尾的 # 会被当成数值的一部分)，第一行可以是 "name,type,..." 表头：
+//
+//	# 圆心 x,y 与半径
+//	a,circle,0,0,7
+//	# 左下角 x,y 与宽、高
+//	b,rectangle,0,0,10,5
+//	# 三个顶点
+//	c,triangle,0,0,4,0,0,3
+//	# 中心、两个半轴、旋转角 (可省略)
+//	d,ellipse,0,0,3,2,0.5
+//	# 中心、边数、外接圆半径、旋转角 (可省略)
+//	e,regular_polygon,0,0,6,2,0
+//	# 依次排列的顶点坐标
+//	f,polygon,0,0,4,0,4,3
+
+// namedShape 是从输入中读到的一个形状
+type namedShape struct {
+	Name   string
+	Source string // 出处，例如 "shapes.csv:3"，用于错误信息
+	Shape  geometry.Shape
+}
+
+// loadFile 读取一个文件 ("-" 表示标准输入) 中的全部形状，返回所有能读到的形状和遇到的全部错误
+func loadFile(name, format string, stdin io.Reader) ([]namedShape, []error) {
+	var data []byte
+	var err error
+	label := name
+	if name == "-" {
+		label = "<stdin>"
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+	if format == "auto" {
+		format = detectFormat(name, data)
+	}
+	var shapes []namedShape
+	var errs []error
+	if format == "json" {
+		shapes, errs = parseJSON(label, data)
+	} else {
+		shapes, errs = parseCSV(label, data)
+	}
+	for i := range shapes {
+		if shapes[i].Name == "" {
+			shapes[i].Name = shapes[i].Source
+		}
+	}
+	return shapes, errs
+}
+
+// detectFormat 优先按扩展名判断，标准输入等无法判断时看第一个非空白字符
+func detectFormat(name string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".geojson":
+		return "json"
+	case ".csv":
+		return "csv"
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return "json"
+	}
+	return "csv"
+}
+
+// --- JSON ---
+
+func parseJSON(label string, data []byte) ([]namedShape, []error) {
+	var raws []json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return nil, nil
+	case trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return nil, []error{fmt.Errorf("%s: %w", label, err)}
+		}
+	default:
+		var wrapper struct {
+			Shapes []json.RawMessage `json:"shapes"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, []error{fmt.Errorf("%s: %w", label, err)}
+		}
+		raws = wrapper.Shapes
+		if raws == nil {
+			raws = []json.RawMessage{trimmed}
+		}
+	}
+
+	var shapes []namedShape
+	var errs []error
+	for i, raw := range raws {
+		src := fmt.Sprintf("%s#%d", label, i+1)
+		var meta struct {
+			Name string `json:"name"`
+		}
+		_ = json.Unmarshal(raw, &meta) // 语法错误会在 UnmarshalShape 中报告
+		s, err := geometry.UnmarshalShape(raw)
+		if err == nil {
+			err = checkSimple(s)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src, err))
+			continue
+		}
+		shapes = append(shapes, namedShape{Name: meta.Name, Source: src, Shape: s})
+	}
+	return shapes, errs
+}
+
+// --- CSV ---
+
+func parseCSV(label string, data []byte) ([]namedShape, []error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var shapes []namedShape
+	var errs []error
+	for first := true; ; first = false {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+			break
+		}
+		line, _ := r.FieldPos(0)
+		src := fmt.Sprintf("%s:%d", label, line)
+		if first && len(rec) >= 2 && strings.EqualFold(rec[0], "name") && strings.EqualFold(rec[1], "type") {
+			continue // 表头
+		}
+		s, err := csvShape(rec)
+		if err == nil {
+			err = checkSimple(s)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src, err))
+			continue
+		}
+		shapes = append(shapes, namedShape{Name: strings.TrimSpace(rec[0]), Source: src, Shape: s})
+	}
+	return shapes, errs
+}
+
+// csvShape 根据一行 CSV 记录 (名称, 类型, 数值...) 调用对应的构造函数
+func csvShape(rec []string) (geometry.Shape, error) {
+	if len(rec) < 2 {
+		return nil, fmt.Errorf("每行至少需要名称和类型两列")
+	}
+	typ := strings.ToLower(strings.TrimSpace(rec[1]))
+	nums := make([]float64, 0, len(rec)-2)
+	for i, f := range rec[2:] {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("第 %d 列不是有限的数字: %q", i+3, f)
+		}
+		nums = append(nums, v)
+	}
+	argc := func(counts ...int) error {
+		want := make([]string, len(counts))
+		for i, c := range counts {
+			if len(nums) == c {
+				return nil
+			}
+			want[i] = strconv.Itoa(c)
+		}
+		return fmt.Errorf("%s 需要 %s 个数值, 得到 %d", typ, strings.Join(want, " 或 "), len(nums))
+	}
+	pt := func(i int) geometry.Point { return geometry.Pt(nums[i], nums[i+1]) }
+
+	switch typ {
+	case "circle":
+		if err := argc(3); err != nil {
+			return nil, err
+		}
+		return geometry.NewCircle(pt(0), nums[2])
+	case "rectangle":
+		if err := argc(4); err != nil {
+			return nil, err
+		}
+		return geometry.NewRectangleAt(pt(0), nums[2], nums[3])
+	case "triangle":
+		if err := argc(6); err != nil {
+			return nil, err
+		}
+		return geometry.NewTriangle(pt(0), pt(2), pt(4))
+	case "ellipse":
+		if err := argc(4, 5); err != nil {
+			return nil, err
+		}
+		e, err := geometry.NewEllipse(pt(0), nums[2], nums[3])
+		if err == nil && len(nums) == 5 {
+			e.Angle = nums[4]
+		}
+		return e, err
+	case "regular_polygon":
+		if err := argc(4, 5); err != nil {
+			return nil, err
+		}
+		if nums[2] != math.Trunc(nums[2]) {
+			return nil, fmt.Errorf("边数必须是整数, 得到 %v", nums[2])
+		}
+		var rotation float64
+		if len(nums) == 5 {
+			rotation = nums[4]
+		}
+		return geometry.NewRegularPolygon(pt(0), int(nums[2]), nums[3], rotation)
+	case "polygon":
+		if len(nums)%2 != 0 {
+			return nil, fmt.Errorf("polygon 的坐标数必须是偶数, 得到 %d", len(nums))
+		}
+		pts := make([]geometry.Point, 0, len(nums)/2)
+		for i := 0; i < len(nums); i += 2 {
+			pts = append(pts, pt(i))
+		}
+		return geometry.NewPolygon(pts)
+	default:
+		return nil, fmt.Errorf("未知的形状类型 %q", rec[1])
+	}
+}
+
+// checkSimple 拒绝自交的多边形：它们的面积和重叠区域没有明确的定义
+func checkSimple(s geometry.Shape) error {
+	if pg, ok := s.(geometry.Polygon); ok && !pg.IsSimple() {
+		return fmt.Errorf("%w: 多边形存在自交", geometry.ErrInvalidShape)
+	}
+	return nil
+}
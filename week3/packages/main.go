@@ -1,72 +1,103 @@
+// 命令 packages 是一个基于 geometry 包的命令行工具：
+// 从 JSON/CSV 文件或标准输入读取形状，计算每个形状的面积、周长和外接框，
+// 以及两两之间的重叠面积，以表格或 JSON 格式输出。
+//
+// 用法:
+//
+//	go run ./week3/packages [选项] [文件...]
+//
+// 没有指定文件或文件名为 "-" 时从标准输入读取。输入格式见 input.go。
+//
+// 退出码: 0 成功；1 输入无法读取或包含无效形状；2 命令行参数错误。
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	// 导入我们自定义的 geometry 包
-	// 路径是相对于项目根目录下的 GOPATH/src 或者 Go Modules 的模块路径
-	// 在 Go Modules 项目中，如果 geometry 是当前模块的一部分，
-	// 导入路径通常是 "moduleName/path/to/package"
-	// 例如，如果我们的 go.mod 定义了 module "github.com/Mag1cFall/go-get-started",
-	// 那么导入路径就是 "github.com/Mag1cFall/go-get-started/week3/packages/geometry"
-	//
-	// VS Code 和 Go 工具通常能自动解析同模块下的相对路径，
-	// 但标准的导入路径是基于模块的。
-	// 为了简单起见，并假设 Go 工具能处理好同模块下的相对引用，我们先用相对路径风格。
-	// 如果遇到问题，我们可能需要调整为完整的模块路径。
-	//
-	// 鉴于当前项目的结构和 go.mod (module github.com/Mag1cFall/go-get-started),
-	// 正确的导入路径应该是：
-	"github.com/Mag1cFall/go-get-started/week3/packages/geometry"
+	"io"
+	"os"
 )
 
-func main() {
-	fmt.Println("--- 第3周学习：使用自定义包 ---")
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
 
-	// 调用 geometry 包中导出的常量
-	fmt.Printf("geometry 包中的 Pi 常量: %.4f\n", geometry.Pi)
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
 
-	// 调用 geometry 包中导出的函数
-	rectWidth, rectHeight := 10.0, 5.0
-	area := geometry.Area(rectWidth, rectHeight) // 旧的 Area 函数，直接接收参数
-	perimeter := geometry.Perimeter(rectWidth, rectHeight)
-	fmt.Printf("矩形 (%.2f x %.2f): 面积 = %.2f, 周长 = %.2f\n", rectWidth, rectHeight, area, perimeter)
+// run 是命令的主体，把输入输出作为参数传入，便于测试和复用
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("packages", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", "table", "输出格式: table 或 json")
+	input := fs.String("f", "auto", "输入格式: auto、json 或 csv (auto 按扩展名或内容判断)")
+	demo := fs.Bool("demo", false, "运行 geometry 包的使用演示")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "用法: packages [选项] [文件...]")
+		fmt.Fprintln(stderr, "从 JSON/CSV 文件或标准输入读取形状，输出面积、周长、外接框和两两重叠面积。")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *demo {
+		runDemo()
+		return exitOK
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "未知的输出格式 %q\n", *output)
+		return exitUsage
+	}
+	if *input != "auto" && *input != "json" && *input != "csv" {
+		fmt.Fprintf(stderr, "未知的输入格式 %q\n", *input)
+		return exitUsage
+	}
 
-	fmt.Println("\n--- 使用 geometry 包中的导出类型和方法 ---")
-	// 创建 Circle 实例 (Circle 是导出的)
-	circ := geometry.Circle{Radius: 7.0}
-	fmt.Printf("圆形半径: %.2f\n", circ.Radius)
-	fmt.Printf("圆形面积 (通过方法调用): %.2f\n", circ.CircleArea())
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	var shapes []namedShape
+	var errs []error
+	for _, name := range files {
+		s, e := loadFile(name, *input, stdin)
+		shapes = append(shapes, s...)
+		errs = append(errs, e...)
+	}
+	if len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintln(stderr, "错误:", err)
+		}
+		return exitInvalid
+	}
+	if len(shapes) == 0 {
+		fmt.Fprintln(stderr, "错误: 输入中没有任何形状")
+		return exitInvalid
+	}
 
-	// 创建 Rectangle 实例 (Rectangle 是导出的)
-	// 使用 NewRectangle 构造函数
-	myRect, err := geometry.NewRectangle(8.0, 4.0)
-	if err != nil {
-		fmt.Println("创建 Rectangle 失败:", err)
+	// 个别形状对的重叠面积无法计算时，仍然输出其余结果，但以退出码 1 结束
+	rep, errs := analyze(shapes)
+	var err error
+	if *output == "json" {
+		err = rep.writeJSON(stdout)
 	} else {
-		fmt.Printf("自定义矩形: Width=%.2f, Height=%.2f\n", myRect.Width, myRect.Height)
-		fmt.Printf("  面积: %.2f\n", myRect.Area())      // 调用 Rectangle 的 Area 方法
-		fmt.Printf("  周长: %.2f\n", myRect.Perimeter()) // 调用 Rectangle 的 Perimeter 方法
+		err = rep.writeTable(stdout)
 	}
-
-	// 尝试创建无效的 Rectangle
-	_, err = geometry.NewRectangle(-1.0, 5.0)
 	if err != nil {
-		fmt.Println("创建无效 Rectangle 时捕获到错误:", err)
+		errs = append(errs, err)
 	}
-
-	// 注意：geometry 包中的 rect 结构体和 internalHelperFunction 函数因为未导出（首字母小写），
-	// 所以不能在 main 包中直接访问。
-	// var r geometry.rect // 这会导致编译错误: cannot refer to unexported name geometry.rect
-	// geometry.internalHelperFunction() // 这会导致编译错误: cannot refer to unexported name geometry.internalHelperFunction
-
-	fmt.Println("\n--- 包的使用演示结束 ---")
-	// init 函数的调用顺序：
-	// 1. 被导入包的 init 函数 (geometry包的init会先执行)
-	// 2. 当前包 (main包) 的 init 函数 (如果定义了的话)
-	// 3. main 函数
-}
-
-// 我们也可以在 main 包中定义 init 函数
-func init() {
-	fmt.Println("main 包 (week3/packages/main.go) 的 init 函数被调用了。")
+	if len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintln(stderr, "错误:", err)
+		}
+		return exitInvalid
+	}
+	return exitOK
 }
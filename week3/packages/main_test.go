@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mag1cFall/go-get-started/week3/packages/geometry"
+)
+
+func TestRunExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	badFile := filepath.Join(dir, "bad.csv")
+	if err := os.WriteFile(badFile, []byte("a,circle,0,0,-1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		want       int
+		wantStdout string // stdout 中应该包含的内容
+		wantStderr string // stderr 中应该包含的内容
+	}{
+		{"CSV 表格", nil, "a,rectangle,0,0,4,4\nb,rectangle,2,2,4,4\n", exitOK, "重叠:", ""},
+		{"JSON 输出", []string{"-o", "json"}, `[{"name":"c","type":"circle","radius":1}]`, exitOK, `"name": "c"`, ""},
+		{"零宽矩形不算错误", nil, "a,rectangle,0,0,0,4\nb,rectangle,0,0,4,4\n", exitOK, "没有相互重叠的形状", ""},
+		{"帮助", []string{"-h"}, "", exitOK, "", "用法"},
+		{"无效形状", []string{badFile}, "", exitInvalid, "", "bad.csv:1"},
+		{"文件不存在", []string{filepath.Join(dir, "missing.csv")}, "", exitInvalid, "", "missing.csv"},
+		{"空输入", nil, "", exitInvalid, "", "没有任何形状"},
+		{"自交多边形", []string{"-f", "csv"}, "p,polygon,0,0,4,4,4,0,0,6\n", exitInvalid, "", "自交"},
+		{"未知选项", []string{"-x"}, "", exitUsage, "", "flag provided but not defined"},
+		{"未知输出格式", []string{"-o", "xml"}, "", exitUsage, "", "未知的输出格式"},
+		{"未知输入格式", []string{"-f", "yaml"}, "", exitUsage, "", "未知的输入格式"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			got := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if got != tt.want {
+				t.Fatalf("退出码 = %d, want %d\nstdout: %s\nstderr: %s", got, tt.want, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout 中没有 %q:\n%s", tt.wantStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr 中没有 %q:\n%s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunJSONReport(t *testing.T) {
+	var stdout, stderr strings.Builder
+	in := "a,rectangle,0,0,4,4\nb,rectangle,2,2,4,4\nc,circle,100,100,1\n"
+	if code := run([]string{"-o", "json"}, strings.NewReader(in), &stdout, &stderr); code != exitOK {
+		t.Fatalf("退出码 = %d, stderr: %s", code, stderr.String())
+	}
+	var rep report
+	if err := json.Unmarshal([]byte(stdout.String()), &rep); err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Shapes) != 3 || len(rep.Overlaps) != 1 {
+		t.Fatalf("报告 = %+v, want 3 个形状和 1 处重叠", rep)
+	}
+	if o := rep.Overlaps[0]; o.A != "a" || o.B != "b" || o.Area != 4 {
+		t.Errorf("重叠 = %+v, want a 与 b 重叠面积为 4", o)
+	}
+}
+
+// 布尔运算的错误不能被悄悄丢掉
+func TestAnalyzeReportsIntersectionErrors(t *testing.T) {
+	bowtie := geometry.Polygon{Points: []geometry.Point{{X: 0, Y: 0}, {X: 4, Y: 4}, {X: 4, Y: 0}, {X: 0, Y: 6}}}
+	square, _ := geometry.NewRectangle(4, 4)
+	_, errs := analyze([]namedShape{{Name: "bowtie", Shape: bowtie}, {Name: "square", Shape: square}})
+	if len(errs) != 1 || !errors.Is(errs[0], geometry.ErrNotSimple) || !strings.Contains(errs[0].Error(), "bowtie") {
+		t.Errorf("analyze 的错误 = %v, want 一个包含形状名称的 ErrNotSimple", errs)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Mag1cFall/go-get-started/week3/packages/geometry"
+)
+
+// shapeReport 是单个形状的计算结果
+type shapeReport struct {
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	Area      float64    `json:"area"`
+	Perimeter float64    `json:"perimeter"`
+	Bounds    [4]float64 `json:"bounds"` // [minX, minY, maxX, maxY]
+}
+
+// overlapReport 描述两个形状的重叠部分
+type overlapReport struct {
+	A    string  `json:"a"`
+	B    string  `json:"b"`
+	Area float64 `json:"area"`
+}
+
+type report struct {
+	Shapes   []shapeReport   `json:"shapes"`
+	Overlaps []overlapReport `json:"overlaps"`
+}
+
+// analyze 计算每个形状的属性以及两两之间的重叠面积。
+// 外接框不相交的形状对直接跳过，只有可能重叠时才做布尔运算。
+// 布尔运算失败的形状对不会出现在 Overlaps 中，对应的错误在第二个返回值中逐一列出。
+func analyze(shapes []namedShape) (report, []error) {
+	rep := report{Shapes: make([]shapeReport, len(shapes)), Overlaps: []overlapReport{}}
+	for i, ns := range shapes {
+		b := ns.Shape.Bounds()
+		rep.Shapes[i] = shapeReport{
+			Name:      ns.Name,
+			Type:      shapeType(ns.Shape),
+			Area:      ns.Shape.Area(),
+			Perimeter: ns.Shape.Perimeter(),
+			Bounds:    [4]float64{b.Min.X, b.Min.Y, b.Max.X, b.Max.Y},
+		}
+	}
+	var errs []error
+	for i := range shapes {
+		for j := i + 1; j < len(shapes); j++ {
+			a, b := shapes[i], shapes[j]
+			if !a.Shape.Bounds().Intersects(b.Shape.Bounds()) {
+				continue
+			}
+			// 面积为零的形状 (如宽度为 0 的矩形) 没有内部，不可能与其它形状重叠，布尔运算也会拒绝它们
+			if a.Shape.Area() <= geometry.Epsilon || b.Shape.Area() <= geometry.Epsilon {
+				continue
+			}
+			m, err := geometry.Intersection(a.Shape, b.Shape)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("计算 %s 与 %s 的重叠面积失败: %w", a.Name, b.Name, err))
+				continue
+			}
+			if m.Area() <= geometry.Epsilon {
+				continue
+			}
+			rep.Overlaps = append(rep.Overlaps, overlapReport{A: a.Name, B: b.Name, Area: m.Area()})
+		}
+	}
+	return rep, errs
+}
+
+func shapeType(s geometry.Shape) string {
+	switch s.(type) {
+	case geometry.Circle:
+		return "circle"
+	case geometry.Rectangle:
+		return "rectangle"
+	case geometry.Triangle:
+		return "triangle"
+	case geometry.Ellipse:
+		return "ellipse"
+	case geometry.RegularPolygon:
+		return "regular_polygon"
+	case geometry.Polygon:
+		return "polygon"
+	default:
+		return fmt.Sprintf("%T", s)
+	}
+}
+
+func (r report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(r)
+}
+
+func (r report) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "名称\t类型\t面积\t周长\t外接框")
+	for _, s := range r.Shapes {
+		fmt.Fprintf(tw, "%s\t%s\t%.4f\t%.4f\t[%g, %g] - [%g, %g]\n",
+			s.Name, s.Type, s.Area, s.Perimeter, s.Bounds[0], s.Bounds[1], s.Bounds[2], s.Bounds[3])
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Overlaps) == 0 {
+		_, err := fmt.Fprintln(w, "\n没有相互重叠的形状")
+		return err
+	}
+	fmt.Fprintln(w, "\n重叠:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "形状 A\t形状 B\t重叠面积")
+	for _, o := range r.Overlaps {
+		fmt.Fprintf(tw, "%s\t%s\t%.4f\n", o.A, o.B, o.Area)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+
+	"github.com/Mag1cFall/go-get-started/week3/packages/geometry"
+)
+
+// maxBodyBytes 限制请求体大小，防止客户端发送超大请求耗尽内存
+const maxBodyBytes = 1 << 20
+
+// newMux 注册所有路由
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/shapes/area", post(handleArea))
+	mux.Handle("/shapes/intersect", post(handleIntersect))
+	mux.Handle("/shapes/transform", post(handleTransform))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &apiError{Status: http.StatusNotFound, Code: "not_found", Message: "路径不存在: " + r.URL.Path})
+	})
+	return mux
+}
+
+// --- 错误 ---
+
+// apiError 是返回给客户端的结构化错误，响应体为 {"error": {...}}。
+type apiError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`             // 机器可读的错误码，如 "invalid_json"、"invalid_shape"
+	Message string `json:"message"`          // 人类可读的说明
+	Field   string `json:"field,omitempty"`  // 出错的请求字段，例如 "a"、"steps[1]"
+	Offset  *int   `json:"offset,omitempty"` // JSON 解析错误在该字段中的字节偏移
+}
+
+func (e *apiError) Error() string { return e.Message }
+
+func badRequest(code, field, format string, args ...any) *apiError {
+	return &apiError{Status: http.StatusBadRequest, Code: code, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// shapeError 把 geometry.UnmarshalShape 的错误转换为 apiError
+func shapeError(field string, err error) *apiError {
+	var pe *geometry.ParseError
+	if errors.As(err, &pe) {
+		e := badRequest("invalid_json", field, "%s", pe.Msg)
+		e.Offset = &pe.Offset
+		return e
+	}
+	return badRequest("invalid_shape", field, "%v", err)
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	writeJSON(w, e.Status, map[string]*apiError{"error": e})
+}
+
+// writeJSON 先把响应编码到缓冲区，编码失败 (例如结果中出现 NaN 或 Inf) 时改为返回 500，
+// 而不是先发出 200 再写出一个残缺的响应体。
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		if status == http.StatusInternalServerError {
+			http.Error(w, err.Error(), status) // 连错误响应都无法编码，只能退回纯文本
+			return
+		}
+		writeError(w, &apiError{Status: http.StatusInternalServerError, Code: "internal", Message: "编码响应失败: " + err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes()) // 此时状态码已经发出，写入失败也无法再通知客户端
+}
+
+// post 把 "读取请求体 -> 返回结果或错误" 形式的函数包装为只接受 POST 的 http.Handler，
+// 统一处理方法检查、请求体大小限制和 JSON 响应。
+func post(h func(body []byte) (any, *apiError)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, &apiError{Status: http.StatusMethodNotAllowed, Code: "method_not_allowed",
+				Message: "只支持 POST 请求, 得到 " + r.Method})
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, &apiError{Status: http.StatusRequestEntityTooLarge, Code: "body_too_large",
+					Message: fmt.Sprintf("请求体不能超过 %d 字节", maxBodyBytes)})
+				return
+			}
+			writeError(w, badRequest("bad_request", "", "读取请求体失败: %v", err))
+			return
+		}
+		resp, apiErr := h(body)
+		if apiErr != nil {
+			writeError(w, apiErr)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// decodeBody 严格解码请求体：不允许未知字段，也不允许 JSON 值后面还有多余内容
+func decodeBody(body []byte, v any) *apiError {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		e := badRequest("invalid_json", "", "请求体不是有效的 JSON: %v", err)
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			off := int(syn.Offset)
+			e.Offset = &off
+		}
+		return e
+	}
+	if dec.More() {
+		return badRequest("invalid_json", "", "请求体中只能包含一个 JSON 值")
+	}
+	return nil
+}
+
+// decodeShape 解码 field 字段中的形状，缺失时报错
+func decodeShape(field string, raw json.RawMessage) (geometry.Shape, *apiError) {
+	if len(raw) == 0 {
+		return nil, badRequest("missing_field", field, "缺少字段 %q", field)
+	}
+	s, err := geometry.UnmarshalShape(raw)
+	if err != nil {
+		return nil, shapeError(field, err)
+	}
+	return s, nil
+}
+
+// --- 响应中使用的类型 ---
+
+type bboxJSON struct {
+	Min [2]float64 `json:"min"`
+	Max [2]float64 `json:"max"`
+}
+
+func bboxOf(b geometry.BBox) bboxJSON {
+	return bboxJSON{Min: xy(b.Min), Max: xy(b.Max)}
+}
+
+func xy(p geometry.Point) [2]float64 { return [2]float64{p.X, p.Y} }
+
+// finite 判断所有值都是有限数值。坐标很大时，面积、缩放结果等可能溢出为 Inf 甚至 NaN，而 JSON 无法表示它们。
+func finite(vals ...float64) bool {
+	for _, v := range vals {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// overflowError 表示输入本身合法，但计算结果超出了 float64 的范围
+func overflowError(field string) *apiError {
+	return badRequest("out_of_range", field, "计算结果超出浮点数范围, 请缩小坐标或参数")
+}
+
+// --- POST /shapes/area ---
+
+type areaResponse struct {
+	Shape     json.RawMessage `json:"shape"`
+	Area      float64         `json:"area"`
+	Perimeter float64         `json:"perimeter"`
+	Bounds    bboxJSON        `json:"bounds"`
+	Centroid  [2]float64      `json:"centroid"`
+}
+
+// handleArea 的请求体就是一个形状
+func handleArea(body []byte) (any, *apiError) {
+	s, apiErr := decodeShape("", body)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	b, c := s.Bounds(), s.Centroid()
+	if !finite(s.Area(), s.Perimeter(), b.Min.X, b.Min.Y, b.Max.X, b.Max.Y, c.X, c.Y) {
+		return nil, overflowError("")
+	}
+	echo, err := geometry.MarshalShape(s)
+	if err != nil {
+		return nil, &apiError{Status: http.StatusInternalServerError, Code: "internal", Message: err.Error()}
+	}
+	return areaResponse{
+		Shape:     echo,
+		Area:      s.Area(),
+		Perimeter: s.Perimeter(),
+		Bounds:    bboxOf(s.Bounds()),
+		Centroid:  xy(s.Centroid()),
+	}, nil
+}
+
+// --- POST /shapes/intersect ---
+
+type intersectRequest struct {
+	A json.RawMessage `json:"a"`
+	B json.RawMessage `json:"b"`
+}
+
+type collisionJSON struct {
+	Depth  float64    `json:"depth"`
+	Normal [2]float64 `json:"normal"` // 从 a 指向 b 的单位向量
+	MTV    [2]float64 `json:"mtv"`    // 把 b 平移 MTV 即可分离两个形状
+}
+
+type intersectResponse struct {
+	Intersects  bool           `json:"intersects"`
+	OverlapArea float64        `json:"overlap_area"`
+	Collision   *collisionJSON `json:"collision,omitempty"` // 只对凸形状计算，且仅在相交时给出
+}
+
+func handleIntersect(body []byte) (any, *apiError) {
+	var req intersectRequest
+	if apiErr := decodeBody(body, &req); apiErr != nil {
+		return nil, apiErr
+	}
+	a, apiErr := decodeShape("a", req.A)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	b, apiErr := decodeShape("b", req.B)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	overlap, err := geometry.Intersection(a, b)
+	switch {
+	case errors.Is(err, geometry.ErrDegeneratePolygon):
+		// 构造函数允许宽度为 0 的矩形、半径为 0 的圆，但它们没有内部，无法参与布尔运算
+		field := "b"
+		if a.Area() <= geometry.Epsilon {
+			field = "a"
+		}
+		return nil, badRequest("degenerate_shape", field, "面积为零的形状无法计算重叠区域: %v", err)
+	case errors.Is(err, geometry.ErrNotSimple):
+		return nil, badRequest("invalid_shape", "", "%v", err)
+	case err != nil:
+		return nil, &apiError{Status: http.StatusInternalServerError, Code: "internal", Message: err.Error()}
+	}
+	if !finite(overlap.Area()) {
+		return nil, overflowError("")
+	}
+	resp := intersectResponse{OverlapArea: overlap.Area(), Intersects: overlap.Area() > geometry.Epsilon}
+	// 碰撞检测还能识别边界接触，并给出分离所需的最小平移向量；不支持的形状组合 (如椭圆、凹多边形) 跳过
+	if c, err := geometry.Collide(a, b); err == nil && finite(c.Depth, c.MTV.X, c.MTV.Y, c.Normal.X, c.Normal.Y) {
+		resp.Intersects = c.Intersects
+		if c.Intersects {
+			resp.Collision = &collisionJSON{Depth: c.Depth, Normal: xy(c.Normal), MTV: xy(c.MTV)}
+		}
+	}
+	return resp, nil
+}
+
+// --- POST /shapes/transform ---
+
+// transformStep 是一步变换，op 决定使用哪些字段：
+//
+//	{"op": "translate", "x": 1, "y": 2}
+//	{"op": "rotate", "angle": 1.5708, "center": [0, 0]}   角度为弧度，逆时针为正，center 可省略
+//	{"op": "scale", "x": 2, "y": 2, "center": [0, 0]}     center 可省略
+//	{"op": "shear", "x": 0.5, "y": 0}
+//	{"op": "matrix", "matrix": [a, b, c, d, e, f]}        与 SVG matrix() 的参数顺序相同
+type transformStep struct {
+	Op     string      `json:"op"`
+	X      float64     `json:"x"`
+	Y      float64     `json:"y"`
+	Angle  float64     `json:"angle"`
+	Center *[2]float64 `json:"center"`
+	Matrix *[6]float64 `json:"matrix"`
+}
+
+type transformRequest struct {
+	Shape json.RawMessage `json:"shape"`
+	Steps []transformStep `json:"steps"`
+}
+
+type transformResponse struct {
+	Shape  json.RawMessage `json:"shape"`
+	Matrix [6]float64      `json:"matrix"` // 所有步骤组合后的矩阵
+}
+
+func (s transformStep) transform() (geometry.Transform, error) {
+	var c geometry.Point
+	if s.Center != nil {
+		c = geometry.Pt(s.Center[0], s.Center[1])
+	}
+	switch s.Op {
+	case "translate":
+		return geometry.Translate(s.X, s.Y), nil
+	case "rotate":
+		return geometry.RotateAbout(c, s.Angle), nil
+	case "scale":
+		return geometry.ScaleAbout(c, s.X, s.Y), nil
+	case "shear":
+		return geometry.Shear(s.X, s.Y), nil
+	case "matrix":
+		if s.Matrix == nil {
+			return geometry.Transform{}, errors.New(`缺少字段 "matrix"`)
+		}
+		m := s.Matrix
+		return geometry.Transform{A: m[0], B: m[1], C: m[2], D: m[3], E: m[4], F: m[5]}, nil
+	case "":
+		return geometry.Transform{}, errors.New(`缺少字段 "op"`)
+	default:
+		return geometry.Transform{}, fmt.Errorf("未知的变换 %q", s.Op)
+	}
+}
+
+func handleTransform(body []byte) (any, *apiError) {
+	var req transformRequest
+	if apiErr := decodeBody(body, &req); apiErr != nil {
+		return nil, apiErr
+	}
+	s, apiErr := decodeShape("shape", req.Shape)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	t := geometry.Identity()
+	for i, step := range req.Steps {
+		st, err := step.transform()
+		if err != nil {
+			return nil, badRequest("invalid_transform", fmt.Sprintf("steps[%d]", i), "%v", err)
+		}
+		t = t.Then(st)
+		if !finite(t.A, t.B, t.C, t.D, t.E, t.F) {
+			return nil, overflowError(fmt.Sprintf("steps[%d]", i))
+		}
+	}
+	out, err := t.ApplyShape(s)
+	if err != nil {
+		return nil, badRequest("invalid_transform", "steps", "%v", err)
+	}
+	// 矩阵有限时结果仍可能溢出，例如把 1e300 附近的坐标再放大 10 倍
+	if b := out.Bounds(); !finite(b.Min.X, b.Min.Y, b.Max.X, b.Max.Y) {
+		return nil, overflowError("steps")
+	}
+	encoded, err := geometry.MarshalShape(out)
+	if err != nil {
+		return nil, &apiError{Status: http.StatusInternalServerError, Code: "internal", Message: err.Error()}
+	}
+	return transformResponse{Shape: encoded, Matrix: [6]float64{t.A, t.B, t.C, t.D, t.E, t.F}}, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// do 向 newMux() 发送请求，返回状态码和解码后的响应体
+func do(t *testing.T, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	newMux().ServeHTTP(rec, req)
+	var out map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("%s %s: 响应不是 JSON: %v\n%s", method, path, err, rec.Body.String())
+	}
+	return rec.Code, out
+}
+
+// errorField 返回错误响应中的某个字段
+func errorField(resp map[string]any, key string) any {
+	e, _ := resp["error"].(map[string]any)
+	return e[key]
+}
+
+func TestHandlers(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   string // 错误码，成功时为空
+	}{
+		{"面积", "POST", "/shapes/area", `{"type":"circle","center":[0,0],"radius":2}`, 200, ""},
+		{"GeoJSON 面积", "POST", "/shapes/area", `{"type":"Polygon","coordinates":[[[0,0],[4,0],[4,3],[0,0]]]}`, 200, ""},
+		{"面积溢出", "POST", "/shapes/area", `{"type":"circle","radius":1e200}`, 400, "out_of_range"},
+		{"非法 JSON", "POST", "/shapes/area", `{"type":`, 400, "invalid_json"},
+		{"无效形状", "POST", "/shapes/area", `{"type":"circle","radius":-1}`, 400, "invalid_shape"},
+		{"方法不允许", "GET", "/shapes/area", ``, 405, "method_not_allowed"},
+		{"路径不存在", "POST", "/shapes/volume", `{}`, 404, "not_found"},
+		{"相交",
+			"POST", "/shapes/intersect",
+			`{"a":{"type":"rectangle","width":4,"height":4},"b":{"type":"rectangle","origin":[2,2],"width":4,"height":4}}`,
+			200, ""},
+		{"零宽矩形",
+			"POST", "/shapes/intersect",
+			`{"a":{"type":"rectangle","width":0,"height":5},"b":{"type":"rectangle","width":4,"height":4}}`,
+			400, "degenerate_shape"},
+		{"缺少字段", "POST", "/shapes/intersect", `{"a":{"type":"circle","radius":1}}`, 400, "missing_field"},
+		{"变换",
+			"POST", "/shapes/transform",
+			`{"shape":{"type":"rectangle","width":2,"height":1},"steps":[{"op":"translate","x":1,"y":1},{"op":"scale","x":2,"y":2}]}`,
+			200, ""},
+		{"变换溢出",
+			"POST", "/shapes/transform",
+			`{"shape":{"type":"circle","radius":1},"steps":[{"op":"scale","x":1e308,"y":1e308},{"op":"scale","x":10,"y":10}]}`,
+			400, "out_of_range"},
+		{"坐标溢出",
+			"POST", "/shapes/transform",
+			`{"shape":{"type":"rectangle","origin":[1e308,0],"width":1,"height":1},"steps":[{"op":"scale","x":10,"y":1}]}`,
+			400, "out_of_range"},
+		{"未知变换", "POST", "/shapes/transform", `{"shape":{"type":"circle","radius":1},"steps":[{"op":"spin"}]}`, 400, "invalid_transform"},
+		{"奇异矩阵", "POST", "/shapes/transform", `{"shape":{"type":"circle","radius":1},"steps":[{"op":"scale","x":0,"y":1}]}`, 400, "invalid_transform"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := do(t, tt.method, tt.path, tt.body)
+			if status != tt.wantStatus {
+				t.Fatalf("状态码 = %d, want %d, 响应 %v", status, tt.wantStatus, resp)
+			}
+			if code, _ := errorField(resp, "code").(string); code != tt.wantCode {
+				t.Errorf("错误码 = %q, want %q, 响应 %v", code, tt.wantCode, resp)
+			}
+		})
+	}
+}
+
+func TestHandlerResults(t *testing.T) {
+	_, resp := do(t, "POST", "/shapes/area", `{"type":"circle","center":[0,0],"radius":2}`)
+	if area := resp["area"].(float64); math.Abs(area-4*math.Pi) > 1e-9 {
+		t.Errorf("圆的面积 = %v, want %v", area, 4*math.Pi)
+	}
+
+	_, resp = do(t, "POST", "/shapes/intersect",
+		`{"a":{"type":"rectangle","width":4,"height":4},"b":{"type":"rectangle","origin":[2,2],"width":4,"height":4}}`)
+	if resp["intersects"] != true || math.Abs(resp["overlap_area"].(float64)-4) > 1e-9 {
+		t.Errorf("intersect 响应 = %v, want 相交且重叠面积为 4", resp)
+	}
+
+	_, resp = do(t, "POST", "/shapes/intersect",
+		`{"a":{"type":"rectangle","width":4,"height":4},"b":{"type":"circle","center":[2,2],"radius":0}}`)
+	if errorField(resp, "field") != "b" {
+		t.Errorf("零半径的圆应该报告字段 b, 响应 %v", resp)
+	}
+
+	// 带洞的 GeoJSON 多边形解码为 Region，回显和变换结果都按 GeoJSON 编码
+	const holed = `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[2,4],[4,4],[4,2],[2,2]]]}`
+	status, resp := do(t, "POST", "/shapes/area", holed)
+	if status != 200 || math.Abs(resp["area"].(float64)-96) > 1e-9 {
+		t.Fatalf("带洞多边形的面积响应 = %d %v, want 200 且面积为 96", status, resp)
+	}
+	if rings := resp["shape"].(map[string]any)["coordinates"].([]any); len(rings) != 2 {
+		t.Errorf("回显的形状应该有 2 个环, 得到 %v", resp["shape"])
+	}
+	status, resp = do(t, "POST", "/shapes/transform", `{"shape":`+holed+`,"steps":[{"op":"translate","x":1,"y":0}]}`)
+	if status != 200 {
+		t.Fatalf("变换带洞多边形的状态码 = %d, 响应 %v", status, resp)
+	}
+	shape := resp["shape"].(map[string]any)
+	hole := shape["coordinates"].([]any)[1].([]any)
+	if shape["type"] != "Polygon" || len(hole) != 5 || hole[0].([]any)[0].(float64) < 3 {
+		t.Errorf("变换后的形状 = %v, want 向右平移 1 的带洞 Polygon", shape)
+	}
+
+	_, resp = do(t, "POST", "/shapes/transform",
+		`{"shape":{"type":"rectangle","width":2,"height":1},"steps":[{"op":"translate","x":1,"y":1},{"op":"scale","x":2,"y":2}]}`)
+	shape = resp["shape"].(map[string]any)
+	if shape["type"] != "rectangle" || shape["width"] != 4.0 || shape["height"] != 2.0 {
+		t.Errorf("变换后的形状 = %v, want 4x2 的矩形", shape)
+	}
+	if origin := shape["origin"].([]any); origin[0] != 2.0 || origin[1] != 2.0 {
+		t.Errorf("变换后的原点 = %v, want [2 2]", origin)
+	}
+}
@@ -0,0 +1,76 @@
+// 命令 geometry_service 把 week3 的 geometry 包以 HTTP JSON 接口的形式提供给其它服务调用。
+//
+// 接口 (请求和响应都是 JSON，形状使用 geometry.MarshalShape 的格式):
+//
+//	POST /shapes/area       计算面积、周长、外接框和质心
+//	POST /shapes/intersect  判断两个形状是否相交，并计算重叠面积和最小平移向量
+//	POST /shapes/transform  对形状依次应用平移、旋转、缩放等仿射变换
+//
+// 示例:
+//
+//	curl -X POST localhost:8080/shapes/area -d '{"type":"circle","center":[0,0],"radius":2}'
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "监听地址")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           logRequests(newMux()),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	// 收到 Ctrl+C 或 SIGTERM 时优雅关闭：不再接受新连接，等待正在处理的请求完成
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("关闭服务器时出错: %v", err)
+		}
+	}()
+
+	log.Printf("geometry 服务正在监听 %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("ListenAndServe 错误: %v", err)
+	}
+	log.Println("服务器已停止。")
+}
+
+// statusRecorder 记录处理器写入的状态码，供日志中间件使用
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// logRequests 是一个简单的日志中间件，记录每个请求的方法、路径、状态码和耗时
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		log.Printf("%s %s | %d | %v", r.Method, r.URL.Path, rec.status, time.Since(start))
+	})
+}